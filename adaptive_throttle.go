@@ -0,0 +1,94 @@
+package cloudwatch
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// maxThrottleFactor bounds how much slower than its flush interval a
+	// throttled writer flushes.
+	maxThrottleFactor = 10
+
+	// throttleRecovery is the factor by which the flush rate increases after
+	// each successful flush, until it's back to the flush interval.
+	throttleRecovery = 1.1
+)
+
+// adaptiveThrottle slows down flushes when AWS CloudWatch Logs throttles
+// requests, halving the flush rate every time, and speeds them up again
+// gradually as flushes succeed.
+type adaptiveThrottle struct {
+	mu                 sync.Mutex // This protects the fields below.
+	min, max, interval time.Duration
+}
+
+func newAdaptiveThrottle(interval time.Duration) *adaptiveThrottle {
+	return &adaptiveThrottle{min: interval, max: maxThrottleFactor * interval, interval: interval}
+}
+
+// throttled records a throttled request, and returns the new interval between
+// flushes.
+func (t *adaptiveThrottle) throttled() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.interval *= 2; t.interval > t.max {
+		t.interval = t.max
+	}
+	return t.interval
+}
+
+// succeeded records a successful flush, and returns the new interval between
+// flushes.
+func (t *adaptiveThrottle) succeeded() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.interval = time.Duration(float64(t.interval) / throttleRecovery); t.interval < t.min {
+		t.interval = t.min
+	}
+	return t.interval
+}
+
+func (t *adaptiveThrottle) getInterval() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.interval
+}
+
+// CurrentFlushRate returns the number of flushes per second the writer is
+// currently limited to. It's lower than the rate set using WithFlushInterval
+// after AWS CloudWatch Logs throttled requests.
+func (w *writerImpl) CurrentFlushRate() float64 {
+	interval := w.flushInterval
+	if w.adaptive != nil {
+		interval = w.adaptive.getInterval()
+	}
+
+	if interval <= 0 {
+		return 0
+	}
+	return float64(time.Second) / float64(interval)
+}
+
+// adaptThrottle records the outcome of a flush, adjusting the interval between
+// flushes if it changed. The caller must hold the lock.
+func (w *writerImpl) adaptThrottle(throttled bool) {
+	if w.adaptive == nil {
+		return
+	}
+
+	before := w.adaptive.getInterval()
+
+	var after time.Duration
+	if throttled {
+		after = w.adaptive.throttled()
+	} else {
+		after = w.adaptive.succeeded()
+	}
+
+	if after != before && w.throttle != nil {
+		w.throttle.Reset(after)
+	}
+}
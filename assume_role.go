@@ -0,0 +1,185 @@
+package cloudwatch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/pkg/errors"
+)
+
+const (
+	assumeRoleProviderName = "CloudWatchAssumeRoleProvider"
+
+	// The interval between two attempts to refresh the credentials of an
+	// assumed role which are still valid, after one failed.
+	assumeRoleRetryInterval = 10 * time.Second
+)
+
+// newSTSClient and newLogsClient build the clients used to assume a role. They
+// are variables so that they can be stubbed out in unit tests.
+var (
+	newSTSClient = func(cfg *aws.Config) (stsiface.STSAPI, error) {
+		sess, err := session.NewSession(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return sts.New(sess), nil
+	}
+
+	newLogsClient = func(cfg *aws.Config) (iface.CloudWatchLogsAPI, error) {
+		sess, err := session.NewSession(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return cloudwatchlogs.New(sess), nil
+	}
+)
+
+type assumeRoleConfig struct {
+	roleARN     string
+	sessionName string
+	duration    time.Duration
+}
+
+// WithAssumeRole makes the group use temporary credentials of the IAM role
+// roleARN, typically in a central logging account, instead of those of the
+// client passed to NewGroup. The role is assumed using sts:AssumeRole when the
+// group is created, with the client's configuration if it's a
+// *cloudwatchlogs.CloudWatchLogs, and the credentials are refreshed before
+// they expire, when a request next needs them. duration is
+// how long they're valid for, between 15 minutes and the role's maximum
+// session duration, or the role's default if 0.
+//
+// The identity of the client must be allowed the sts:AssumeRole action on
+// roleARN, and the role's trust policy must allow that identity to assume it:
+//
+//	{
+//	  "Effect": "Allow",
+//	  "Principal": {"AWS": "arn:aws:iam::<application account>:root"},
+//	  "Action": "sts:AssumeRole"
+//	}
+//
+// If the role can't be assumed when the group is created, the error is logged
+// and assuming it is retried when the credentials are first needed. If the STS
+// or CloudWatch Logs client can't be built, NewGroupFromConfig returns the
+// error, and every request of a group created by NewGroup returns it, rather
+// than using the credentials of the client passed to NewGroup.
+func WithAssumeRole(roleARN, sessionName string, duration time.Duration) GroupOption {
+	return func(g *groupImpl) {
+		g.assumeRole = &assumeRoleConfig{roleARN: roleARN, sessionName: sessionName, duration: duration}
+	}
+}
+
+// useAssumedRole replaces the client of the group by one using the credentials
+// of the assumed role. If either client can't be built, the group's client is
+// left unchanged and an error is returned.
+func (g *groupImpl) useAssumedRole() error {
+	cfg := aws.NewConfig()
+	if c, ok := g.CloudWatchLogsAPI.(*cloudwatchlogs.CloudWatchLogs); ok {
+		cfg = c.Config.Copy()
+	}
+
+	// The endpoint of the CloudWatch Logs client isn't one of STS.
+	stsConfig := g.httpConfig(cfg)
+	stsConfig.Endpoint = nil
+
+	stsClient, err := newSTSClient(stsConfig)
+	if err != nil {
+		return errors.Wrapf(err, "could not create an STS client to assume role %s", g.assumeRole.roleARN)
+	}
+
+	input := &sts.AssumeRoleInput{
+		RoleArn:         aws.String(g.assumeRole.roleARN),
+		RoleSessionName: aws.String(g.assumeRole.sessionName),
+	}
+	if g.assumeRole.duration > 0 {
+		input.DurationSeconds = aws.Int64(int64(g.assumeRole.duration / time.Second))
+	}
+
+	provider := &assumeRoleProvider{client: stsClient, input: input, logger: g.logger}
+	if err := provider.refresh(); err != nil {
+		g.logger.Error("could not assume role", "role", g.assumeRole.roleARN, "error", err)
+	}
+
+	client, err := newLogsClient(g.clientConfig(cfg).WithCredentials(credentials.NewCredentials(provider)))
+	if err != nil {
+		return errors.Wrapf(err, "could not create a CloudWatch Logs client for role %s", g.assumeRole.roleARN)
+	}
+
+	g.CloudWatchLogsAPI = client
+	return nil
+}
+
+// assumeRoleProvider is a credentials.Provider returning the credentials of an
+// assumed role. They're refreshed when they're next needed once 80% of their
+// lifetime has elapsed, so that requests never use expired ones.
+type assumeRoleProvider struct {
+	client stsiface.STSAPI
+	input  *sts.AssumeRoleInput
+	logger GroupLogger
+
+	mu        sync.Mutex // This protects the fields below.
+	current   *sts.Credentials
+	refreshAt time.Time
+}
+
+func (p *assumeRoleProvider) Retrieve() (credentials.Value, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current == nil || !time.Now().Before(p.refreshAt) {
+		if err := p.refreshLocked(); err != nil {
+			if p.current == nil || !time.Now().Before(aws.TimeValue(p.current.Expiration)) {
+				return credentials.Value{ProviderName: assumeRoleProviderName}, err
+			}
+
+			// The current credentials are still valid, so they're used
+			// until the next attempt.
+			p.logger.Error("could not refresh the assumed role credentials", "role", aws.StringValue(p.input.RoleArn), "error", err)
+			p.refreshAt = time.Now().Add(assumeRoleRetryInterval)
+		}
+	}
+
+	return credentials.Value{
+		AccessKeyID:     aws.StringValue(p.current.AccessKeyId),
+		SecretAccessKey: aws.StringValue(p.current.SecretAccessKey),
+		SessionToken:    aws.StringValue(p.current.SessionToken),
+		ProviderName:    assumeRoleProviderName,
+	}, nil
+}
+
+// IsExpired reports whether the credentials last retrieved must be retrieved
+// again, because they're due to be refreshed.
+func (p *assumeRoleProvider) IsExpired() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.current == nil || !time.Now().Before(p.refreshAt)
+}
+
+func (p *assumeRoleProvider) refresh() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.refreshLocked()
+}
+
+// refreshLocked assumes the role again. The caller must hold the lock.
+func (p *assumeRoleProvider) refreshLocked() error {
+	resp, err := p.client.AssumeRoleWithContext(context.Background(), p.input)
+	if err != nil {
+		return errors.Wrap(err, "could not assume role")
+	}
+
+	p.current = resp.Credentials
+	p.refreshAt = time.Now().Add(time.Until(aws.TimeValue(p.current.Expiration)) * 4 / 5)
+	p.logger.Info("assumed role", "role", aws.StringValue(p.input.RoleArn), "expiration", aws.TimeValue(p.current.Expiration))
+	return nil
+}
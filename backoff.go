@@ -0,0 +1,45 @@
+package cloudwatch
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultBaseDelay  = 100 * time.Millisecond
+	defaultMaxDelay   = 30 * time.Second
+	defaultMaxRetries = 5
+)
+
+// backoff describes an exponential backoff schedule with full jitter, as
+// described in https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/.
+type backoff struct {
+	baseDelay, maxDelay time.Duration
+	maxRetries          int
+}
+
+func newBackoff() backoff {
+	return backoff{
+		baseDelay:  defaultBaseDelay,
+		maxDelay:   defaultMaxDelay,
+		maxRetries: defaultMaxRetries,
+	}
+}
+
+// delay returns a random duration to wait before the given retry attempt,
+// counting from zero. The upper bound doubles with each attempt, starting at
+// baseDelay and capped at maxDelay.
+func (b backoff) delay(attempt int) time.Duration {
+	ceiling := b.maxDelay
+	if attempt < 32 {
+		if d := b.baseDelay << uint(attempt); d > 0 && d < ceiling {
+			ceiling = d
+		}
+	}
+
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
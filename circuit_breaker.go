@@ -0,0 +1,204 @@
+package cloudwatch
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+)
+
+// ErrCircuitOpen is returned from Flush while the writer's circuit breaker is
+// open, in which case no request is made to AWS CloudWatch Logs.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a writer's circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed is the normal state, in which events are sent.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen is the state after too many consecutive failed flushes, in
+	// which no events are sent until a probe is due.
+	CircuitOpen
+
+	// CircuitHalfOpen is the state during a probe flush.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreakerWriter is the part of Writer reporting the state of the
+// circuit breaker set using WithCircuitBreaker.
+type CircuitBreakerWriter interface {
+	WriteFlushCloser
+
+	// State returns the current state of the circuit breaker.
+	State() CircuitState
+}
+
+// CircuitStateChange is sent to the error channel set using WithErrorChannel
+// whenever the circuit breaker changes state.
+type CircuitStateChange struct {
+	From, To CircuitState
+
+	// Err is the flush error which caused the transition, if any.
+	Err error
+}
+
+func (e *CircuitStateChange) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("circuit breaker %s", e.To)
+	}
+	return fmt.Sprintf("circuit breaker %s: %v", e.To, e.Err)
+}
+
+// WithCircuitBreaker stops the writer from sending events after threshold
+// consecutive flushes failed. While the circuit is open, new events are dropped
+// unless WithBufferCapacity is used, in which case the buffer full policy
+// applies once the buffer is full, or WithEventBuffer is used with a buffer
+// other than NewMemoryEventBuffer, which keeps them according to its own
+// capacity. After resetAfter, a single probe flush is attempted: if it
+// succeeds the circuit closes, otherwise it stays open for another resetAfter.
+//
+// Flush errors do not fail the writer permanently when using a circuit
+// breaker, and unsent events are kept in the buffer. State transitions are sent
+// to the error channel as a *CircuitStateChange, and the current state is
+// returned by Writer.State.
+func WithCircuitBreaker(threshold int, resetAfter time.Duration) CreateOption {
+	return func(w *writerImpl) {
+		w.breaker = &circuitBreaker{threshold: threshold, resetAfter: resetAfter}
+	}
+}
+
+type circuitBreaker struct {
+	threshold  int
+	resetAfter time.Duration
+
+	mu       sync.Mutex // This protects the fields below.
+	state    CircuitState
+	failures int
+	openedAt time.Time
+}
+
+// allow reports whether a flush may be attempted at now, moving from the open
+// to the half-open state if a probe is due.
+func (b *circuitBreaker) allow(now time.Time) (bool, *CircuitStateChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != CircuitOpen {
+		return true, nil
+	}
+
+	if now.Sub(b.openedAt) < b.resetAfter {
+		return false, nil
+	}
+
+	return true, b.transition(CircuitHalfOpen, nil)
+}
+
+// record records the outcome of a flush at now.
+func (b *circuitBreaker) record(err error, now time.Time) *CircuitStateChange {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		if b.state == CircuitClosed {
+			return nil
+		}
+		return b.transition(CircuitClosed, nil)
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.threshold) {
+		b.openedAt = now
+		return b.transition(CircuitOpen, err)
+	}
+
+	return nil
+}
+
+// transition moves to the given state. The caller must hold the lock.
+func (b *circuitBreaker) transition(to CircuitState, err error) *CircuitStateChange {
+	ret := &CircuitStateChange{From: b.state, To: to, Err: err}
+	b.state = to
+	return ret
+}
+
+func (b *circuitBreaker) getState() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// State returns the state of the writer's circuit breaker, which is always
+// closed without WithCircuitBreaker.
+func (w *writerImpl) State() CircuitState {
+	if w.breaker == nil {
+		return CircuitClosed
+	}
+	return w.breaker.getState()
+}
+
+// dropping reports whether new events are dropped because the circuit is open,
+// which is only the case if they'd be added to an unbounded in-memory buffer.
+func (w *writerImpl) dropping() bool {
+	if w.breaker == nil {
+		return false
+	}
+	if b, ok := w.buffered().(*eventsBuffer); !ok || b.capacity > 0 {
+		return false
+	}
+	return w.breaker.getState() == CircuitOpen
+}
+
+// allowFlush reports whether the circuit breaker allows flushing.
+func (w *writerImpl) allowFlush() bool {
+	if w.breaker == nil {
+		return true
+	}
+
+	ok, change := w.breaker.allow(w.now())
+	if change != nil {
+		w.reportStateChange(change)
+	}
+	return ok
+}
+
+// recordFlush records the outcome of flushing a batch with the circuit
+// breaker.
+func (w *writerImpl) recordFlush(err error) {
+	if w.breaker == nil {
+		return
+	}
+
+	if change := w.breaker.record(err, w.now()); change != nil {
+		w.reportStateChange(change)
+	}
+}
+
+// reportStateChange logs a transition of the circuit breaker and sends it to
+// the error channel.
+func (w *writerImpl) reportStateChange(change *CircuitStateChange) {
+	fields := []interface{}{"stream", aws.StringValue(w.streamName), "from", change.From, "to", change.To}
+	if change.Err != nil {
+		fields = append(fields, "error", change.Err)
+	}
+	w.log().Warn("circuit breaker state changed", fields...)
+
+	w.reportError(change)
+}
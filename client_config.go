@@ -0,0 +1,98 @@
+package cloudwatch
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+)
+
+// WithHTTPClient makes the CloudWatch Logs and STS clients send requests using
+// c, for example to go through a proxy or present a client certificate. It's
+// only used when the group builds the clients, that is by NewGroupFromConfig,
+// or with WithAssumeRole. Otherwise, the client passed to NewGroup was already
+// built, so creating writers and reading events return ErrClientOptions. c
+// isn't modified, the clients use a copy of it.
+func WithHTTPClient(c *http.Client) GroupOption {
+	return func(g *groupImpl) {
+		g.httpClient = c
+	}
+}
+
+// WithHTTPTransport makes the CloudWatch Logs client send requests using t,
+// for example to trace them, keeping the other settings of the HTTP client.
+// Like WithHTTPClient, it's only used when the group builds the client.
+func WithHTTPTransport(t http.RoundTripper) GroupOption {
+	return func(g *groupImpl) {
+		g.httpTransport = t
+	}
+}
+
+// WithEndpointURL sends the requests of the CloudWatch Logs client to rawURL
+// rather than to the public endpoint of the region, for example to use a VPC
+// endpoint or LocalStack. Like WithHTTPClient, it's only used when the group
+// builds the client. If rawURL isn't an absolute URL, NewGroupFromConfig
+// returns an error.
+//
+// With WithAssumeRole, the STS requests still go to the public STS endpoint,
+// unless the AWS_STS_REGIONAL_ENDPOINTS environment variable or the
+// sts_regional_endpoints shared config setting says otherwise.
+func WithEndpointURL(rawURL string) GroupOption {
+	u, err := url.Parse(rawURL)
+	if err == nil && (u.Scheme == "" || u.Host == "") {
+		err = errors.New("missing scheme or host")
+	}
+
+	return func(g *groupImpl) {
+		if err != nil {
+			g.optionErr = errors.Wrapf(err, "invalid endpoint URL %q", rawURL)
+			return
+		}
+		g.endpointURL = aws.String(rawURL)
+	}
+}
+
+// hasClientOptions reports whether options only used when the group builds the
+// client were set.
+func (g *groupImpl) hasClientOptions() bool {
+	return g.configuredHTTPClient() != nil || g.endpointURL != nil
+}
+
+// configuredHTTPClient returns a copy of the HTTP client set using
+// WithHTTPClient and WithHTTPTransport, or nil if neither was used. The SDK may
+// modify the client it's given, for example to load a custom CA bundle, which
+// mustn't affect the caller's client.
+func (g *groupImpl) configuredHTTPClient() *http.Client {
+	if g.httpClient == nil && g.httpTransport == nil {
+		return nil
+	}
+
+	ret := new(http.Client)
+	if g.httpClient != nil {
+		*ret = *g.httpClient
+	}
+	if g.httpTransport != nil {
+		ret.Transport = g.httpTransport
+	}
+	return ret
+}
+
+// httpConfig returns a copy of cfg using the configured HTTP client, if any.
+func (g *groupImpl) httpConfig(cfg *aws.Config) *aws.Config {
+	cfg = cfg.Copy()
+	if c := g.configuredHTTPClient(); c != nil {
+		cfg.HTTPClient = c
+	}
+	return cfg
+}
+
+// clientConfig returns a copy of cfg using the configured HTTP client and
+// endpoint, if any, for the CloudWatch Logs client.
+func (g *groupImpl) clientConfig(cfg *aws.Config) *aws.Config {
+	cfg = g.httpConfig(cfg)
+	if g.endpointURL != nil {
+		cfg.Endpoint = g.endpointURL
+	}
+	return cfg
+}
@@ -0,0 +1,140 @@
+// Package cloudwatchanomalies manages CloudWatch Logs anomaly detectors for the
+// log group of a cloudwatch.Group.
+//
+// An anomaly detector learns the patterns of a log group from its past events
+// before reporting anomalies: it trains on up to two weeks of existing events
+// when it's created, then keeps updating its baseline. Groups need a steady
+// volume of events, ideally several days' worth covering their usual traffic,
+// before the results are meaningful. Until then most patterns look new and are
+// reported as anomalies, or nothing is reported at all if too few events were
+// seen during the evaluation period.
+package cloudwatchanomalies
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+// Group is a cloudwatch.Group which can detect anomalies in its log group.
+type Group struct {
+	cloudwatch.Group
+}
+
+// AnomalyDetectorOptions configures an anomaly detector. Zero values use the
+// defaults of the API.
+type AnomalyDetectorOptions struct {
+	// DetectorName is the name of the detector.
+	DetectorName string
+
+	// EvaluationFrequency is how often the detector looks for anomalies, one
+	// of the cloudwatchlogs.EvaluationFrequency constants.
+	EvaluationFrequency string
+
+	// FilterPattern restricts the log events the detector looks at.
+	FilterPattern string
+
+	// AnomalyVisibilityTime is the number of days an anomaly is still
+	// reported after it was last seen, from 7 to 90.
+	AnomalyVisibilityTime int64
+
+	// KMSKeyID is the ARN of the KMS key used to encrypt the anomalies.
+	KMSKeyID string
+}
+
+// NewGroup returns a Group detecting anomalies in the log group of g.
+func NewGroup(g cloudwatch.Group) *Group {
+	return &Group{Group: g}
+}
+
+// CreateAnomalyDetector creates an anomaly detector for the log group, and
+// returns its ARN. It requires the logs:CreateLogAnomalyDetector and
+// logs:DescribeLogGroups IAM permissions.
+func (g *Group) CreateAnomalyDetector(ctx context.Context, opts AnomalyDetectorOptions) (string, error) {
+	group, err := g.Describe(ctx)
+	if err != nil {
+		return "", err
+	}
+	if group.Arn == nil {
+		return "", cloudwatch.ErrNotFound
+	}
+
+	// DescribeLogGroups returns the ARN of the group's streams, ending with
+	// ":*".
+	groupARN := strings.TrimSuffix(*group.Arn, ":*")
+
+	input := &cloudwatchlogs.CreateLogAnomalyDetectorInput{
+		LogGroupArnList: aws.StringSlice([]string{groupARN}),
+	}
+	if opts.DetectorName != "" {
+		input.DetectorName = aws.String(opts.DetectorName)
+	}
+	if opts.EvaluationFrequency != "" {
+		input.EvaluationFrequency = aws.String(opts.EvaluationFrequency)
+	}
+	if opts.FilterPattern != "" {
+		input.FilterPattern = aws.String(opts.FilterPattern)
+	}
+	if opts.AnomalyVisibilityTime != 0 {
+		input.AnomalyVisibilityTime = aws.Int64(opts.AnomalyVisibilityTime)
+	}
+	if opts.KMSKeyID != "" {
+		input.KmsKeyId = aws.String(opts.KMSKeyID)
+	}
+
+	resp, err := g.CreateLogAnomalyDetectorWithContext(ctx, input)
+	if err != nil {
+		return "", errors.Wrap(err, "could not create the anomaly detector")
+	}
+	return aws.StringValue(resp.AnomalyDetectorArn), nil
+}
+
+// ListAnomalies returns all anomalies found by the detector with the ARN
+// detectorARN, suppressed or not. It requires the logs:ListAnomalies IAM
+// permission.
+func (g *Group) ListAnomalies(ctx context.Context, detectorARN string) ([]cloudwatchlogs.Anomaly, error) {
+	input := &cloudwatchlogs.ListAnomaliesInput{
+		AnomalyDetectorArn: aws.String(detectorARN),
+	}
+
+	var anomalies []cloudwatchlogs.Anomaly
+	for {
+		resp, err := g.ListAnomaliesWithContext(ctx, input)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list the anomalies")
+		}
+
+		for _, anomaly := range resp.Anomalies {
+			anomalies = append(anomalies, *anomaly)
+		}
+
+		if resp.NextToken == nil {
+			return anomalies, nil
+		}
+		input.NextToken = resp.NextToken
+	}
+}
+
+// UpdateAnomaly suppresses the anomaly with the ID anomalyID found by the
+// detector with the ARN detectorARN if suppress is true, so that it's no
+// longer reported, and stops suppressing it otherwise. Suppression doesn't
+// expire. It requires the logs:UpdateAnomaly IAM permission.
+func (g *Group) UpdateAnomaly(ctx context.Context, anomalyID, detectorARN string, suppress bool) error {
+	input := &cloudwatchlogs.UpdateAnomalyInput{
+		AnomalyDetectorArn: aws.String(detectorARN),
+		AnomalyId:          aws.String(anomalyID),
+	}
+	if suppress {
+		input.SuppressionType = aws.String(cloudwatchlogs.SuppressionTypeInfinite)
+	}
+
+	if _, err := g.UpdateAnomalyWithContext(ctx, input); err != nil {
+		return errors.Wrap(err, "could not update the anomaly")
+	}
+	return nil
+}
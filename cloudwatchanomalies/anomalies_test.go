@@ -0,0 +1,182 @@
+package cloudwatchanomalies
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+const detectorARN = "arn:aws:logs:eu-west-1:123456789012:anomaly-detector:detector"
+
+type mockAPI struct {
+	mock.Mock
+	iface.CloudWatchLogsAPI
+}
+
+func (m *mockAPI) DescribeLogGroupsWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeLogGroupsInput, opts ...request.Option) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DescribeLogGroupsOutput), args.Error(1)
+}
+
+func (m *mockAPI) CreateLogAnomalyDetectorWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogAnomalyDetectorInput, opts ...request.Option) (*cloudwatchlogs.CreateLogAnomalyDetectorOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.CreateLogAnomalyDetectorOutput), args.Error(1)
+}
+
+func (m *mockAPI) ListAnomaliesWithContext(ctx aws.Context, input *cloudwatchlogs.ListAnomaliesInput, opts ...request.Option) (*cloudwatchlogs.ListAnomaliesOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.ListAnomaliesOutput), args.Error(1)
+}
+
+func (m *mockAPI) UpdateAnomalyWithContext(ctx aws.Context, input *cloudwatchlogs.UpdateAnomalyInput, opts ...request.Option) (*cloudwatchlogs.UpdateAnomalyOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.UpdateAnomalyOutput), args.Error(1)
+}
+
+type anomaliesTestSuite struct {
+	suite.Suite
+
+	api *mockAPI
+	ctx context.Context
+	sut *Group
+}
+
+func (a *anomaliesTestSuite) SetupTest() {
+	a.api = new(mockAPI)
+	a.ctx = context.Background()
+	a.sut = NewGroup(cloudwatch.NewGroup(a.api, "groupName"))
+}
+
+func (a *anomaliesTestSuite) TestCreateAnomalyDetector() {
+	a.describingGroupsReturns(&cloudwatchlogs.LogGroup{
+		LogGroupName: aws.String("groupName"),
+		Arn:          aws.String("arn:aws:logs:eu-west-1:123456789012:log-group:groupName:*"),
+	})
+	a.api.On(
+		"CreateLogAnomalyDetectorWithContext",
+		a.ctx,
+		&cloudwatchlogs.CreateLogAnomalyDetectorInput{
+			LogGroupArnList:     aws.StringSlice([]string{"arn:aws:logs:eu-west-1:123456789012:log-group:groupName"}),
+			DetectorName:        aws.String("detector"),
+			EvaluationFrequency: aws.String(cloudwatchlogs.EvaluationFrequencyFifteenMin),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.CreateLogAnomalyDetectorOutput{AnomalyDetectorArn: aws.String(detectorARN)}, nil).Once()
+
+	arn, err := a.sut.CreateAnomalyDetector(a.ctx, AnomalyDetectorOptions{
+		DetectorName:        "detector",
+		EvaluationFrequency: cloudwatchlogs.EvaluationFrequencyFifteenMin,
+	})
+	a.Require().NoError(err)
+	a.Equal(detectorARN, arn)
+	a.api.AssertExpectations(a.T())
+}
+
+func (a *anomaliesTestSuite) TestCreateAnomalyDetector_GroupNotFound() {
+	a.describingGroupsReturns(&cloudwatchlogs.LogGroup{LogGroupName: aws.String("groupNameSuffix")})
+
+	_, err := a.sut.CreateAnomalyDetector(a.ctx, AnomalyDetectorOptions{})
+	a.Equal(cloudwatch.ErrNotFound, err)
+}
+
+func (a *anomaliesTestSuite) TestCreateAnomalyDetector_Error() {
+	a.describingGroupsReturns(&cloudwatchlogs.LogGroup{
+		LogGroupName: aws.String("groupName"),
+		Arn:          aws.String("arn:aws:logs:eu-west-1:123456789012:log-group:groupName:*"),
+	})
+	a.api.On("CreateLogAnomalyDetectorWithContext", a.ctx, mock.Anything, []request.Option(nil)).
+		Return((*cloudwatchlogs.CreateLogAnomalyDetectorOutput)(nil), errors.New("boom")).Once()
+
+	_, err := a.sut.CreateAnomalyDetector(a.ctx, AnomalyDetectorOptions{})
+	a.EqualError(err, "could not create the anomaly detector: boom")
+}
+
+func (a *anomaliesTestSuite) TestListAnomalies() {
+	a.api.On(
+		"ListAnomaliesWithContext",
+		a.ctx,
+		&cloudwatchlogs.ListAnomaliesInput{AnomalyDetectorArn: aws.String(detectorARN)},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.ListAnomaliesOutput{
+		Anomalies: []*cloudwatchlogs.Anomaly{{AnomalyId: aws.String("first")}},
+		NextToken: aws.String("token"),
+	}, nil).Once()
+	a.api.On(
+		"ListAnomaliesWithContext",
+		a.ctx,
+		&cloudwatchlogs.ListAnomaliesInput{AnomalyDetectorArn: aws.String(detectorARN), NextToken: aws.String("token")},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.ListAnomaliesOutput{
+		Anomalies: []*cloudwatchlogs.Anomaly{{AnomalyId: aws.String("second")}},
+	}, nil).Once()
+
+	anomalies, err := a.sut.ListAnomalies(a.ctx, detectorARN)
+	a.Require().NoError(err)
+	a.Equal([]cloudwatchlogs.Anomaly{
+		{AnomalyId: aws.String("first")},
+		{AnomalyId: aws.String("second")},
+	}, anomalies)
+	a.api.AssertExpectations(a.T())
+}
+
+func (a *anomaliesTestSuite) TestListAnomalies_Error() {
+	a.api.On("ListAnomaliesWithContext", a.ctx, mock.Anything, []request.Option(nil)).
+		Return((*cloudwatchlogs.ListAnomaliesOutput)(nil), errors.New("boom")).Once()
+
+	_, err := a.sut.ListAnomalies(a.ctx, detectorARN)
+	a.EqualError(err, "could not list the anomalies: boom")
+}
+
+func (a *anomaliesTestSuite) TestUpdateAnomaly() {
+	a.updatingAnomalyReturns(&cloudwatchlogs.UpdateAnomalyInput{
+		AnomalyDetectorArn: aws.String(detectorARN),
+		AnomalyId:          aws.String("anomalyID"),
+		SuppressionType:    aws.String(cloudwatchlogs.SuppressionTypeInfinite),
+	}, nil)
+	a.Require().NoError(a.sut.UpdateAnomaly(a.ctx, "anomalyID", detectorARN, true))
+
+	a.updatingAnomalyReturns(&cloudwatchlogs.UpdateAnomalyInput{
+		AnomalyDetectorArn: aws.String(detectorARN),
+		AnomalyId:          aws.String("anomalyID"),
+	}, nil)
+	a.Require().NoError(a.sut.UpdateAnomaly(a.ctx, "anomalyID", detectorARN, false))
+
+	a.api.AssertExpectations(a.T())
+}
+
+func (a *anomaliesTestSuite) TestUpdateAnomaly_Error() {
+	a.updatingAnomalyReturns(&cloudwatchlogs.UpdateAnomalyInput{
+		AnomalyDetectorArn: aws.String(detectorARN),
+		AnomalyId:          aws.String("anomalyID"),
+	}, errors.New("boom"))
+
+	err := a.sut.UpdateAnomaly(a.ctx, "anomalyID", detectorARN, false)
+	a.EqualError(err, "could not update the anomaly: boom")
+}
+
+func (a *anomaliesTestSuite) describingGroupsReturns(group *cloudwatchlogs.LogGroup) {
+	a.api.On(
+		"DescribeLogGroupsWithContext",
+		a.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String("groupName")},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.DescribeLogGroupsOutput{LogGroups: []*cloudwatchlogs.LogGroup{group}}, nil).Once()
+}
+
+func (a *anomaliesTestSuite) updatingAnomalyReturns(input *cloudwatchlogs.UpdateAnomalyInput, err error) {
+	a.api.On("UpdateAnomalyWithContext", a.ctx, input, []request.Option(nil)).
+		Return(&cloudwatchlogs.UpdateAnomalyOutput{}, err).Once()
+}
+
+func TestAnomalies(t *testing.T) {
+	suite.Run(t, new(anomaliesTestSuite))
+}
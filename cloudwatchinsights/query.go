@@ -0,0 +1,140 @@
+// Package cloudwatchinsights runs CloudWatch Logs Insights queries against the
+// log group of a cloudwatch.Group.
+package cloudwatchinsights
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+const (
+	defaultPollBaseDelay = 100 * time.Millisecond
+	defaultPollMaxDelay  = 5 * time.Second
+)
+
+// Group is a cloudwatch.Group which can run Logs Insights queries against its
+// log group.
+type Group struct {
+	cloudwatch.Group
+
+	// Delays between polls for query results, doubling from base up to max.
+	pollBaseDelay, pollMaxDelay time.Duration
+}
+
+// QueryResult is the outcome of a finished query.
+type QueryResult struct {
+	// Status is either cloudwatchlogs.QueryStatusComplete or
+	// cloudwatchlogs.QueryStatusCancelled.
+	Status string
+
+	// Rows maps each field of the matching log events to its value. The
+	// internal @ptr field is left out.
+	Rows []map[string]string
+
+	Statistics *cloudwatchlogs.QueryStatistics
+}
+
+// NewGroup returns a Group querying the log group of g.
+func NewGroup(g cloudwatch.Group) *Group {
+	return &Group{
+		Group:         g,
+		pollBaseDelay: defaultPollBaseDelay,
+		pollMaxDelay:  defaultPollMaxDelay,
+	}
+}
+
+// Query runs query over the log events between start and end, and waits for
+// its results. A limit of zero uses the default limit of the API. If ctx is
+// done before the query finishes, the query is stopped.
+func (g *Group) Query(ctx context.Context, query string, start, end time.Time, limit int64) (*QueryResult, error) {
+	queryID, err := g.StartQuery(ctx, query, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := g.pollBaseDelay
+	for {
+		if err := aws.SleepWithContext(ctx, delay); err != nil {
+			// The query would otherwise keep running, and count against the
+			// limit of concurrent queries.
+			_ = g.StopQuery(context.Background(), queryID)
+			return nil, err
+		}
+
+		resp, err := g.GetQueryResultsWithContext(ctx, &cloudwatchlogs.GetQueryResultsInput{
+			QueryId: aws.String(queryID),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get the query results")
+		}
+
+		switch status := aws.StringValue(resp.Status); status {
+		case cloudwatchlogs.QueryStatusComplete, cloudwatchlogs.QueryStatusCancelled:
+			return newQueryResult(resp), nil
+		case cloudwatchlogs.QueryStatusScheduled, cloudwatchlogs.QueryStatusRunning:
+		default:
+			return nil, errors.Errorf("query %s ended with status %s", queryID, status)
+		}
+
+		if delay *= 2; delay > g.pollMaxDelay {
+			delay = g.pollMaxDelay
+		}
+	}
+}
+
+// StartQuery starts query over the log events between start and end, and
+// returns its ID, to be used with GetQueryResults or StopQuery. A limit of
+// zero uses the default limit of the API.
+func (g *Group) StartQuery(ctx context.Context, query string, start, end time.Time, limit int64) (string, error) {
+	input := &cloudwatchlogs.StartQueryInput{
+		LogGroupName: aws.String(g.Name()),
+		QueryString:  aws.String(query),
+		StartTime:    aws.Int64(start.Unix()),
+		EndTime:      aws.Int64(end.Unix()),
+	}
+	if limit > 0 {
+		input.Limit = aws.Int64(limit)
+	}
+
+	resp, err := g.StartQueryWithContext(ctx, input)
+	if err != nil {
+		return "", errors.Wrap(err, "could not start the query")
+	}
+
+	return aws.StringValue(resp.QueryId), nil
+}
+
+// StopQuery stops a query started with StartQuery.
+func (g *Group) StopQuery(ctx context.Context, queryID string) error {
+	_, err := g.StopQueryWithContext(ctx, &cloudwatchlogs.StopQueryInput{
+		QueryId: aws.String(queryID),
+	})
+
+	return errors.Wrap(err, "could not stop the query")
+}
+
+func newQueryResult(resp *cloudwatchlogs.GetQueryResultsOutput) *QueryResult {
+	ret := &QueryResult{
+		Status:     aws.StringValue(resp.Status),
+		Rows:       make([]map[string]string, 0, len(resp.Results)),
+		Statistics: resp.Statistics,
+	}
+
+	for _, fields := range resp.Results {
+		row := make(map[string]string, len(fields))
+		for _, field := range fields {
+			if name := aws.StringValue(field.Field); name != "@ptr" {
+				row[name] = aws.StringValue(field.Value)
+			}
+		}
+		ret.Rows = append(ret.Rows, row)
+	}
+
+	return ret
+}
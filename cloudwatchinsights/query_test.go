@@ -0,0 +1,134 @@
+package cloudwatchinsights
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+type mockAPI struct {
+	mock.Mock
+	iface.CloudWatchLogsAPI
+}
+
+func (m *mockAPI) GetQueryResultsWithContext(ctx aws.Context, input *cloudwatchlogs.GetQueryResultsInput, opts ...request.Option) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.GetQueryResultsOutput), args.Error(1)
+}
+
+func (m *mockAPI) StartQueryWithContext(ctx aws.Context, input *cloudwatchlogs.StartQueryInput, opts ...request.Option) (*cloudwatchlogs.StartQueryOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.StartQueryOutput), args.Error(1)
+}
+
+func (m *mockAPI) StopQueryWithContext(ctx aws.Context, input *cloudwatchlogs.StopQueryInput, opts ...request.Option) (*cloudwatchlogs.StopQueryOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.StopQueryOutput), args.Error(1)
+}
+
+type queryTestSuite struct {
+	suite.Suite
+
+	api        *mockAPI
+	ctx        context.Context
+	start, end time.Time
+	sut        *Group
+}
+
+func (q *queryTestSuite) SetupTest() {
+	q.api = new(mockAPI)
+	q.ctx = context.Background()
+	q.start = time.Unix(1000, 0)
+	q.end = time.Unix(2000, 0)
+
+	q.sut = NewGroup(cloudwatch.NewGroup(q.api, "groupName"))
+	q.sut.pollBaseDelay = time.Millisecond
+	q.sut.pollMaxDelay = time.Millisecond
+}
+
+func (q *queryTestSuite) TestQuery() {
+	q.startingQueryReturns(q.ctx, aws.Int64(10))
+	q.gettingResultsReturns(cloudwatchlogs.QueryStatusRunning, nil).Once()
+	q.gettingResultsReturns(cloudwatchlogs.QueryStatusComplete, [][]*cloudwatchlogs.ResultField{
+		{
+			{Field: aws.String("@message"), Value: aws.String("Hello")},
+			{Field: aws.String("@ptr"), Value: aws.String("pointer")},
+		},
+	}).Once()
+
+	result, err := q.sut.Query(q.ctx, "fields @message", q.start, q.end, 10)
+	q.Require().NoError(err)
+
+	q.Equal(cloudwatchlogs.QueryStatusComplete, result.Status)
+	q.Equal([]map[string]string{{"@message": "Hello"}}, result.Rows)
+	q.Equal(float64(1), aws.Float64Value(result.Statistics.RecordsMatched))
+	q.api.AssertExpectations(q.T())
+}
+
+func (q *queryTestSuite) TestQueryFailed() {
+	q.startingQueryReturns(q.ctx, nil)
+	q.gettingResultsReturns(cloudwatchlogs.QueryStatusFailed, nil)
+
+	_, err := q.sut.Query(q.ctx, "fields @message", q.start, q.end, 0)
+	q.EqualError(err, "query queryID ended with status Failed")
+}
+
+func (q *queryTestSuite) TestQueryCancelled() {
+	ctx, cancel := context.WithCancel(q.ctx)
+
+	q.startingQueryReturns(ctx, nil)
+	q.gettingResultsReturns(cloudwatchlogs.QueryStatusRunning, nil).Run(func(mock.Arguments) {
+		cancel()
+	})
+	q.api.On(
+		"StopQueryWithContext",
+		context.Background(),
+		&cloudwatchlogs.StopQueryInput{QueryId: aws.String("queryID")},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.StopQueryOutput{}, nil).Once()
+
+	_, err := q.sut.Query(ctx, "fields @message", q.start, q.end, 0)
+	q.Equal(context.Canceled, err)
+	q.api.AssertExpectations(q.T())
+}
+
+func (q *queryTestSuite) startingQueryReturns(ctx context.Context, limit *int64) {
+	q.api.On(
+		"StartQueryWithContext",
+		ctx,
+		&cloudwatchlogs.StartQueryInput{
+			LogGroupName: aws.String("groupName"),
+			QueryString:  aws.String("fields @message"),
+			StartTime:    aws.Int64(1000),
+			EndTime:      aws.Int64(2000),
+			Limit:        limit,
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.StartQueryOutput{QueryId: aws.String("queryID")}, nil).Once()
+}
+
+func (q *queryTestSuite) gettingResultsReturns(status string, results [][]*cloudwatchlogs.ResultField) *mock.Call {
+	return q.api.On(
+		"GetQueryResultsWithContext",
+		mock.Anything,
+		&cloudwatchlogs.GetQueryResultsInput{QueryId: aws.String("queryID")},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.GetQueryResultsOutput{
+		Results:    results,
+		Statistics: &cloudwatchlogs.QueryStatistics{RecordsMatched: aws.Float64(float64(len(results)))},
+		Status:     aws.String(status),
+	}, nil)
+}
+
+func TestQuery(t *testing.T) {
+	suite.Run(t, new(queryTestSuite))
+}
@@ -0,0 +1,97 @@
+// Package cloudwatchlogrus provides a logrus hook sending log entries to AWS
+// CloudWatch Logs.
+package cloudwatchlogrus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+// Hook is a logrus.Hook writing entries as JSON to a CloudWatch Logs stream,
+// one event per entry. It must be closed to send the buffered entries.
+type Hook struct {
+	group      cloudwatch.Group
+	ctx        context.Context
+	streamName string
+	opts       []cloudwatch.CreateOption
+
+	formatter logrus.Formatter
+	levels    []logrus.Level
+
+	writer cloudwatch.WriteFlushCloser
+	err    error
+
+	sync.Mutex // This protects writer and err.
+}
+
+// NewLogrusHook returns a Hook writing to the streamName log stream of g. The
+// stream is created with opts on the first call to Fire, and any error doing
+// so is returned from that and subsequent calls.
+func NewLogrusHook(g cloudwatch.Group, ctx context.Context, streamName string, opts ...cloudwatch.CreateOption) (*Hook, error) {
+	if g == nil {
+		return nil, errors.New("group is required")
+	}
+	if streamName == "" {
+		return nil, errors.New("stream name is required")
+	}
+
+	return &Hook{
+		group:      g,
+		ctx:        ctx,
+		streamName: streamName,
+		opts:       opts,
+		formatter:  &logrus.JSONFormatter{},
+		levels:     logrus.AllLevels,
+	}, nil
+}
+
+// WithLevels restricts the hook to entries at the given levels. By default,
+// entries at all levels are sent.
+func (h *Hook) WithLevels(levels []logrus.Level) *Hook {
+	h.levels = levels
+	return h
+}
+
+// Levels implements logrus.Hook.
+func (h *Hook) Levels() []logrus.Level {
+	return h.levels
+}
+
+// Fire implements logrus.Hook, serializing the entry and its data fields as a
+// JSON object.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	b, err := h.formatter.Format(entry)
+	if err != nil {
+		return errors.Wrap(err, "could not format the log entry")
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	if h.writer == nil && h.err == nil {
+		h.writer, h.err = h.group.Create(h.ctx, h.streamName, h.opts...)
+	}
+	if h.err != nil {
+		return h.err
+	}
+
+	_, err = h.writer.Write(b)
+	return err
+}
+
+// Close sends the buffered entries and closes the underlying writer, if it
+// has been created.
+func (h *Hook) Close() error {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.writer == nil {
+		return nil
+	}
+	return h.writer.Close()
+}
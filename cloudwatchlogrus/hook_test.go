@@ -0,0 +1,127 @@
+package cloudwatchlogrus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+type mockAPI struct {
+	mock.Mock
+	iface.CloudWatchLogsAPI
+}
+
+func (m *mockAPI) CreateLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogStreamInput, opts ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.CreateLogStreamOutput), args.Error(1)
+}
+
+func (m *mockAPI) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.PutLogEventsInput, opts ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.PutLogEventsOutput), args.Error(1)
+}
+
+type hookTestSuite struct {
+	suite.Suite
+
+	api    *mockAPI
+	ctx    context.Context
+	logger *logrus.Logger
+}
+
+func (h *hookTestSuite) SetupTest() {
+	h.api = new(mockAPI)
+	h.ctx = context.Background()
+	h.logger = logrus.New()
+	h.logger.SetOutput(ioutil.Discard)
+}
+
+func (h *hookTestSuite) creatingLogStreamReturns(err error) {
+	h.api.On(
+		"CreateLogStreamWithContext",
+		h.ctx,
+		&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String("groupName"),
+			LogStreamName: aws.String("streamName"),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.CreateLogStreamOutput{}, err)
+}
+
+func (h *hookTestSuite) newHook() *Hook {
+	hook, err := NewLogrusHook(cloudwatch.NewGroup(h.api, "groupName"), h.ctx, "streamName")
+	h.Require().NoError(err)
+	return hook
+}
+
+func (h *hookTestSuite) TestFire() {
+	h.creatingLogStreamReturns(nil)
+
+	var messages []string
+	h.api.On(
+		"PutLogEventsWithContext",
+		mock.Anything,
+		mock.AnythingOfType("*cloudwatchlogs.PutLogEventsInput"),
+		[]request.Option(nil),
+	).Run(func(args mock.Arguments) {
+		for _, event := range args.Get(1).(*cloudwatchlogs.PutLogEventsInput).LogEvents {
+			messages = append(messages, *event.Message)
+		}
+	}).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	hook := h.newHook()
+	h.logger.AddHook(hook)
+
+	h.logger.WithField("order", 42).Info("Hello")
+	h.NoError(hook.Close())
+
+	h.Require().Len(messages, 1)
+
+	var entry map[string]interface{}
+	h.Require().NoError(json.Unmarshal([]byte(messages[0]), &entry))
+	h.Equal("Hello", entry["msg"])
+	h.Equal("info", entry["level"])
+	h.Equal(float64(42), entry["order"])
+}
+
+func (h *hookTestSuite) TestCreatesWriterLazily() {
+	hook := h.newHook()
+
+	h.NoError(hook.Close())
+	h.api.AssertNotCalled(h.T(), "CreateLogStreamWithContext")
+}
+
+func (h *hookTestSuite) TestCreateError() {
+	h.creatingLogStreamReturns(errors.New("bacon"))
+
+	hook := h.newHook()
+	entry := logrus.NewEntry(h.logger)
+
+	h.EqualError(hook.Fire(entry), "could not create the log stream: bacon")
+	h.EqualError(hook.Fire(entry), "could not create the log stream: bacon")
+	h.api.AssertNumberOfCalls(h.T(), "CreateLogStreamWithContext", 1)
+}
+
+func (h *hookTestSuite) TestLevels() {
+	hook := h.newHook()
+	h.Equal(logrus.AllLevels, hook.Levels())
+
+	levels := []logrus.Level{logrus.ErrorLevel}
+	h.Equal(levels, hook.WithLevels(levels).Levels())
+}
+
+func TestHook(t *testing.T) {
+	suite.Run(t, new(hookTestSuite))
+}
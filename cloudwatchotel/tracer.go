@@ -0,0 +1,104 @@
+// Package cloudwatchotel traces the calls made to AWS CloudWatch Logs by
+// cloudwatch writers and readers with OpenTelemetry.
+package cloudwatchotel
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+// Attributes recorded on the spans.
+const (
+	LogGroupKey   = attribute.Key("aws.log.group.name")
+	LogStreamKey  = attribute.Key("aws.log.stream.name")
+	EventCountKey = attribute.Key("cloudwatch.event_count")
+	ByteCountKey  = attribute.Key("cloudwatch.byte_count")
+)
+
+// WithOTelTracer makes the writer record a cloudwatch.PutLogEvents span with
+// tracer for each call to PutLogEvents.
+func WithOTelTracer(tracer trace.Tracer) cloudwatch.CreateOption {
+	return cloudwatch.WithAPIWrapper(wrap(tracer))
+}
+
+// WithOTelReadTracer makes the reader record a cloudwatch.GetLogEvents span
+// with tracer for each call to GetLogEvents.
+func WithOTelReadTracer(tracer trace.Tracer) cloudwatch.ReadOption {
+	return cloudwatch.WithReadAPIWrapper(wrap(tracer))
+}
+
+func wrap(tracer trace.Tracer) func(iface.CloudWatchLogsAPI) iface.CloudWatchLogsAPI {
+	return func(client iface.CloudWatchLogsAPI) iface.CloudWatchLogsAPI {
+		return &tracingClient{CloudWatchLogsAPI: client, tracer: tracer}
+	}
+}
+
+// tracingClient records spans around the calls made by writers and readers,
+// and passes the other calls through.
+type tracingClient struct {
+	iface.CloudWatchLogsAPI
+	tracer trace.Tracer
+}
+
+func (c *tracingClient) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.PutLogEventsInput, opts ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	var bytes int
+	for _, event := range input.LogEvents {
+		bytes += len(aws.StringValue(event.Message))
+	}
+
+	ctx, span := c.tracer.Start(ctx, "cloudwatch.PutLogEvents",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			LogGroupKey.String(aws.StringValue(input.LogGroupName)),
+			LogStreamKey.String(aws.StringValue(input.LogStreamName)),
+			EventCountKey.Int(len(input.LogEvents)),
+			ByteCountKey.Int(bytes),
+		),
+	)
+	defer span.End()
+
+	resp, err := c.CloudWatchLogsAPI.PutLogEventsWithContext(ctx, input, opts...)
+	recordError(span, err)
+	return resp, err
+}
+
+func (c *tracingClient) GetLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.GetLogEventsInput, opts ...request.Option) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	ctx, span := c.tracer.Start(ctx, "cloudwatch.GetLogEvents",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			LogGroupKey.String(aws.StringValue(input.LogGroupName)),
+			LogStreamKey.String(aws.StringValue(input.LogStreamName)),
+		),
+	)
+	defer span.End()
+
+	resp, err := c.CloudWatchLogsAPI.GetLogEventsWithContext(ctx, input, opts...)
+	if err != nil {
+		recordError(span, err)
+		return resp, err
+	}
+
+	// The events are only known once they've been received.
+	var bytes int
+	for _, event := range resp.Events {
+		bytes += len(aws.StringValue(event.Message))
+	}
+	span.SetAttributes(EventCountKey.Int(len(resp.Events)), ByteCountKey.Int(bytes))
+
+	return resp, nil
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,154 @@
+package cloudwatchotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+type mockAPI struct {
+	mock.Mock
+	iface.CloudWatchLogsAPI
+}
+
+func (m *mockAPI) CreateLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogStreamInput, opts ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.CreateLogStreamOutput), args.Error(1)
+}
+
+func (m *mockAPI) GetLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.GetLogEventsInput, opts ...request.Option) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.GetLogEventsOutput), args.Error(1)
+}
+
+func (m *mockAPI) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.PutLogEventsInput, opts ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.PutLogEventsOutput), args.Error(1)
+}
+
+type tracerTestSuite struct {
+	suite.Suite
+
+	api      *mockAPI
+	ctx      context.Context
+	recorder *tracetest.SpanRecorder
+	provider *sdktrace.TracerProvider
+}
+
+func (t *tracerTestSuite) SetupTest() {
+	t.api = new(mockAPI)
+	t.ctx = context.Background()
+	t.recorder = tracetest.NewSpanRecorder()
+	t.provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(t.recorder))
+}
+
+func (t *tracerTestSuite) TestPutLogEvents() {
+	t.api.On(
+		"CreateLogStreamWithContext",
+		t.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.CreateLogStreamInput"),
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil)
+
+	t.api.On(
+		"PutLogEventsWithContext",
+		mock.Anything,
+		mock.AnythingOfType("*cloudwatchlogs.PutLogEventsInput"),
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	writer, err := cloudwatch.NewGroup(t.api, "groupName").Create(
+		t.ctx,
+		"streamName",
+		WithOTelTracer(t.provider.Tracer("test")),
+		cloudwatch.WithFlushInterval(time.Hour),
+	)
+	t.Require().NoError(err)
+
+	_, err = io.WriteString(writer, "Hello\nWorld!\n")
+	t.Require().NoError(err)
+	t.Require().NoError(writer.Flush())
+
+	spans := t.recorder.Ended()
+	t.Require().Len(spans, 1)
+	t.Equal("cloudwatch.PutLogEvents", spans[0].Name())
+	t.Equal(codes.Unset, spans[0].Status().Code)
+	t.Equal([]attribute.KeyValue{
+		LogGroupKey.String("groupName"),
+		LogStreamKey.String("streamName"),
+		EventCountKey.Int(2),
+		ByteCountKey.Int(13),
+	}, spans[0].Attributes())
+
+	t.NoError(writer.Close())
+}
+
+func (t *tracerTestSuite) TestGetLogEvents() {
+	t.api.On(
+		"GetLogEventsWithContext",
+		mock.Anything,
+		mock.AnythingOfType("*cloudwatchlogs.GetLogEventsInput"),
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("Hello")},
+		},
+	}, nil).Once()
+
+	client := wrap(t.provider.Tracer("test"))(t.api)
+	_, err := client.GetLogEventsWithContext(t.ctx, &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String("groupName"),
+		LogStreamName: aws.String("streamName"),
+	})
+	t.Require().NoError(err)
+
+	spans := t.recorder.Ended()
+	t.Require().Len(spans, 1)
+	t.Equal("cloudwatch.GetLogEvents", spans[0].Name())
+	t.Equal([]attribute.KeyValue{
+		LogGroupKey.String("groupName"),
+		LogStreamKey.String("streamName"),
+		EventCountKey.Int(1),
+		ByteCountKey.Int(5),
+	}, spans[0].Attributes())
+}
+
+func (t *tracerTestSuite) TestError() {
+	t.api.On(
+		"GetLogEventsWithContext",
+		mock.Anything,
+		mock.AnythingOfType("*cloudwatchlogs.GetLogEventsInput"),
+		[]request.Option(nil),
+	).Return((*cloudwatchlogs.GetLogEventsOutput)(nil), errors.New("bacon"))
+
+	client := wrap(t.provider.Tracer("test"))(t.api)
+	_, err := client.GetLogEventsWithContext(t.ctx, &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String("groupName"),
+		LogStreamName: aws.String("streamName"),
+	})
+	t.EqualError(err, "bacon")
+
+	spans := t.recorder.Ended()
+	t.Require().Len(spans, 1)
+	t.Equal(sdktrace.Status{Code: codes.Error, Description: "bacon"}, spans[0].Status())
+	t.Len(spans[0].Events(), 1)
+}
+
+func TestTracer(t *testing.T) {
+	suite.Run(t, new(tracerTestSuite))
+}
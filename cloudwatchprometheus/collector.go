@@ -0,0 +1,113 @@
+// Package cloudwatchprometheus exports metrics about cloudwatch writers to
+// Prometheus.
+package cloudwatchprometheus
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/prometheus/client_golang/prometheus"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+// PrometheusCollector is a prometheus.Collector of metrics about the writers
+// it's passed to using WithPrometheusCollector. It can be shared by several
+// writers, in which case the metrics are aggregated across them.
+type PrometheusCollector struct {
+	flushDuration prometheus.Histogram
+	eventsSent    prometheus.Counter
+	bytesSent     prometheus.Counter
+	flushErrors   *prometheus.CounterVec
+	bufferSize    prometheus.Gauge
+}
+
+// NewPrometheusCollector returns a new PrometheusCollector, which needs to be
+// registered with a prometheus.Registerer.
+func NewPrometheusCollector() *PrometheusCollector {
+	return &PrometheusCollector{
+		flushDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "cloudwatch_flush_duration_seconds",
+			Help:    "Time taken to send a batch of log events, including retries.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		eventsSent: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "cloudwatch_events_sent_total",
+			Help: "Number of log events sent.",
+		}),
+		bytesSent: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "cloudwatch_bytes_sent_total",
+			Help: "Size of the log events sent, including 26 bytes of overhead per event.",
+		}),
+		flushErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cloudwatch_flush_errors_total",
+			Help: "Number of batches of log events which failed to be sent, by error type.",
+		}, []string{"type"}),
+		bufferSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "cloudwatch_buffer_size_events",
+			Help: "Number of log events waiting to be sent.",
+		}),
+	}
+}
+
+// WithPrometheusCollector makes the writer report its metrics to c.
+func WithPrometheusCollector(c *PrometheusCollector) cloudwatch.CreateOption {
+	return cloudwatch.WithMetrics(c)
+}
+
+// Describe implements prometheus.Collector.
+func (c *PrometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.flushDuration.Describe(ch)
+	c.eventsSent.Describe(ch)
+	c.bytesSent.Describe(ch)
+	c.flushErrors.Describe(ch)
+	c.bufferSize.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *PrometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	c.flushDuration.Collect(ch)
+	c.eventsSent.Collect(ch)
+	c.bytesSent.Collect(ch)
+	c.flushErrors.Collect(ch)
+	c.bufferSize.Collect(ch)
+}
+
+// ObserveFlush implements cloudwatch.Metrics.
+func (c *PrometheusCollector) ObserveFlush(duration time.Duration, events, bytes int, err error) {
+	c.flushDuration.Observe(duration.Seconds())
+
+	if err != nil {
+		c.flushErrors.WithLabelValues(errorType(err)).Inc()
+		return
+	}
+
+	c.eventsSent.Add(float64(events))
+	c.bytesSent.Add(float64(bytes))
+}
+
+// SetBufferSize implements cloudwatch.Metrics.
+func (c *PrometheusCollector) SetBufferSize(events int) {
+	c.bufferSize.Set(float64(events))
+}
+
+// errorType returns a label for err with a low cardinality: the AWS error
+// code if there is one.
+func errorType(err error) string {
+	switch err := err.(type) {
+	case awserr.Error:
+		return err.Code()
+	case *cloudwatch.RejectedLogEventsInfoError:
+		return "RejectedLogEvents"
+	}
+
+	switch err {
+	case cloudwatch.ErrWriteTimeout:
+		return "WriteTimeout"
+	case context.Canceled, context.DeadlineExceeded:
+		return "ContextDone"
+	}
+
+	return "Other"
+}
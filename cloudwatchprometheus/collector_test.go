@@ -0,0 +1,106 @@
+package cloudwatchprometheus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+type mockAPI struct {
+	mock.Mock
+	iface.CloudWatchLogsAPI
+}
+
+func (m *mockAPI) CreateLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogStreamInput, opts ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.CreateLogStreamOutput), args.Error(1)
+}
+
+func (m *mockAPI) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.PutLogEventsInput, opts ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.PutLogEventsOutput), args.Error(1)
+}
+
+type collectorTestSuite struct {
+	suite.Suite
+
+	api *mockAPI
+	ctx context.Context
+	sut *PrometheusCollector
+}
+
+func (c *collectorTestSuite) SetupTest() {
+	c.api = new(mockAPI)
+	c.ctx = context.Background()
+	c.sut = NewPrometheusCollector()
+
+	c.api.On(
+		"CreateLogStreamWithContext",
+		c.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.CreateLogStreamInput"),
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil)
+}
+
+func (c *collectorTestSuite) TestWriterMetrics() {
+	c.api.On(
+		"PutLogEventsWithContext",
+		mock.Anything,
+		mock.AnythingOfType("*cloudwatchlogs.PutLogEventsInput"),
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	writer, err := cloudwatch.NewGroup(c.api, "groupName").Create(
+		c.ctx,
+		"streamName",
+		WithPrometheusCollector(c.sut),
+		cloudwatch.WithFlushInterval(time.Hour),
+	)
+	c.Require().NoError(err)
+
+	_, err = io.WriteString(writer, "Hello\nWorld\n")
+	c.Require().NoError(err)
+	c.Equal(float64(2), testutil.ToFloat64(c.sut.bufferSize))
+
+	c.Require().NoError(writer.Flush())
+	c.Equal(float64(0), testutil.ToFloat64(c.sut.bufferSize))
+	c.Equal(float64(2), testutil.ToFloat64(c.sut.eventsSent))
+	c.Equal(float64(2*(6+26)), testutil.ToFloat64(c.sut.bytesSent))
+	c.Equal(1, testutil.CollectAndCount(c.sut.flushDuration))
+	c.Equal(0, testutil.CollectAndCount(c.sut.flushErrors))
+
+	c.NoError(writer.Close())
+}
+
+func (c *collectorTestSuite) TestFlushErrors() {
+	c.sut.ObserveFlush(time.Second, 1, 10, awserr.New("ThrottlingException", "slow down", nil))
+	c.sut.ObserveFlush(time.Second, 1, 10, cloudwatch.ErrWriteTimeout)
+	c.sut.ObserveFlush(time.Second, 1, 10, errors.New("bacon"))
+
+	c.Equal(float64(1), testutil.ToFloat64(c.sut.flushErrors.WithLabelValues("ThrottlingException")))
+	c.Equal(float64(1), testutil.ToFloat64(c.sut.flushErrors.WithLabelValues("WriteTimeout")))
+	c.Equal(float64(1), testutil.ToFloat64(c.sut.flushErrors.WithLabelValues("Other")))
+	c.Equal(float64(0), testutil.ToFloat64(c.sut.eventsSent))
+}
+
+func (c *collectorTestSuite) TestRegister() {
+	c.NoError(prometheus.NewRegistry().Register(c.sut))
+}
+
+func TestCollector(t *testing.T) {
+	suite.Run(t, new(collectorTestSuite))
+}
@@ -0,0 +1,852 @@
+// Package cloudwatchv2 allows using the cloudwatch package with a CloudWatch
+// Logs client from aws-sdk-go-v2.
+package cloudwatchv2
+
+import (
+	"context"
+	"net/url"
+
+	cwl "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/pkg/errors"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+// CloudWatchLogsV2API is the subset of the aws-sdk-go-v2 CloudWatch Logs
+// client used by the cloudwatch package. It is satisfied by
+// *cloudwatchlogs.Client.
+type CloudWatchLogsV2API interface {
+	AssociateKmsKey(context.Context, *cwl.AssociateKmsKeyInput, ...func(*cwl.Options)) (*cwl.AssociateKmsKeyOutput, error)
+	CreateExportTask(context.Context, *cwl.CreateExportTaskInput, ...func(*cwl.Options)) (*cwl.CreateExportTaskOutput, error)
+	CreateLogAnomalyDetector(context.Context, *cwl.CreateLogAnomalyDetectorInput, ...func(*cwl.Options)) (*cwl.CreateLogAnomalyDetectorOutput, error)
+	CreateLogGroup(context.Context, *cwl.CreateLogGroupInput, ...func(*cwl.Options)) (*cwl.CreateLogGroupOutput, error)
+	CreateLogStream(context.Context, *cwl.CreateLogStreamInput, ...func(*cwl.Options)) (*cwl.CreateLogStreamOutput, error)
+	DeleteDataProtectionPolicy(context.Context, *cwl.DeleteDataProtectionPolicyInput, ...func(*cwl.Options)) (*cwl.DeleteDataProtectionPolicyOutput, error)
+	DeleteLogGroup(context.Context, *cwl.DeleteLogGroupInput, ...func(*cwl.Options)) (*cwl.DeleteLogGroupOutput, error)
+	DeleteLogStream(context.Context, *cwl.DeleteLogStreamInput, ...func(*cwl.Options)) (*cwl.DeleteLogStreamOutput, error)
+	DeleteMetricFilter(context.Context, *cwl.DeleteMetricFilterInput, ...func(*cwl.Options)) (*cwl.DeleteMetricFilterOutput, error)
+	DeleteRetentionPolicy(context.Context, *cwl.DeleteRetentionPolicyInput, ...func(*cwl.Options)) (*cwl.DeleteRetentionPolicyOutput, error)
+	DeleteSubscriptionFilter(context.Context, *cwl.DeleteSubscriptionFilterInput, ...func(*cwl.Options)) (*cwl.DeleteSubscriptionFilterOutput, error)
+	DescribeExportTasks(context.Context, *cwl.DescribeExportTasksInput, ...func(*cwl.Options)) (*cwl.DescribeExportTasksOutput, error)
+	DescribeLogGroups(context.Context, *cwl.DescribeLogGroupsInput, ...func(*cwl.Options)) (*cwl.DescribeLogGroupsOutput, error)
+	DescribeLogStreams(context.Context, *cwl.DescribeLogStreamsInput, ...func(*cwl.Options)) (*cwl.DescribeLogStreamsOutput, error)
+	DescribeMetricFilters(context.Context, *cwl.DescribeMetricFiltersInput, ...func(*cwl.Options)) (*cwl.DescribeMetricFiltersOutput, error)
+	DescribeSubscriptionFilters(context.Context, *cwl.DescribeSubscriptionFiltersInput, ...func(*cwl.Options)) (*cwl.DescribeSubscriptionFiltersOutput, error)
+	DisassociateKmsKey(context.Context, *cwl.DisassociateKmsKeyInput, ...func(*cwl.Options)) (*cwl.DisassociateKmsKeyOutput, error)
+	FilterLogEvents(context.Context, *cwl.FilterLogEventsInput, ...func(*cwl.Options)) (*cwl.FilterLogEventsOutput, error)
+	GetDataProtectionPolicy(context.Context, *cwl.GetDataProtectionPolicyInput, ...func(*cwl.Options)) (*cwl.GetDataProtectionPolicyOutput, error)
+	GetLogEvents(context.Context, *cwl.GetLogEventsInput, ...func(*cwl.Options)) (*cwl.GetLogEventsOutput, error)
+	GetLogRecord(context.Context, *cwl.GetLogRecordInput, ...func(*cwl.Options)) (*cwl.GetLogRecordOutput, error)
+	GetQueryResults(context.Context, *cwl.GetQueryResultsInput, ...func(*cwl.Options)) (*cwl.GetQueryResultsOutput, error)
+	ListAnomalies(context.Context, *cwl.ListAnomaliesInput, ...func(*cwl.Options)) (*cwl.ListAnomaliesOutput, error)
+	ListTagsForResource(context.Context, *cwl.ListTagsForResourceInput, ...func(*cwl.Options)) (*cwl.ListTagsForResourceOutput, error)
+	PutDataProtectionPolicy(context.Context, *cwl.PutDataProtectionPolicyInput, ...func(*cwl.Options)) (*cwl.PutDataProtectionPolicyOutput, error)
+	PutLogEvents(context.Context, *cwl.PutLogEventsInput, ...func(*cwl.Options)) (*cwl.PutLogEventsOutput, error)
+	PutMetricFilter(context.Context, *cwl.PutMetricFilterInput, ...func(*cwl.Options)) (*cwl.PutMetricFilterOutput, error)
+	PutRetentionPolicy(context.Context, *cwl.PutRetentionPolicyInput, ...func(*cwl.Options)) (*cwl.PutRetentionPolicyOutput, error)
+	PutSubscriptionFilter(context.Context, *cwl.PutSubscriptionFilterInput, ...func(*cwl.Options)) (*cwl.PutSubscriptionFilterOutput, error)
+	StartQuery(context.Context, *cwl.StartQueryInput, ...func(*cwl.Options)) (*cwl.StartQueryOutput, error)
+	StopQuery(context.Context, *cwl.StopQueryInput, ...func(*cwl.Options)) (*cwl.StopQueryOutput, error)
+	TagResource(context.Context, *cwl.TagResourceInput, ...func(*cwl.Options)) (*cwl.TagResourceOutput, error)
+	TestMetricFilter(context.Context, *cwl.TestMetricFilterInput, ...func(*cwl.Options)) (*cwl.TestMetricFilterOutput, error)
+	UntagResource(context.Context, *cwl.UntagResourceInput, ...func(*cwl.Options)) (*cwl.UntagResourceOutput, error)
+	UpdateAnomaly(context.Context, *cwl.UpdateAnomalyInput, ...func(*cwl.Options)) (*cwl.UpdateAnomalyOutput, error)
+}
+
+// UnsupportedError is returned by the requests of the cloudwatch package which
+// can't be made using a v2 client.
+type UnsupportedError struct {
+	Operation string
+}
+
+func (e *UnsupportedError) Error() string {
+	return e.Operation + " is not supported by the aws-sdk-go-v2 adapter"
+}
+
+// Option allows setting various options on the adapter built by NewGroupV2.
+type Option func(*adapter)
+
+// WithGroupOptions applies opts to the group returned by NewGroupV2, for
+// example to set its logger, metrics or read rate limit.
+func WithGroupOptions(opts ...cloudwatch.GroupOption) Option {
+	return func(a *adapter) {
+		a.groupOpts = append(a.groupOpts, opts...)
+	}
+}
+
+// WithEndpointURL sends the requests of the client to rawURL rather than to
+// the endpoint it resolves, for example to use a VPC endpoint or LocalStack.
+// If rawURL isn't an absolute URL, every request fails.
+func WithEndpointURL(rawURL string) Option {
+	u, err := url.Parse(rawURL)
+	if err == nil && (u.Scheme == "" || u.Host == "") {
+		err = errors.New("missing scheme or host")
+	}
+	err = errors.Wrapf(err, "invalid endpoint URL %q", rawURL)
+
+	return func(a *adapter) {
+		if err != nil {
+			a.optFns = append(a.optFns, func(o *cwl.Options) {
+				o.APIOptions = append(o.APIOptions, func(*middleware.Stack) error { return err })
+			})
+			return
+		}
+
+		a.optFns = append(a.optFns, func(o *cwl.Options) {
+			o.BaseEndpoint = aws.String(rawURL)
+		})
+	}
+}
+
+// NewGroupV2 returns a new cloudwatch.Group using an aws-sdk-go-v2 client.
+//
+// Requests made by the group and by the cloudwatchinsights and
+// cloudwatchanomalies packages are translated to and from their v2
+// equivalents, except for Live Tail sessions. Those, and the other methods of
+// the embedded CloudWatchLogsAPI, fail with an *UnsupportedError.
+func NewGroupV2(client CloudWatchLogsV2API, groupName string, opts ...Option) cloudwatch.Group {
+	a := &adapter{client: client}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return cloudwatch.NewGroup(a, groupName, a.groupOpts...)
+}
+
+// adapter implements the aws-sdk-go CloudWatch Logs API on top of an
+// aws-sdk-go-v2 client. Live Tail sessions aren't translated, since the event
+// stream of a StartLiveTailOutput can only be set by the v1 client.
+type adapter struct {
+	unsupportedClient
+	client CloudWatchLogsV2API
+
+	// Passed to every request of client.
+	optFns []func(*cwl.Options)
+
+	// Passed to cloudwatch.NewGroup.
+	groupOpts []cloudwatch.GroupOption
+}
+
+func (a *adapter) AssociateKmsKeyWithContext(ctx aws.Context, input *cloudwatchlogs.AssociateKmsKeyInput, _ ...request.Option) (*cloudwatchlogs.AssociateKmsKeyOutput, error) {
+	_, err := a.client.AssociateKmsKey(ctx, &cwl.AssociateKmsKeyInput{
+		KmsKeyId:           input.KmsKeyId,
+		LogGroupName:       input.LogGroupName,
+		ResourceIdentifier: input.ResourceIdentifier,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.AssociateKmsKeyOutput{}, nil
+}
+
+func (a *adapter) CreateExportTaskWithContext(ctx aws.Context, input *cloudwatchlogs.CreateExportTaskInput, _ ...request.Option) (*cloudwatchlogs.CreateExportTaskOutput, error) {
+	resp, err := a.client.CreateExportTask(ctx, &cwl.CreateExportTaskInput{
+		Destination:         input.Destination,
+		DestinationPrefix:   input.DestinationPrefix,
+		From:                input.From,
+		LogGroupName:        input.LogGroupName,
+		LogStreamNamePrefix: input.LogStreamNamePrefix,
+		TaskName:            input.TaskName,
+		To:                  input.To,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.CreateExportTaskOutput{TaskId: resp.TaskId}, nil
+}
+
+func (a *adapter) CreateLogAnomalyDetectorWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogAnomalyDetectorInput, _ ...request.Option) (*cloudwatchlogs.CreateLogAnomalyDetectorOutput, error) {
+	resp, err := a.client.CreateLogAnomalyDetector(ctx, &cwl.CreateLogAnomalyDetectorInput{
+		AnomalyVisibilityTime: input.AnomalyVisibilityTime,
+		DetectorName:          input.DetectorName,
+		EvaluationFrequency:   types.EvaluationFrequency(aws.StringValue(input.EvaluationFrequency)),
+		FilterPattern:         input.FilterPattern,
+		KmsKeyId:              input.KmsKeyId,
+		LogGroupArnList:       aws.StringValueSlice(input.LogGroupArnList),
+		Tags:                  aws.StringValueMap(input.Tags),
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.CreateLogAnomalyDetectorOutput{AnomalyDetectorArn: resp.AnomalyDetectorArn}, nil
+}
+
+func (a *adapter) CreateLogGroupWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogGroupInput, _ ...request.Option) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	_, err := a.client.CreateLogGroup(ctx, &cwl.CreateLogGroupInput{
+		KmsKeyId:     input.KmsKeyId,
+		LogGroupName: input.LogGroupName,
+		Tags:         aws.StringValueMap(input.Tags),
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.CreateLogGroupOutput{}, nil
+}
+
+func (a *adapter) CreateLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogStreamInput, _ ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	_, err := a.client.CreateLogStream(ctx, &cwl.CreateLogStreamInput{
+		LogGroupName:  input.LogGroupName,
+		LogStreamName: input.LogStreamName,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+}
+
+func (a *adapter) DeleteDataProtectionPolicyWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteDataProtectionPolicyInput, _ ...request.Option) (*cloudwatchlogs.DeleteDataProtectionPolicyOutput, error) {
+	_, err := a.client.DeleteDataProtectionPolicy(ctx, &cwl.DeleteDataProtectionPolicyInput{
+		LogGroupIdentifier: input.LogGroupIdentifier,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.DeleteDataProtectionPolicyOutput{}, nil
+}
+
+func (a *adapter) DeleteLogGroupWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteLogGroupInput, _ ...request.Option) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+	_, err := a.client.DeleteLogGroup(ctx, &cwl.DeleteLogGroupInput{
+		LogGroupName: input.LogGroupName,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.DeleteLogGroupOutput{}, nil
+}
+
+func (a *adapter) DeleteLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteLogStreamInput, _ ...request.Option) (*cloudwatchlogs.DeleteLogStreamOutput, error) {
+	_, err := a.client.DeleteLogStream(ctx, &cwl.DeleteLogStreamInput{
+		LogGroupName:  input.LogGroupName,
+		LogStreamName: input.LogStreamName,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.DeleteLogStreamOutput{}, nil
+}
+
+func (a *adapter) DeleteMetricFilterWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteMetricFilterInput, _ ...request.Option) (*cloudwatchlogs.DeleteMetricFilterOutput, error) {
+	_, err := a.client.DeleteMetricFilter(ctx, &cwl.DeleteMetricFilterInput{
+		FilterName:   input.FilterName,
+		LogGroupName: input.LogGroupName,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.DeleteMetricFilterOutput{}, nil
+}
+
+func (a *adapter) DeleteRetentionPolicyWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteRetentionPolicyInput, _ ...request.Option) (*cloudwatchlogs.DeleteRetentionPolicyOutput, error) {
+	_, err := a.client.DeleteRetentionPolicy(ctx, &cwl.DeleteRetentionPolicyInput{
+		LogGroupName: input.LogGroupName,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.DeleteRetentionPolicyOutput{}, nil
+}
+
+func (a *adapter) DeleteSubscriptionFilterWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteSubscriptionFilterInput, _ ...request.Option) (*cloudwatchlogs.DeleteSubscriptionFilterOutput, error) {
+	_, err := a.client.DeleteSubscriptionFilter(ctx, &cwl.DeleteSubscriptionFilterInput{
+		FilterName:   input.FilterName,
+		LogGroupName: input.LogGroupName,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.DeleteSubscriptionFilterOutput{}, nil
+}
+
+func (a *adapter) DescribeExportTasksWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeExportTasksInput, _ ...request.Option) (*cloudwatchlogs.DescribeExportTasksOutput, error) {
+	resp, err := a.client.DescribeExportTasks(ctx, &cwl.DescribeExportTasksInput{
+		Limit:      toInt32(input.Limit),
+		NextToken:  input.NextToken,
+		StatusCode: types.ExportTaskStatusCode(aws.StringValue(input.StatusCode)),
+		TaskId:     input.TaskId,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.DescribeExportTasksOutput{NextToken: resp.NextToken}
+	for _, task := range resp.ExportTasks {
+		exportTask := &cloudwatchlogs.ExportTask{
+			Destination:       task.Destination,
+			DestinationPrefix: task.DestinationPrefix,
+			From:              task.From,
+			LogGroupName:      task.LogGroupName,
+			TaskId:            task.TaskId,
+			TaskName:          task.TaskName,
+			To:                task.To,
+		}
+		if info := task.ExecutionInfo; info != nil {
+			exportTask.ExecutionInfo = &cloudwatchlogs.ExportTaskExecutionInfo{
+				CompletionTime: info.CompletionTime,
+				CreationTime:   info.CreationTime,
+			}
+		}
+		if status := task.Status; status != nil {
+			exportTask.Status = &cloudwatchlogs.ExportTaskStatus{
+				Code:    toString(string(status.Code)),
+				Message: status.Message,
+			}
+		}
+		ret.ExportTasks = append(ret.ExportTasks, exportTask)
+	}
+	return ret, nil
+}
+
+func (a *adapter) DescribeLogGroupsWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeLogGroupsInput, _ ...request.Option) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	resp, err := a.client.DescribeLogGroups(ctx, &cwl.DescribeLogGroupsInput{
+		Limit:              toInt32(input.Limit),
+		LogGroupNamePrefix: input.LogGroupNamePrefix,
+		NextToken:          input.NextToken,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.DescribeLogGroupsOutput{NextToken: resp.NextToken}
+	for _, group := range resp.LogGroups {
+		ret.LogGroups = append(ret.LogGroups, &cloudwatchlogs.LogGroup{
+			Arn:               group.Arn,
+			CreationTime:      group.CreationTime,
+			KmsKeyId:          group.KmsKeyId,
+			LogGroupName:      group.LogGroupName,
+			MetricFilterCount: toInt64(group.MetricFilterCount),
+			RetentionInDays:   toInt64(group.RetentionInDays),
+			StoredBytes:       group.StoredBytes,
+		})
+	}
+	return ret, nil
+}
+
+func (a *adapter) DescribeLogStreamsWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeLogStreamsInput, _ ...request.Option) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	resp, err := a.client.DescribeLogStreams(ctx, &cwl.DescribeLogStreamsInput{
+		Descending:          input.Descending,
+		Limit:               toInt32(input.Limit),
+		LogGroupName:        input.LogGroupName,
+		LogStreamNamePrefix: input.LogStreamNamePrefix,
+		NextToken:           input.NextToken,
+		OrderBy:             types.OrderBy(aws.StringValue(input.OrderBy)),
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.DescribeLogStreamsOutput{NextToken: resp.NextToken}
+	for _, stream := range resp.LogStreams {
+		ret.LogStreams = append(ret.LogStreams, &cloudwatchlogs.LogStream{
+			Arn:                 stream.Arn,
+			CreationTime:        stream.CreationTime,
+			FirstEventTimestamp: stream.FirstEventTimestamp,
+			LastEventTimestamp:  stream.LastEventTimestamp,
+			LastIngestionTime:   stream.LastIngestionTime,
+			LogStreamName:       stream.LogStreamName,
+			StoredBytes:         stream.StoredBytes,
+			UploadSequenceToken: stream.UploadSequenceToken,
+		})
+	}
+	return ret, nil
+}
+
+func (a *adapter) DescribeMetricFiltersWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeMetricFiltersInput, _ ...request.Option) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	resp, err := a.client.DescribeMetricFilters(ctx, &cwl.DescribeMetricFiltersInput{
+		FilterNamePrefix: input.FilterNamePrefix,
+		Limit:            toInt32(input.Limit),
+		LogGroupName:     input.LogGroupName,
+		MetricName:       input.MetricName,
+		MetricNamespace:  input.MetricNamespace,
+		NextToken:        input.NextToken,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.DescribeMetricFiltersOutput{NextToken: resp.NextToken}
+	for _, filter := range resp.MetricFilters {
+		metricFilter := &cloudwatchlogs.MetricFilter{
+			CreationTime:  filter.CreationTime,
+			FilterName:    filter.FilterName,
+			FilterPattern: filter.FilterPattern,
+			LogGroupName:  filter.LogGroupName,
+		}
+		for _, t := range filter.MetricTransformations {
+			metricFilter.MetricTransformations = append(metricFilter.MetricTransformations, &cloudwatchlogs.MetricTransformation{
+				DefaultValue:    t.DefaultValue,
+				Dimensions:      aws.StringMap(t.Dimensions),
+				MetricName:      t.MetricName,
+				MetricNamespace: t.MetricNamespace,
+				MetricValue:     t.MetricValue,
+				Unit:            toString(string(t.Unit)),
+			})
+		}
+		ret.MetricFilters = append(ret.MetricFilters, metricFilter)
+	}
+	return ret, nil
+}
+
+func (a *adapter) DescribeSubscriptionFiltersWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeSubscriptionFiltersInput, _ ...request.Option) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	resp, err := a.client.DescribeSubscriptionFilters(ctx, &cwl.DescribeSubscriptionFiltersInput{
+		FilterNamePrefix: input.FilterNamePrefix,
+		Limit:            toInt32(input.Limit),
+		LogGroupName:     input.LogGroupName,
+		NextToken:        input.NextToken,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.DescribeSubscriptionFiltersOutput{NextToken: resp.NextToken}
+	for _, filter := range resp.SubscriptionFilters {
+		ret.SubscriptionFilters = append(ret.SubscriptionFilters, &cloudwatchlogs.SubscriptionFilter{
+			CreationTime:   filter.CreationTime,
+			DestinationArn: filter.DestinationArn,
+			Distribution:   toString(string(filter.Distribution)),
+			FilterName:     filter.FilterName,
+			FilterPattern:  filter.FilterPattern,
+			LogGroupName:   filter.LogGroupName,
+			RoleArn:        filter.RoleArn,
+		})
+	}
+	return ret, nil
+}
+
+func (a *adapter) DisassociateKmsKeyWithContext(ctx aws.Context, input *cloudwatchlogs.DisassociateKmsKeyInput, _ ...request.Option) (*cloudwatchlogs.DisassociateKmsKeyOutput, error) {
+	_, err := a.client.DisassociateKmsKey(ctx, &cwl.DisassociateKmsKeyInput{
+		LogGroupName:       input.LogGroupName,
+		ResourceIdentifier: input.ResourceIdentifier,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.DisassociateKmsKeyOutput{}, nil
+}
+
+func (a *adapter) FilterLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.FilterLogEventsInput, _ ...request.Option) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	resp, err := a.client.FilterLogEvents(ctx, &cwl.FilterLogEventsInput{
+		EndTime:             input.EndTime,
+		FilterPattern:       input.FilterPattern,
+		Limit:               toInt32(input.Limit),
+		LogGroupName:        input.LogGroupName,
+		LogStreamNamePrefix: input.LogStreamNamePrefix,
+		LogStreamNames:      aws.StringValueSlice(input.LogStreamNames),
+		NextToken:           input.NextToken,
+		StartTime:           input.StartTime,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.FilterLogEventsOutput{NextToken: resp.NextToken}
+	for _, event := range resp.Events {
+		ret.Events = append(ret.Events, &cloudwatchlogs.FilteredLogEvent{
+			EventId:       event.EventId,
+			IngestionTime: event.IngestionTime,
+			LogStreamName: event.LogStreamName,
+			Message:       event.Message,
+			Timestamp:     event.Timestamp,
+		})
+	}
+	return ret, nil
+}
+
+func (a *adapter) GetDataProtectionPolicyWithContext(ctx aws.Context, input *cloudwatchlogs.GetDataProtectionPolicyInput, _ ...request.Option) (*cloudwatchlogs.GetDataProtectionPolicyOutput, error) {
+	resp, err := a.client.GetDataProtectionPolicy(ctx, &cwl.GetDataProtectionPolicyInput{
+		LogGroupIdentifier: input.LogGroupIdentifier,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.GetDataProtectionPolicyOutput{
+		LastUpdatedTime:    resp.LastUpdatedTime,
+		LogGroupIdentifier: resp.LogGroupIdentifier,
+		PolicyDocument:     resp.PolicyDocument,
+	}, nil
+}
+
+func (a *adapter) GetLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.GetLogEventsInput, _ ...request.Option) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	resp, err := a.client.GetLogEvents(ctx, &cwl.GetLogEventsInput{
+		EndTime:       input.EndTime,
+		Limit:         toInt32(input.Limit),
+		LogGroupName:  input.LogGroupName,
+		LogStreamName: input.LogStreamName,
+		NextToken:     input.NextToken,
+		StartFromHead: input.StartFromHead,
+		StartTime:     input.StartTime,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.GetLogEventsOutput{
+		NextBackwardToken: resp.NextBackwardToken,
+		NextForwardToken:  resp.NextForwardToken,
+	}
+	for _, event := range resp.Events {
+		ret.Events = append(ret.Events, &cloudwatchlogs.OutputLogEvent{
+			IngestionTime: event.IngestionTime,
+			Message:       event.Message,
+			Timestamp:     event.Timestamp,
+		})
+	}
+	return ret, nil
+}
+
+func (a *adapter) GetLogRecordWithContext(ctx aws.Context, input *cloudwatchlogs.GetLogRecordInput, _ ...request.Option) (*cloudwatchlogs.GetLogRecordOutput, error) {
+	resp, err := a.client.GetLogRecord(ctx, &cwl.GetLogRecordInput{
+		LogRecordPointer: input.LogRecordPointer,
+		Unmask:           aws.BoolValue(input.Unmask),
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.GetLogRecordOutput{LogRecord: aws.StringMap(resp.LogRecord)}, nil
+}
+
+func (a *adapter) GetQueryResultsWithContext(ctx aws.Context, input *cloudwatchlogs.GetQueryResultsInput, _ ...request.Option) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+	resp, err := a.client.GetQueryResults(ctx, &cwl.GetQueryResultsInput{
+		QueryId: input.QueryId,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.GetQueryResultsOutput{
+		EncryptionKey: resp.EncryptionKey,
+		Status:        toString(string(resp.Status)),
+	}
+	if stats := resp.Statistics; stats != nil {
+		ret.Statistics = &cloudwatchlogs.QueryStatistics{
+			BytesScanned:   aws.Float64(stats.BytesScanned),
+			RecordsMatched: aws.Float64(stats.RecordsMatched),
+			RecordsScanned: aws.Float64(stats.RecordsScanned),
+		}
+	}
+	for _, fields := range resp.Results {
+		row := make([]*cloudwatchlogs.ResultField, 0, len(fields))
+		for _, field := range fields {
+			row = append(row, &cloudwatchlogs.ResultField{Field: field.Field, Value: field.Value})
+		}
+		ret.Results = append(ret.Results, row)
+	}
+	return ret, nil
+}
+
+func (a *adapter) ListAnomaliesWithContext(ctx aws.Context, input *cloudwatchlogs.ListAnomaliesInput, _ ...request.Option) (*cloudwatchlogs.ListAnomaliesOutput, error) {
+	resp, err := a.client.ListAnomalies(ctx, &cwl.ListAnomaliesInput{
+		AnomalyDetectorArn: input.AnomalyDetectorArn,
+		Limit:              toInt32(input.Limit),
+		NextToken:          input.NextToken,
+		SuppressionState:   types.SuppressionState(aws.StringValue(input.SuppressionState)),
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.ListAnomaliesOutput{NextToken: resp.NextToken}
+	for _, anomaly := range resp.Anomalies {
+		ret.Anomalies = append(ret.Anomalies, toAnomaly(anomaly))
+	}
+	return ret, nil
+}
+
+func (a *adapter) ListTagsForResourceWithContext(ctx aws.Context, input *cloudwatchlogs.ListTagsForResourceInput, _ ...request.Option) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+	resp, err := a.client.ListTagsForResource(ctx, &cwl.ListTagsForResourceInput{
+		ResourceArn: input.ResourceArn,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.ListTagsForResourceOutput{Tags: aws.StringMap(resp.Tags)}, nil
+}
+
+func (a *adapter) PutDataProtectionPolicyWithContext(ctx aws.Context, input *cloudwatchlogs.PutDataProtectionPolicyInput, _ ...request.Option) (*cloudwatchlogs.PutDataProtectionPolicyOutput, error) {
+	resp, err := a.client.PutDataProtectionPolicy(ctx, &cwl.PutDataProtectionPolicyInput{
+		LogGroupIdentifier: input.LogGroupIdentifier,
+		PolicyDocument:     input.PolicyDocument,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.PutDataProtectionPolicyOutput{
+		LastUpdatedTime:    resp.LastUpdatedTime,
+		LogGroupIdentifier: resp.LogGroupIdentifier,
+		PolicyDocument:     resp.PolicyDocument,
+	}, nil
+}
+
+func (a *adapter) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.PutLogEventsInput, _ ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	events := make([]types.InputLogEvent, 0, len(input.LogEvents))
+	for _, event := range input.LogEvents {
+		events = append(events, types.InputLogEvent{
+			Message:   event.Message,
+			Timestamp: event.Timestamp,
+		})
+	}
+
+	resp, err := a.client.PutLogEvents(ctx, &cwl.PutLogEventsInput{
+		LogEvents:     events,
+		LogGroupName:  input.LogGroupName,
+		LogStreamName: input.LogStreamName,
+		SequenceToken: input.SequenceToken,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: resp.NextSequenceToken}
+	if info := resp.RejectedLogEventsInfo; info != nil {
+		ret.RejectedLogEventsInfo = &cloudwatchlogs.RejectedLogEventsInfo{
+			ExpiredLogEventEndIndex:  toInt64(info.ExpiredLogEventEndIndex),
+			TooNewLogEventStartIndex: toInt64(info.TooNewLogEventStartIndex),
+			TooOldLogEventEndIndex:   toInt64(info.TooOldLogEventEndIndex),
+		}
+	}
+	return ret, nil
+}
+
+func (a *adapter) PutMetricFilterWithContext(ctx aws.Context, input *cloudwatchlogs.PutMetricFilterInput, _ ...request.Option) (*cloudwatchlogs.PutMetricFilterOutput, error) {
+	transformations := make([]types.MetricTransformation, 0, len(input.MetricTransformations))
+	for _, t := range input.MetricTransformations {
+		transformations = append(transformations, types.MetricTransformation{
+			DefaultValue:    t.DefaultValue,
+			Dimensions:      aws.StringValueMap(t.Dimensions),
+			MetricName:      t.MetricName,
+			MetricNamespace: t.MetricNamespace,
+			MetricValue:     t.MetricValue,
+			Unit:            types.StandardUnit(aws.StringValue(t.Unit)),
+		})
+	}
+
+	_, err := a.client.PutMetricFilter(ctx, &cwl.PutMetricFilterInput{
+		FilterName:            input.FilterName,
+		FilterPattern:         input.FilterPattern,
+		LogGroupName:          input.LogGroupName,
+		MetricTransformations: transformations,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.PutMetricFilterOutput{}, nil
+}
+
+func (a *adapter) PutRetentionPolicyWithContext(ctx aws.Context, input *cloudwatchlogs.PutRetentionPolicyInput, _ ...request.Option) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+	_, err := a.client.PutRetentionPolicy(ctx, &cwl.PutRetentionPolicyInput{
+		LogGroupName:    input.LogGroupName,
+		RetentionInDays: toInt32(input.RetentionInDays),
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
+}
+
+func (a *adapter) PutSubscriptionFilterWithContext(ctx aws.Context, input *cloudwatchlogs.PutSubscriptionFilterInput, _ ...request.Option) (*cloudwatchlogs.PutSubscriptionFilterOutput, error) {
+	_, err := a.client.PutSubscriptionFilter(ctx, &cwl.PutSubscriptionFilterInput{
+		DestinationArn: input.DestinationArn,
+		Distribution:   types.Distribution(aws.StringValue(input.Distribution)),
+		FilterName:     input.FilterName,
+		FilterPattern:  input.FilterPattern,
+		LogGroupName:   input.LogGroupName,
+		RoleArn:        input.RoleArn,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.PutSubscriptionFilterOutput{}, nil
+}
+
+func (a *adapter) StartQueryWithContext(ctx aws.Context, input *cloudwatchlogs.StartQueryInput, _ ...request.Option) (*cloudwatchlogs.StartQueryOutput, error) {
+	resp, err := a.client.StartQuery(ctx, &cwl.StartQueryInput{
+		EndTime:             input.EndTime,
+		Limit:               toInt32(input.Limit),
+		LogGroupIdentifiers: toStrings(input.LogGroupIdentifiers),
+		LogGroupName:        input.LogGroupName,
+		LogGroupNames:       toStrings(input.LogGroupNames),
+		QueryString:         input.QueryString,
+		StartTime:           input.StartTime,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.StartQueryOutput{QueryId: resp.QueryId}, nil
+}
+
+func (a *adapter) StopQueryWithContext(ctx aws.Context, input *cloudwatchlogs.StopQueryInput, _ ...request.Option) (*cloudwatchlogs.StopQueryOutput, error) {
+	resp, err := a.client.StopQuery(ctx, &cwl.StopQueryInput{
+		QueryId: input.QueryId,
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.StopQueryOutput{Success: aws.Bool(resp.Success)}, nil
+}
+
+func (a *adapter) TagResourceWithContext(ctx aws.Context, input *cloudwatchlogs.TagResourceInput, _ ...request.Option) (*cloudwatchlogs.TagResourceOutput, error) {
+	_, err := a.client.TagResource(ctx, &cwl.TagResourceInput{
+		ResourceArn: input.ResourceArn,
+		Tags:        aws.StringValueMap(input.Tags),
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.TagResourceOutput{}, nil
+}
+
+func (a *adapter) TestMetricFilterWithContext(ctx aws.Context, input *cloudwatchlogs.TestMetricFilterInput, _ ...request.Option) (*cloudwatchlogs.TestMetricFilterOutput, error) {
+	resp, err := a.client.TestMetricFilter(ctx, &cwl.TestMetricFilterInput{
+		FilterPattern:    input.FilterPattern,
+		LogEventMessages: aws.StringValueSlice(input.LogEventMessages),
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ret := &cloudwatchlogs.TestMetricFilterOutput{}
+	for _, match := range resp.Matches {
+		ret.Matches = append(ret.Matches, &cloudwatchlogs.MetricFilterMatchRecord{
+			EventMessage:    match.EventMessage,
+			EventNumber:     aws.Int64(match.EventNumber),
+			ExtractedValues: aws.StringMap(match.ExtractedValues),
+		})
+	}
+	return ret, nil
+}
+
+func (a *adapter) UntagResourceWithContext(ctx aws.Context, input *cloudwatchlogs.UntagResourceInput, _ ...request.Option) (*cloudwatchlogs.UntagResourceOutput, error) {
+	_, err := a.client.UntagResource(ctx, &cwl.UntagResourceInput{
+		ResourceArn: input.ResourceArn,
+		TagKeys:     aws.StringValueSlice(input.TagKeys),
+	}, a.optFns...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.UntagResourceOutput{}, nil
+}
+
+func (a *adapter) UpdateAnomalyWithContext(ctx aws.Context, input *cloudwatchlogs.UpdateAnomalyInput, _ ...request.Option) (*cloudwatchlogs.UpdateAnomalyOutput, error) {
+	v2Input := &cwl.UpdateAnomalyInput{
+		AnomalyDetectorArn: input.AnomalyDetectorArn,
+		AnomalyId:          input.AnomalyId,
+		PatternId:          input.PatternId,
+		SuppressionType:    types.SuppressionType(aws.StringValue(input.SuppressionType)),
+	}
+	if p := input.SuppressionPeriod; p != nil {
+		v2Input.SuppressionPeriod = &types.SuppressionPeriod{
+			SuppressionUnit: types.SuppressionUnit(aws.StringValue(p.SuppressionUnit)),
+			Value:           int32(aws.Int64Value(p.Value)),
+		}
+	}
+
+	if _, err := a.client.UpdateAnomaly(ctx, v2Input, a.optFns...); err != nil {
+		return nil, translateError(err)
+	}
+	return &cloudwatchlogs.UpdateAnomalyOutput{}, nil
+}
+
+// translateError converts errors returned by the v2 client to the errors the
+// v1 client would have returned, so that the cloudwatch package handles them
+// the same way.
+func translateError(err error) error {
+	var (
+		notFound        *types.ResourceNotFoundException
+		alreadyExists   *types.ResourceAlreadyExistsException
+		invalidSequence *types.InvalidSequenceTokenException
+		alreadyAccepted *types.DataAlreadyAcceptedException
+		apiErr          smithy.APIError
+	)
+
+	switch {
+	case errors.As(err, &notFound):
+		return &cloudwatchlogs.ResourceNotFoundException{Message_: notFound.Message}
+	case errors.As(err, &alreadyExists):
+		return &cloudwatchlogs.ResourceAlreadyExistsException{Message_: alreadyExists.Message}
+	case errors.As(err, &invalidSequence):
+		return &cloudwatchlogs.InvalidSequenceTokenException{
+			ExpectedSequenceToken: invalidSequence.ExpectedSequenceToken,
+			Message_:              invalidSequence.Message,
+		}
+	case errors.As(err, &alreadyAccepted):
+		return &cloudwatchlogs.DataAlreadyAcceptedException{
+			ExpectedSequenceToken: alreadyAccepted.ExpectedSequenceToken,
+			Message_:              alreadyAccepted.Message,
+		}
+	case errors.As(err, &apiErr):
+		// This keeps throttling errors recognizable by their error code.
+		return awserr.New(apiErr.ErrorCode(), apiErr.ErrorMessage(), err)
+	default:
+		return err
+	}
+}
+
+func toAnomaly(a types.Anomaly) *cloudwatchlogs.Anomaly {
+	ret := &cloudwatchlogs.Anomaly{
+		Active:                    a.Active,
+		AnomalyDetectorArn:        a.AnomalyDetectorArn,
+		AnomalyId:                 a.AnomalyId,
+		Description:               a.Description,
+		FirstSeen:                 aws.Int64(a.FirstSeen),
+		Histogram:                 aws.Int64Map(a.Histogram),
+		IsPatternLevelSuppression: a.IsPatternLevelSuppression,
+		LastSeen:                  aws.Int64(a.LastSeen),
+		LogGroupArnList:           aws.StringSlice(a.LogGroupArnList),
+		PatternId:                 a.PatternId,
+		PatternRegex:              a.PatternRegex,
+		PatternString:             a.PatternString,
+		Priority:                  a.Priority,
+		State:                     toString(string(a.State)),
+		Suppressed:                a.Suppressed,
+		SuppressedDate:            aws.Int64(a.SuppressedDate),
+		SuppressedUntil:           aws.Int64(a.SuppressedUntil),
+	}
+	for _, sample := range a.LogSamples {
+		ret.LogSamples = append(ret.LogSamples, &cloudwatchlogs.LogEvent{Message: sample.Message, Timestamp: sample.Timestamp})
+	}
+	for _, token := range a.PatternTokens {
+		ret.PatternTokens = append(ret.PatternTokens, &cloudwatchlogs.PatternToken{
+			DynamicTokenPosition: aws.Int64(int64(token.DynamicTokenPosition)),
+			Enumerations:         aws.Int64Map(token.Enumerations),
+			IsDynamic:            token.IsDynamic,
+			TokenString:          token.TokenString,
+		})
+	}
+	return ret
+}
+
+func toInt32(v *int64) *int32 {
+	if v == nil {
+		return nil
+	}
+	ret := int32(*v)
+	return &ret
+}
+
+func toInt64(v *int32) *int64 {
+	if v == nil {
+		return nil
+	}
+	return aws.Int64(int64(*v))
+}
+
+// toStrings keeps optional lists which aren't set nil, since the v2 client
+// sends empty lists.
+func toStrings(v []*string) []string {
+	if v == nil {
+		return nil
+	}
+	return aws.StringValueSlice(v)
+}
+
+func toString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return aws.String(v)
+}
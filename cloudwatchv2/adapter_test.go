@@ -0,0 +1,632 @@
+package cloudwatchv2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	v1 "github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+	"github.com/deliveroo/cloudwatch-go/cloudwatchanomalies"
+	"github.com/deliveroo/cloudwatch-go/cloudwatchinsights"
+)
+
+type mockV2API struct {
+	mock.Mock
+
+	// Options of the last PutRetentionPolicy request.
+	optFns []func(*cloudwatchlogs.Options)
+}
+
+func (m *mockV2API) AssociateKmsKey(ctx context.Context, input *cloudwatchlogs.AssociateKmsKeyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.AssociateKmsKeyOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.AssociateKmsKeyOutput), args.Error(1)
+}
+
+func (m *mockV2API) CreateExportTask(ctx context.Context, input *cloudwatchlogs.CreateExportTaskInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateExportTaskOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.CreateExportTaskOutput), args.Error(1)
+}
+
+func (m *mockV2API) CreateLogAnomalyDetector(ctx context.Context, input *cloudwatchlogs.CreateLogAnomalyDetectorInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogAnomalyDetectorOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.CreateLogAnomalyDetectorOutput), args.Error(1)
+}
+
+func (m *mockV2API) CreateLogGroup(ctx context.Context, input *cloudwatchlogs.CreateLogGroupInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.CreateLogGroupOutput), args.Error(1)
+}
+
+func (m *mockV2API) CreateLogStream(ctx context.Context, input *cloudwatchlogs.CreateLogStreamInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.CreateLogStreamOutput), args.Error(1)
+}
+
+func (m *mockV2API) DeleteDataProtectionPolicy(ctx context.Context, input *cloudwatchlogs.DeleteDataProtectionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteDataProtectionPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DeleteDataProtectionPolicyOutput), args.Error(1)
+}
+
+func (m *mockV2API) DeleteLogGroup(ctx context.Context, input *cloudwatchlogs.DeleteLogGroupInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DeleteLogGroupOutput), args.Error(1)
+}
+
+func (m *mockV2API) DeleteLogStream(ctx context.Context, input *cloudwatchlogs.DeleteLogStreamInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogStreamOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DeleteLogStreamOutput), args.Error(1)
+}
+
+func (m *mockV2API) DeleteMetricFilter(ctx context.Context, input *cloudwatchlogs.DeleteMetricFilterInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteMetricFilterOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DeleteMetricFilterOutput), args.Error(1)
+}
+
+func (m *mockV2API) DeleteRetentionPolicy(ctx context.Context, input *cloudwatchlogs.DeleteRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteRetentionPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DeleteRetentionPolicyOutput), args.Error(1)
+}
+
+func (m *mockV2API) DeleteSubscriptionFilter(ctx context.Context, input *cloudwatchlogs.DeleteSubscriptionFilterInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteSubscriptionFilterOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DeleteSubscriptionFilterOutput), args.Error(1)
+}
+
+func (m *mockV2API) DescribeExportTasks(ctx context.Context, input *cloudwatchlogs.DescribeExportTasksInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeExportTasksOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DescribeExportTasksOutput), args.Error(1)
+}
+
+func (m *mockV2API) DescribeLogGroups(ctx context.Context, input *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DescribeLogGroupsOutput), args.Error(1)
+}
+
+func (m *mockV2API) DescribeLogStreams(ctx context.Context, input *cloudwatchlogs.DescribeLogStreamsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DescribeLogStreamsOutput), args.Error(1)
+}
+
+func (m *mockV2API) DescribeMetricFilters(ctx context.Context, input *cloudwatchlogs.DescribeMetricFiltersInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DescribeMetricFiltersOutput), args.Error(1)
+}
+
+func (m *mockV2API) DescribeSubscriptionFilters(ctx context.Context, input *cloudwatchlogs.DescribeSubscriptionFiltersInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DescribeSubscriptionFiltersOutput), args.Error(1)
+}
+
+func (m *mockV2API) DisassociateKmsKey(ctx context.Context, input *cloudwatchlogs.DisassociateKmsKeyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DisassociateKmsKeyOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.DisassociateKmsKeyOutput), args.Error(1)
+}
+
+func (m *mockV2API) FilterLogEvents(ctx context.Context, input *cloudwatchlogs.FilterLogEventsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.FilterLogEventsOutput), args.Error(1)
+}
+
+func (m *mockV2API) GetDataProtectionPolicy(ctx context.Context, input *cloudwatchlogs.GetDataProtectionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.GetDataProtectionPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.GetDataProtectionPolicyOutput), args.Error(1)
+}
+
+func (m *mockV2API) GetLogEvents(ctx context.Context, input *cloudwatchlogs.GetLogEventsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.GetLogEventsOutput), args.Error(1)
+}
+
+func (m *mockV2API) GetLogRecord(ctx context.Context, input *cloudwatchlogs.GetLogRecordInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.GetLogRecordOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.GetLogRecordOutput), args.Error(1)
+}
+
+func (m *mockV2API) GetQueryResults(ctx context.Context, input *cloudwatchlogs.GetQueryResultsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.GetQueryResultsOutput), args.Error(1)
+}
+
+func (m *mockV2API) ListAnomalies(ctx context.Context, input *cloudwatchlogs.ListAnomaliesInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListAnomaliesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.ListAnomaliesOutput), args.Error(1)
+}
+
+func (m *mockV2API) ListTagsForResource(ctx context.Context, input *cloudwatchlogs.ListTagsForResourceInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.ListTagsForResourceOutput), args.Error(1)
+}
+
+func (m *mockV2API) PutDataProtectionPolicy(ctx context.Context, input *cloudwatchlogs.PutDataProtectionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutDataProtectionPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.PutDataProtectionPolicyOutput), args.Error(1)
+}
+
+func (m *mockV2API) PutLogEvents(ctx context.Context, input *cloudwatchlogs.PutLogEventsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.PutLogEventsOutput), args.Error(1)
+}
+
+func (m *mockV2API) PutMetricFilter(ctx context.Context, input *cloudwatchlogs.PutMetricFilterInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutMetricFilterOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.PutMetricFilterOutput), args.Error(1)
+}
+
+func (m *mockV2API) PutRetentionPolicy(ctx context.Context, input *cloudwatchlogs.PutRetentionPolicyInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+	m.optFns = optFns
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.PutRetentionPolicyOutput), args.Error(1)
+}
+
+func (m *mockV2API) PutSubscriptionFilter(ctx context.Context, input *cloudwatchlogs.PutSubscriptionFilterInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutSubscriptionFilterOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.PutSubscriptionFilterOutput), args.Error(1)
+}
+
+func (m *mockV2API) StartQuery(ctx context.Context, input *cloudwatchlogs.StartQueryInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.StartQueryOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.StartQueryOutput), args.Error(1)
+}
+
+func (m *mockV2API) StopQuery(ctx context.Context, input *cloudwatchlogs.StopQueryInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.StopQueryOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.StopQueryOutput), args.Error(1)
+}
+
+func (m *mockV2API) TagResource(ctx context.Context, input *cloudwatchlogs.TagResourceInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.TagResourceOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.TagResourceOutput), args.Error(1)
+}
+
+func (m *mockV2API) TestMetricFilter(ctx context.Context, input *cloudwatchlogs.TestMetricFilterInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.TestMetricFilterOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.TestMetricFilterOutput), args.Error(1)
+}
+
+func (m *mockV2API) UntagResource(ctx context.Context, input *cloudwatchlogs.UntagResourceInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.UntagResourceOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.UntagResourceOutput), args.Error(1)
+}
+
+func (m *mockV2API) UpdateAnomaly(ctx context.Context, input *cloudwatchlogs.UpdateAnomalyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.UpdateAnomalyOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*cloudwatchlogs.UpdateAnomalyOutput), args.Error(1)
+}
+
+type adapterTestSuite struct {
+	suite.Suite
+
+	api                   *mockV2API
+	ctx                   context.Context
+	groupName, streamName string
+	sut                   cloudwatch.Group
+}
+
+func (a *adapterTestSuite) SetupTest() {
+	a.api = new(mockV2API)
+	a.ctx = context.Background()
+	a.groupName = "groupName"
+	a.streamName = "streamName"
+	a.sut = NewGroupV2(a.api, a.groupName)
+}
+
+func (a *adapterTestSuite) TestCreateAndWrite() {
+	a.api.On(
+		"CreateLogStream",
+		a.ctx,
+		&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(a.groupName),
+			LogStreamName: aws.String(a.streamName),
+		},
+	).Return((*cloudwatchlogs.CreateLogStreamOutput)(nil), &types.ResourceAlreadyExistsException{})
+
+	a.api.On(
+		"DescribeLogStreams",
+		a.ctx,
+		&cloudwatchlogs.DescribeLogStreamsInput{
+			LogGroupName:        aws.String(a.groupName),
+			LogStreamNamePrefix: aws.String(a.streamName),
+		},
+	).Return(&cloudwatchlogs.DescribeLogStreamsOutput{
+		LogStreams: []types.LogStream{{LogStreamName: aws.String(a.streamName), UploadSequenceToken: aws.String("token")}},
+	}, nil)
+
+	a.api.On(
+		"PutLogEvents",
+		a.ctx,
+		mock.MatchedBy(func(input *cloudwatchlogs.PutLogEventsInput) bool {
+			return aws.StringValue(input.SequenceToken) == "token" &&
+				len(input.LogEvents) == 1 &&
+				aws.StringValue(input.LogEvents[0].Message) == "Hello"
+		}),
+	).Once().Return(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("next")}, nil)
+
+	writer, err := a.sut.Create(a.ctx, a.streamName)
+	a.Require().NoError(err)
+
+	_, err = writer.Write([]byte("Hello"))
+	a.Require().NoError(err)
+	a.NoError(writer.Close())
+	a.api.AssertExpectations(a.T())
+}
+
+func (a *adapterTestSuite) TestRead() {
+	ctx, cancel := context.WithCancel(a.ctx)
+	defer cancel()
+
+	a.api.On(
+		"GetLogEvents",
+		ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(a.groupName),
+			LogStreamName: aws.String(a.streamName),
+			StartFromHead: aws.Bool(true),
+		},
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []types.OutputLogEvent{
+			{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+		},
+		NextForwardToken: aws.String("next"),
+	}, nil)
+
+	a.api.On(
+		"GetLogEvents",
+		ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(a.groupName),
+			LogStreamName: aws.String(a.streamName),
+			StartFromHead: aws.Bool(true),
+			NextToken:     aws.String("next"),
+		},
+	).Return(&cloudwatchlogs.GetLogEventsOutput{NextForwardToken: aws.String("next")}, nil)
+
+	events, _ := a.sut.Events(ctx, a.streamName)
+
+	select {
+	case event := <-events:
+		a.Equal("Hello", aws.StringValue(event.Message))
+		a.Equal(int64(1000), aws.Int64Value(event.Timestamp))
+	case <-time.After(time.Second):
+		a.Fail("timed out waiting for an event")
+	}
+}
+
+func (a *adapterTestSuite) TestRetention() {
+	a.api.On(
+		"PutRetentionPolicy",
+		a.ctx,
+		&cloudwatchlogs.PutRetentionPolicyInput{
+			LogGroupName:    aws.String(a.groupName),
+			RetentionInDays: aws.Int32(14),
+		},
+	).Once().Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+
+	a.NoError(a.sut.SetRetention(a.ctx, 14))
+	a.api.AssertExpectations(a.T())
+}
+
+func (a *adapterTestSuite) TestEndpointURL() {
+	a.sut = NewGroupV2(a.api, a.groupName, WithEndpointURL("http://localhost:4566"))
+	a.api.On("PutRetentionPolicy", a.ctx, mock.Anything).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+
+	a.Require().NoError(a.sut.SetRetention(a.ctx, 14))
+	var opts cloudwatchlogs.Options
+	for _, fn := range a.api.optFns {
+		fn(&opts)
+	}
+	a.Equal("http://localhost:4566", aws.StringValue(opts.BaseEndpoint))
+
+	// An invalid URL makes requests fail before being sent.
+	a.sut = NewGroupV2(a.api, a.groupName, WithEndpointURL("localhost:4566"))
+	a.Require().NoError(a.sut.SetRetention(a.ctx, 14))
+	opts = cloudwatchlogs.Options{}
+	for _, fn := range a.api.optFns {
+		fn(&opts)
+	}
+	a.Require().Len(opts.APIOptions, 1)
+	a.EqualError(opts.APIOptions[0](nil), `invalid endpoint URL "localhost:4566": missing scheme or host`)
+}
+
+func (a *adapterTestSuite) TestDeleteStreamNotFound() {
+	a.api.On(
+		"DeleteLogStream",
+		a.ctx,
+		&cloudwatchlogs.DeleteLogStreamInput{
+			LogGroupName:  aws.String(a.groupName),
+			LogStreamName: aws.String(a.streamName),
+		},
+	).Once().Return((*cloudwatchlogs.DeleteLogStreamOutput)(nil), &types.ResourceNotFoundException{})
+
+	a.Equal(cloudwatch.ErrNotFound, a.sut.DeleteStream(a.ctx, a.streamName))
+}
+
+func (a *adapterTestSuite) TestListStreams() {
+	a.api.On(
+		"DescribeLogStreams",
+		a.ctx,
+		&cloudwatchlogs.DescribeLogStreamsInput{
+			LogGroupName:        aws.String(a.groupName),
+			LogStreamNamePrefix: aws.String("web-"),
+		},
+	).Once().Return(&cloudwatchlogs.DescribeLogStreamsOutput{
+		LogStreams: []types.LogStream{
+			{LogStreamName: aws.String("web-1")},
+			{LogStreamName: aws.String("web-2")},
+		},
+	}, nil)
+
+	names, err := a.sut.ListStreams(a.ctx, "web-")
+
+	a.NoError(err)
+	a.Equal([]string{"web-1", "web-2"}, names)
+}
+
+func (a *adapterTestSuite) TestTags() {
+	const arn = "arn:aws:logs:eu-west-1:123456789012:log-group:groupName"
+
+	a.api.On(
+		"DescribeLogGroups",
+		a.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(a.groupName)},
+	).Once().Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []types.LogGroup{
+			{LogGroupName: aws.String(a.groupName), Arn: aws.String(arn + ":*")},
+		},
+	}, nil)
+
+	a.api.On(
+		"ListTagsForResource",
+		a.ctx,
+		&cloudwatchlogs.ListTagsForResourceInput{ResourceArn: aws.String(arn)},
+	).Once().Return(&cloudwatchlogs.ListTagsForResourceOutput{
+		Tags: map[string]string{"team": "logistics"},
+	}, nil)
+
+	tags, err := a.sut.ListTags(a.ctx)
+
+	a.NoError(err)
+	a.Equal(map[string]string{"team": "logistics"}, tags)
+}
+
+func (a *adapterTestSuite) TestMetricFilters() {
+	a.api.On(
+		"PutMetricFilter",
+		a.ctx,
+		&cloudwatchlogs.PutMetricFilterInput{
+			FilterName:    aws.String("errors"),
+			FilterPattern: aws.String("ERROR"),
+			LogGroupName:  aws.String(a.groupName),
+			MetricTransformations: []types.MetricTransformation{{
+				Dimensions:      map[string]string{"service": "$.service"},
+				MetricName:      aws.String("Errors"),
+				MetricNamespace: aws.String("App"),
+				MetricValue:     aws.String("1"),
+				Unit:            types.StandardUnitCount,
+			}},
+		},
+	).Once().Return(&cloudwatchlogs.PutMetricFilterOutput{}, nil)
+
+	a.api.On(
+		"DescribeMetricFilters",
+		a.ctx,
+		&cloudwatchlogs.DescribeMetricFiltersInput{LogGroupName: aws.String(a.groupName)},
+	).Once().Return(&cloudwatchlogs.DescribeMetricFiltersOutput{
+		MetricFilters: []types.MetricFilter{{
+			FilterName: aws.String("errors"),
+			MetricTransformations: []types.MetricTransformation{
+				{MetricName: aws.String("Errors"), Unit: types.StandardUnitCount},
+			},
+		}},
+	}, nil)
+
+	err := a.sut.SetMetricFilter(a.ctx, cloudwatch.MetricFilterOptions{
+		FilterName:    "errors",
+		FilterPattern: "ERROR",
+		MetricTransformations: []*v1.MetricTransformation{{
+			Dimensions:      aws.StringMap(map[string]string{"service": "$.service"}),
+			MetricName:      aws.String("Errors"),
+			MetricNamespace: aws.String("App"),
+			MetricValue:     aws.String("1"),
+			Unit:            aws.String(v1.StandardUnitCount),
+		}},
+	})
+	a.Require().NoError(err)
+
+	filters, err := a.sut.ListMetricFilters(a.ctx)
+	a.Require().NoError(err)
+	a.Require().Len(filters, 1)
+	a.Equal("errors", aws.StringValue(filters[0].FilterName))
+	a.Equal(v1.StandardUnitCount, aws.StringValue(filters[0].MetricTransformations[0].Unit))
+}
+
+func (a *adapterTestSuite) TestGetRecord() {
+	a.api.On(
+		"GetLogRecord",
+		a.ctx,
+		&cloudwatchlogs.GetLogRecordInput{LogRecordPointer: aws.String("pointer")},
+	).Once().Return(&cloudwatchlogs.GetLogRecordOutput{
+		LogRecord: map[string]string{"@message": "Hello"},
+	}, nil)
+
+	record, err := a.sut.GetRecord(a.ctx, "pointer")
+
+	a.NoError(err)
+	a.Equal(map[string]string{"@message": "Hello"}, record)
+}
+
+func (a *adapterTestSuite) TestLiveTailUnsupported() {
+	a.api.On(
+		"DescribeLogGroups",
+		a.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(a.groupName)},
+	).Once().Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []types.LogGroup{
+			{LogGroupName: aws.String(a.groupName), Arn: aws.String("arn:aws:logs:eu-west-1:123456789012:log-group:groupName:*")},
+		},
+	}, nil)
+
+	events, errs := a.sut.LiveTail(a.ctx, nil, "")
+
+	_, ok := <-events
+	a.False(ok)
+	a.Equal(&UnsupportedError{Operation: "StartLiveTail"}, <-errs)
+}
+
+func (a *adapterTestSuite) TestUnsupported() {
+	_, err := a.sut.DescribeQueriesWithContext(a.ctx, &v1.DescribeQueriesInput{})
+	a.Equal(&UnsupportedError{Operation: "DescribeQueries"}, err)
+
+	// Only the requests with a context are translated.
+	_, err = a.sut.DeleteLogGroup(&v1.DeleteLogGroupInput{})
+	a.Equal(&UnsupportedError{Operation: "DeleteLogGroup"}, err)
+
+	req, _ := a.sut.DeleteLogGroupRequest(&v1.DeleteLogGroupInput{})
+	a.Equal(&UnsupportedError{Operation: "DeleteLogGroup"}, req.Send())
+}
+
+func (a *adapterTestSuite) TestInsightsQuery() {
+	start, end := time.Unix(1000, 0), time.Unix(2000, 0)
+
+	a.api.On(
+		"StartQuery",
+		a.ctx,
+		&cloudwatchlogs.StartQueryInput{
+			LogGroupName: aws.String(a.groupName),
+			QueryString:  aws.String("fields @message"),
+			StartTime:    aws.Int64(1000),
+			EndTime:      aws.Int64(2000),
+			Limit:        aws.Int32(10),
+		},
+	).Once().Return(&cloudwatchlogs.StartQueryOutput{QueryId: aws.String("query")}, nil)
+
+	a.api.On(
+		"GetQueryResults",
+		a.ctx,
+		&cloudwatchlogs.GetQueryResultsInput{QueryId: aws.String("query")},
+	).Once().Return(&cloudwatchlogs.GetQueryResultsOutput{
+		Status: types.QueryStatusComplete,
+		Results: [][]types.ResultField{
+			{{Field: aws.String("@message"), Value: aws.String("Hello")}},
+		},
+		Statistics: &types.QueryStatistics{RecordsMatched: 1},
+	}, nil)
+
+	result, err := cloudwatchinsights.NewGroup(a.sut).Query(a.ctx, "fields @message", start, end, 10)
+	a.Require().NoError(err)
+	a.Equal(v1.QueryStatusComplete, result.Status)
+	a.Equal([]map[string]string{{"@message": "Hello"}}, result.Rows)
+	a.Equal(1.0, aws.Float64Value(result.Statistics.RecordsMatched))
+
+	a.api.On(
+		"StopQuery",
+		a.ctx,
+		&cloudwatchlogs.StopQueryInput{QueryId: aws.String("query")},
+	).Once().Return(&cloudwatchlogs.StopQueryOutput{Success: true}, nil)
+
+	a.NoError(cloudwatchinsights.NewGroup(a.sut).StopQuery(a.ctx, "query"))
+	a.api.AssertExpectations(a.T())
+}
+
+func (a *adapterTestSuite) TestAnomalies() {
+	const detectorARN = "arn:aws:logs:eu-west-1:123456789012:anomaly-detector:abc"
+
+	a.api.On(
+		"ListAnomalies",
+		a.ctx,
+		&cloudwatchlogs.ListAnomaliesInput{AnomalyDetectorArn: aws.String(detectorARN)},
+	).Once().Return(&cloudwatchlogs.ListAnomaliesOutput{
+		Anomalies: []types.Anomaly{{
+			AnomalyId:     aws.String("anomaly"),
+			FirstSeen:     1000,
+			PatternString: aws.String("<*> failed"),
+			State:         types.StateActive,
+			LogSamples:    []types.LogEvent{{Message: aws.String("payment failed"), Timestamp: aws.Int64(1000)}},
+		}},
+	}, nil)
+
+	a.api.On(
+		"UpdateAnomaly",
+		a.ctx,
+		&cloudwatchlogs.UpdateAnomalyInput{
+			AnomalyDetectorArn: aws.String(detectorARN),
+			AnomalyId:          aws.String("anomaly"),
+			SuppressionType:    types.SuppressionTypeInfinite,
+		},
+	).Once().Return(&cloudwatchlogs.UpdateAnomalyOutput{}, nil)
+
+	group := cloudwatchanomalies.NewGroup(a.sut)
+	anomalies, err := group.ListAnomalies(a.ctx, detectorARN)
+	a.Require().NoError(err)
+	a.Require().Len(anomalies, 1)
+	a.Equal("anomaly", aws.StringValue(anomalies[0].AnomalyId))
+	a.Equal(int64(1000), aws.Int64Value(anomalies[0].FirstSeen))
+	a.Equal(v1.StateActive, aws.StringValue(anomalies[0].State))
+	a.Equal("payment failed", aws.StringValue(anomalies[0].LogSamples[0].Message))
+
+	a.NoError(group.UpdateAnomaly(a.ctx, "anomaly", detectorARN, true))
+	a.api.AssertExpectations(a.T())
+}
+
+func (a *adapterTestSuite) TestGroupOptions() {
+	logger := new(recordingLogger)
+	sut := NewGroupV2(a.api, a.groupName, WithGroupOptions(cloudwatch.WithGroupLogger(logger)))
+
+	a.api.On(
+		"CreateLogStream",
+		a.ctx,
+		&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(a.groupName),
+			LogStreamName: aws.String(a.streamName),
+		},
+	).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil)
+
+	writer, err := sut.Create(a.ctx, a.streamName)
+	a.Require().NoError(err)
+	a.NoError(writer.Close())
+	a.Contains(logger.messages, "created log stream")
+}
+
+func (a *adapterTestSuite) TestTranslateError() {
+	throttled := &smithy.GenericAPIError{Code: "ThrottlingException", Message: "slow down"}
+	translated := translateError(throttled)
+	a.Equal("ThrottlingException", translated.(awserr.Error).Code())
+	a.True(request.IsErrorThrottle(translated))
+
+	invalid := translateError(&types.InvalidSequenceTokenException{ExpectedSequenceToken: aws.String("token")})
+	a.Equal("token", aws.StringValue(invalid.(*v1.InvalidSequenceTokenException).ExpectedSequenceToken))
+
+	accepted := translateError(&types.DataAlreadyAcceptedException{ExpectedSequenceToken: aws.String("token")})
+	a.Equal("token", aws.StringValue(accepted.(*v1.DataAlreadyAcceptedException).ExpectedSequenceToken))
+
+	a.IsType(new(v1.ResourceNotFoundException), translateError(&types.ResourceNotFoundException{}))
+	a.IsType(new(v1.ResourceAlreadyExistsException), translateError(&types.ResourceAlreadyExistsException{}))
+
+	other := errors.New("bacon")
+	a.Equal(other, translateError(other))
+	a.Equal(io.EOF, translateError(io.EOF))
+}
+
+// recordingLogger records the messages it's given.
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Info(msg string, _ ...interface{})  { l.messages = append(l.messages, msg) }
+func (l *recordingLogger) Warn(msg string, _ ...interface{})  { l.messages = append(l.messages, msg) }
+func (l *recordingLogger) Error(msg string, _ ...interface{}) { l.messages = append(l.messages, msg) }
+
+func TestAdapter(t *testing.T) {
+	suite.Run(t, new(adapterTestSuite))
+}
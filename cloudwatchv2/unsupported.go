@@ -0,0 +1,996 @@
+package cloudwatchv2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// unsupportedClient implements the aws-sdk-go CloudWatch Logs API by failing
+// every request with an *UnsupportedError. The adapter embeds it, so that the
+// requests it doesn't translate fail rather than panic.
+type unsupportedClient struct{}
+
+func (unsupportedClient) AssociateKmsKey(*cloudwatchlogs.AssociateKmsKeyInput) (*cloudwatchlogs.AssociateKmsKeyOutput, error) {
+	return nil, &UnsupportedError{Operation: "AssociateKmsKey"}
+}
+
+func (unsupportedClient) AssociateKmsKeyWithContext(aws.Context, *cloudwatchlogs.AssociateKmsKeyInput, ...request.Option) (*cloudwatchlogs.AssociateKmsKeyOutput, error) {
+	return nil, &UnsupportedError{Operation: "AssociateKmsKey"}
+}
+
+func (unsupportedClient) AssociateKmsKeyRequest(*cloudwatchlogs.AssociateKmsKeyInput) (*request.Request, *cloudwatchlogs.AssociateKmsKeyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "AssociateKmsKey"}}, nil
+}
+
+func (unsupportedClient) CancelExportTask(*cloudwatchlogs.CancelExportTaskInput) (*cloudwatchlogs.CancelExportTaskOutput, error) {
+	return nil, &UnsupportedError{Operation: "CancelExportTask"}
+}
+
+func (unsupportedClient) CancelExportTaskWithContext(aws.Context, *cloudwatchlogs.CancelExportTaskInput, ...request.Option) (*cloudwatchlogs.CancelExportTaskOutput, error) {
+	return nil, &UnsupportedError{Operation: "CancelExportTask"}
+}
+
+func (unsupportedClient) CancelExportTaskRequest(*cloudwatchlogs.CancelExportTaskInput) (*request.Request, *cloudwatchlogs.CancelExportTaskOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "CancelExportTask"}}, nil
+}
+
+func (unsupportedClient) CreateDelivery(*cloudwatchlogs.CreateDeliveryInput) (*cloudwatchlogs.CreateDeliveryOutput, error) {
+	return nil, &UnsupportedError{Operation: "CreateDelivery"}
+}
+
+func (unsupportedClient) CreateDeliveryWithContext(aws.Context, *cloudwatchlogs.CreateDeliveryInput, ...request.Option) (*cloudwatchlogs.CreateDeliveryOutput, error) {
+	return nil, &UnsupportedError{Operation: "CreateDelivery"}
+}
+
+func (unsupportedClient) CreateDeliveryRequest(*cloudwatchlogs.CreateDeliveryInput) (*request.Request, *cloudwatchlogs.CreateDeliveryOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "CreateDelivery"}}, nil
+}
+
+func (unsupportedClient) CreateExportTask(*cloudwatchlogs.CreateExportTaskInput) (*cloudwatchlogs.CreateExportTaskOutput, error) {
+	return nil, &UnsupportedError{Operation: "CreateExportTask"}
+}
+
+func (unsupportedClient) CreateExportTaskWithContext(aws.Context, *cloudwatchlogs.CreateExportTaskInput, ...request.Option) (*cloudwatchlogs.CreateExportTaskOutput, error) {
+	return nil, &UnsupportedError{Operation: "CreateExportTask"}
+}
+
+func (unsupportedClient) CreateExportTaskRequest(*cloudwatchlogs.CreateExportTaskInput) (*request.Request, *cloudwatchlogs.CreateExportTaskOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "CreateExportTask"}}, nil
+}
+
+func (unsupportedClient) CreateLogAnomalyDetector(*cloudwatchlogs.CreateLogAnomalyDetectorInput) (*cloudwatchlogs.CreateLogAnomalyDetectorOutput, error) {
+	return nil, &UnsupportedError{Operation: "CreateLogAnomalyDetector"}
+}
+
+func (unsupportedClient) CreateLogAnomalyDetectorWithContext(aws.Context, *cloudwatchlogs.CreateLogAnomalyDetectorInput, ...request.Option) (*cloudwatchlogs.CreateLogAnomalyDetectorOutput, error) {
+	return nil, &UnsupportedError{Operation: "CreateLogAnomalyDetector"}
+}
+
+func (unsupportedClient) CreateLogAnomalyDetectorRequest(*cloudwatchlogs.CreateLogAnomalyDetectorInput) (*request.Request, *cloudwatchlogs.CreateLogAnomalyDetectorOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "CreateLogAnomalyDetector"}}, nil
+}
+
+func (unsupportedClient) CreateLogGroup(*cloudwatchlogs.CreateLogGroupInput) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	return nil, &UnsupportedError{Operation: "CreateLogGroup"}
+}
+
+func (unsupportedClient) CreateLogGroupWithContext(aws.Context, *cloudwatchlogs.CreateLogGroupInput, ...request.Option) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	return nil, &UnsupportedError{Operation: "CreateLogGroup"}
+}
+
+func (unsupportedClient) CreateLogGroupRequest(*cloudwatchlogs.CreateLogGroupInput) (*request.Request, *cloudwatchlogs.CreateLogGroupOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "CreateLogGroup"}}, nil
+}
+
+func (unsupportedClient) CreateLogStream(*cloudwatchlogs.CreateLogStreamInput) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	return nil, &UnsupportedError{Operation: "CreateLogStream"}
+}
+
+func (unsupportedClient) CreateLogStreamWithContext(aws.Context, *cloudwatchlogs.CreateLogStreamInput, ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	return nil, &UnsupportedError{Operation: "CreateLogStream"}
+}
+
+func (unsupportedClient) CreateLogStreamRequest(*cloudwatchlogs.CreateLogStreamInput) (*request.Request, *cloudwatchlogs.CreateLogStreamOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "CreateLogStream"}}, nil
+}
+
+func (unsupportedClient) DeleteAccountPolicy(*cloudwatchlogs.DeleteAccountPolicyInput) (*cloudwatchlogs.DeleteAccountPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteAccountPolicy"}
+}
+
+func (unsupportedClient) DeleteAccountPolicyWithContext(aws.Context, *cloudwatchlogs.DeleteAccountPolicyInput, ...request.Option) (*cloudwatchlogs.DeleteAccountPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteAccountPolicy"}
+}
+
+func (unsupportedClient) DeleteAccountPolicyRequest(*cloudwatchlogs.DeleteAccountPolicyInput) (*request.Request, *cloudwatchlogs.DeleteAccountPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteAccountPolicy"}}, nil
+}
+
+func (unsupportedClient) DeleteDataProtectionPolicy(*cloudwatchlogs.DeleteDataProtectionPolicyInput) (*cloudwatchlogs.DeleteDataProtectionPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDataProtectionPolicy"}
+}
+
+func (unsupportedClient) DeleteDataProtectionPolicyWithContext(aws.Context, *cloudwatchlogs.DeleteDataProtectionPolicyInput, ...request.Option) (*cloudwatchlogs.DeleteDataProtectionPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDataProtectionPolicy"}
+}
+
+func (unsupportedClient) DeleteDataProtectionPolicyRequest(*cloudwatchlogs.DeleteDataProtectionPolicyInput) (*request.Request, *cloudwatchlogs.DeleteDataProtectionPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteDataProtectionPolicy"}}, nil
+}
+
+func (unsupportedClient) DeleteDelivery(*cloudwatchlogs.DeleteDeliveryInput) (*cloudwatchlogs.DeleteDeliveryOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDelivery"}
+}
+
+func (unsupportedClient) DeleteDeliveryWithContext(aws.Context, *cloudwatchlogs.DeleteDeliveryInput, ...request.Option) (*cloudwatchlogs.DeleteDeliveryOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDelivery"}
+}
+
+func (unsupportedClient) DeleteDeliveryRequest(*cloudwatchlogs.DeleteDeliveryInput) (*request.Request, *cloudwatchlogs.DeleteDeliveryOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteDelivery"}}, nil
+}
+
+func (unsupportedClient) DeleteDeliveryDestination(*cloudwatchlogs.DeleteDeliveryDestinationInput) (*cloudwatchlogs.DeleteDeliveryDestinationOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDeliveryDestination"}
+}
+
+func (unsupportedClient) DeleteDeliveryDestinationWithContext(aws.Context, *cloudwatchlogs.DeleteDeliveryDestinationInput, ...request.Option) (*cloudwatchlogs.DeleteDeliveryDestinationOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDeliveryDestination"}
+}
+
+func (unsupportedClient) DeleteDeliveryDestinationRequest(*cloudwatchlogs.DeleteDeliveryDestinationInput) (*request.Request, *cloudwatchlogs.DeleteDeliveryDestinationOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteDeliveryDestination"}}, nil
+}
+
+func (unsupportedClient) DeleteDeliveryDestinationPolicy(*cloudwatchlogs.DeleteDeliveryDestinationPolicyInput) (*cloudwatchlogs.DeleteDeliveryDestinationPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDeliveryDestinationPolicy"}
+}
+
+func (unsupportedClient) DeleteDeliveryDestinationPolicyWithContext(aws.Context, *cloudwatchlogs.DeleteDeliveryDestinationPolicyInput, ...request.Option) (*cloudwatchlogs.DeleteDeliveryDestinationPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDeliveryDestinationPolicy"}
+}
+
+func (unsupportedClient) DeleteDeliveryDestinationPolicyRequest(*cloudwatchlogs.DeleteDeliveryDestinationPolicyInput) (*request.Request, *cloudwatchlogs.DeleteDeliveryDestinationPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteDeliveryDestinationPolicy"}}, nil
+}
+
+func (unsupportedClient) DeleteDeliverySource(*cloudwatchlogs.DeleteDeliverySourceInput) (*cloudwatchlogs.DeleteDeliverySourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDeliverySource"}
+}
+
+func (unsupportedClient) DeleteDeliverySourceWithContext(aws.Context, *cloudwatchlogs.DeleteDeliverySourceInput, ...request.Option) (*cloudwatchlogs.DeleteDeliverySourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDeliverySource"}
+}
+
+func (unsupportedClient) DeleteDeliverySourceRequest(*cloudwatchlogs.DeleteDeliverySourceInput) (*request.Request, *cloudwatchlogs.DeleteDeliverySourceOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteDeliverySource"}}, nil
+}
+
+func (unsupportedClient) DeleteDestination(*cloudwatchlogs.DeleteDestinationInput) (*cloudwatchlogs.DeleteDestinationOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDestination"}
+}
+
+func (unsupportedClient) DeleteDestinationWithContext(aws.Context, *cloudwatchlogs.DeleteDestinationInput, ...request.Option) (*cloudwatchlogs.DeleteDestinationOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteDestination"}
+}
+
+func (unsupportedClient) DeleteDestinationRequest(*cloudwatchlogs.DeleteDestinationInput) (*request.Request, *cloudwatchlogs.DeleteDestinationOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteDestination"}}, nil
+}
+
+func (unsupportedClient) DeleteLogAnomalyDetector(*cloudwatchlogs.DeleteLogAnomalyDetectorInput) (*cloudwatchlogs.DeleteLogAnomalyDetectorOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteLogAnomalyDetector"}
+}
+
+func (unsupportedClient) DeleteLogAnomalyDetectorWithContext(aws.Context, *cloudwatchlogs.DeleteLogAnomalyDetectorInput, ...request.Option) (*cloudwatchlogs.DeleteLogAnomalyDetectorOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteLogAnomalyDetector"}
+}
+
+func (unsupportedClient) DeleteLogAnomalyDetectorRequest(*cloudwatchlogs.DeleteLogAnomalyDetectorInput) (*request.Request, *cloudwatchlogs.DeleteLogAnomalyDetectorOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteLogAnomalyDetector"}}, nil
+}
+
+func (unsupportedClient) DeleteLogGroup(*cloudwatchlogs.DeleteLogGroupInput) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteLogGroup"}
+}
+
+func (unsupportedClient) DeleteLogGroupWithContext(aws.Context, *cloudwatchlogs.DeleteLogGroupInput, ...request.Option) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteLogGroup"}
+}
+
+func (unsupportedClient) DeleteLogGroupRequest(*cloudwatchlogs.DeleteLogGroupInput) (*request.Request, *cloudwatchlogs.DeleteLogGroupOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteLogGroup"}}, nil
+}
+
+func (unsupportedClient) DeleteLogStream(*cloudwatchlogs.DeleteLogStreamInput) (*cloudwatchlogs.DeleteLogStreamOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteLogStream"}
+}
+
+func (unsupportedClient) DeleteLogStreamWithContext(aws.Context, *cloudwatchlogs.DeleteLogStreamInput, ...request.Option) (*cloudwatchlogs.DeleteLogStreamOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteLogStream"}
+}
+
+func (unsupportedClient) DeleteLogStreamRequest(*cloudwatchlogs.DeleteLogStreamInput) (*request.Request, *cloudwatchlogs.DeleteLogStreamOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteLogStream"}}, nil
+}
+
+func (unsupportedClient) DeleteMetricFilter(*cloudwatchlogs.DeleteMetricFilterInput) (*cloudwatchlogs.DeleteMetricFilterOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteMetricFilter"}
+}
+
+func (unsupportedClient) DeleteMetricFilterWithContext(aws.Context, *cloudwatchlogs.DeleteMetricFilterInput, ...request.Option) (*cloudwatchlogs.DeleteMetricFilterOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteMetricFilter"}
+}
+
+func (unsupportedClient) DeleteMetricFilterRequest(*cloudwatchlogs.DeleteMetricFilterInput) (*request.Request, *cloudwatchlogs.DeleteMetricFilterOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteMetricFilter"}}, nil
+}
+
+func (unsupportedClient) DeleteQueryDefinition(*cloudwatchlogs.DeleteQueryDefinitionInput) (*cloudwatchlogs.DeleteQueryDefinitionOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteQueryDefinition"}
+}
+
+func (unsupportedClient) DeleteQueryDefinitionWithContext(aws.Context, *cloudwatchlogs.DeleteQueryDefinitionInput, ...request.Option) (*cloudwatchlogs.DeleteQueryDefinitionOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteQueryDefinition"}
+}
+
+func (unsupportedClient) DeleteQueryDefinitionRequest(*cloudwatchlogs.DeleteQueryDefinitionInput) (*request.Request, *cloudwatchlogs.DeleteQueryDefinitionOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteQueryDefinition"}}, nil
+}
+
+func (unsupportedClient) DeleteResourcePolicy(*cloudwatchlogs.DeleteResourcePolicyInput) (*cloudwatchlogs.DeleteResourcePolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteResourcePolicy"}
+}
+
+func (unsupportedClient) DeleteResourcePolicyWithContext(aws.Context, *cloudwatchlogs.DeleteResourcePolicyInput, ...request.Option) (*cloudwatchlogs.DeleteResourcePolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteResourcePolicy"}
+}
+
+func (unsupportedClient) DeleteResourcePolicyRequest(*cloudwatchlogs.DeleteResourcePolicyInput) (*request.Request, *cloudwatchlogs.DeleteResourcePolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteResourcePolicy"}}, nil
+}
+
+func (unsupportedClient) DeleteRetentionPolicy(*cloudwatchlogs.DeleteRetentionPolicyInput) (*cloudwatchlogs.DeleteRetentionPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteRetentionPolicy"}
+}
+
+func (unsupportedClient) DeleteRetentionPolicyWithContext(aws.Context, *cloudwatchlogs.DeleteRetentionPolicyInput, ...request.Option) (*cloudwatchlogs.DeleteRetentionPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteRetentionPolicy"}
+}
+
+func (unsupportedClient) DeleteRetentionPolicyRequest(*cloudwatchlogs.DeleteRetentionPolicyInput) (*request.Request, *cloudwatchlogs.DeleteRetentionPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteRetentionPolicy"}}, nil
+}
+
+func (unsupportedClient) DeleteSubscriptionFilter(*cloudwatchlogs.DeleteSubscriptionFilterInput) (*cloudwatchlogs.DeleteSubscriptionFilterOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteSubscriptionFilter"}
+}
+
+func (unsupportedClient) DeleteSubscriptionFilterWithContext(aws.Context, *cloudwatchlogs.DeleteSubscriptionFilterInput, ...request.Option) (*cloudwatchlogs.DeleteSubscriptionFilterOutput, error) {
+	return nil, &UnsupportedError{Operation: "DeleteSubscriptionFilter"}
+}
+
+func (unsupportedClient) DeleteSubscriptionFilterRequest(*cloudwatchlogs.DeleteSubscriptionFilterInput) (*request.Request, *cloudwatchlogs.DeleteSubscriptionFilterOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DeleteSubscriptionFilter"}}, nil
+}
+
+func (unsupportedClient) DescribeAccountPolicies(*cloudwatchlogs.DescribeAccountPoliciesInput) (*cloudwatchlogs.DescribeAccountPoliciesOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeAccountPolicies"}
+}
+
+func (unsupportedClient) DescribeAccountPoliciesWithContext(aws.Context, *cloudwatchlogs.DescribeAccountPoliciesInput, ...request.Option) (*cloudwatchlogs.DescribeAccountPoliciesOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeAccountPolicies"}
+}
+
+func (unsupportedClient) DescribeAccountPoliciesRequest(*cloudwatchlogs.DescribeAccountPoliciesInput) (*request.Request, *cloudwatchlogs.DescribeAccountPoliciesOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeAccountPolicies"}}, nil
+}
+
+func (unsupportedClient) DescribeDeliveries(*cloudwatchlogs.DescribeDeliveriesInput) (*cloudwatchlogs.DescribeDeliveriesOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeDeliveries"}
+}
+
+func (unsupportedClient) DescribeDeliveriesWithContext(aws.Context, *cloudwatchlogs.DescribeDeliveriesInput, ...request.Option) (*cloudwatchlogs.DescribeDeliveriesOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeDeliveries"}
+}
+
+func (unsupportedClient) DescribeDeliveriesRequest(*cloudwatchlogs.DescribeDeliveriesInput) (*request.Request, *cloudwatchlogs.DescribeDeliveriesOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeDeliveries"}}, nil
+}
+
+func (unsupportedClient) DescribeDeliveriesPages(*cloudwatchlogs.DescribeDeliveriesInput, func(*cloudwatchlogs.DescribeDeliveriesOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "DescribeDeliveries"}
+}
+
+func (unsupportedClient) DescribeDeliveriesPagesWithContext(aws.Context, *cloudwatchlogs.DescribeDeliveriesInput, func(*cloudwatchlogs.DescribeDeliveriesOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "DescribeDeliveries"}
+}
+
+func (unsupportedClient) DescribeDeliveryDestinations(*cloudwatchlogs.DescribeDeliveryDestinationsInput) (*cloudwatchlogs.DescribeDeliveryDestinationsOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeDeliveryDestinations"}
+}
+
+func (unsupportedClient) DescribeDeliveryDestinationsWithContext(aws.Context, *cloudwatchlogs.DescribeDeliveryDestinationsInput, ...request.Option) (*cloudwatchlogs.DescribeDeliveryDestinationsOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeDeliveryDestinations"}
+}
+
+func (unsupportedClient) DescribeDeliveryDestinationsRequest(*cloudwatchlogs.DescribeDeliveryDestinationsInput) (*request.Request, *cloudwatchlogs.DescribeDeliveryDestinationsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeDeliveryDestinations"}}, nil
+}
+
+func (unsupportedClient) DescribeDeliveryDestinationsPages(*cloudwatchlogs.DescribeDeliveryDestinationsInput, func(*cloudwatchlogs.DescribeDeliveryDestinationsOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "DescribeDeliveryDestinations"}
+}
+
+func (unsupportedClient) DescribeDeliveryDestinationsPagesWithContext(aws.Context, *cloudwatchlogs.DescribeDeliveryDestinationsInput, func(*cloudwatchlogs.DescribeDeliveryDestinationsOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "DescribeDeliveryDestinations"}
+}
+
+func (unsupportedClient) DescribeDeliverySources(*cloudwatchlogs.DescribeDeliverySourcesInput) (*cloudwatchlogs.DescribeDeliverySourcesOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeDeliverySources"}
+}
+
+func (unsupportedClient) DescribeDeliverySourcesWithContext(aws.Context, *cloudwatchlogs.DescribeDeliverySourcesInput, ...request.Option) (*cloudwatchlogs.DescribeDeliverySourcesOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeDeliverySources"}
+}
+
+func (unsupportedClient) DescribeDeliverySourcesRequest(*cloudwatchlogs.DescribeDeliverySourcesInput) (*request.Request, *cloudwatchlogs.DescribeDeliverySourcesOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeDeliverySources"}}, nil
+}
+
+func (unsupportedClient) DescribeDeliverySourcesPages(*cloudwatchlogs.DescribeDeliverySourcesInput, func(*cloudwatchlogs.DescribeDeliverySourcesOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "DescribeDeliverySources"}
+}
+
+func (unsupportedClient) DescribeDeliverySourcesPagesWithContext(aws.Context, *cloudwatchlogs.DescribeDeliverySourcesInput, func(*cloudwatchlogs.DescribeDeliverySourcesOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "DescribeDeliverySources"}
+}
+
+func (unsupportedClient) DescribeDestinations(*cloudwatchlogs.DescribeDestinationsInput) (*cloudwatchlogs.DescribeDestinationsOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeDestinations"}
+}
+
+func (unsupportedClient) DescribeDestinationsWithContext(aws.Context, *cloudwatchlogs.DescribeDestinationsInput, ...request.Option) (*cloudwatchlogs.DescribeDestinationsOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeDestinations"}
+}
+
+func (unsupportedClient) DescribeDestinationsRequest(*cloudwatchlogs.DescribeDestinationsInput) (*request.Request, *cloudwatchlogs.DescribeDestinationsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeDestinations"}}, nil
+}
+
+func (unsupportedClient) DescribeDestinationsPages(*cloudwatchlogs.DescribeDestinationsInput, func(*cloudwatchlogs.DescribeDestinationsOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "DescribeDestinations"}
+}
+
+func (unsupportedClient) DescribeDestinationsPagesWithContext(aws.Context, *cloudwatchlogs.DescribeDestinationsInput, func(*cloudwatchlogs.DescribeDestinationsOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "DescribeDestinations"}
+}
+
+func (unsupportedClient) DescribeExportTasks(*cloudwatchlogs.DescribeExportTasksInput) (*cloudwatchlogs.DescribeExportTasksOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeExportTasks"}
+}
+
+func (unsupportedClient) DescribeExportTasksWithContext(aws.Context, *cloudwatchlogs.DescribeExportTasksInput, ...request.Option) (*cloudwatchlogs.DescribeExportTasksOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeExportTasks"}
+}
+
+func (unsupportedClient) DescribeExportTasksRequest(*cloudwatchlogs.DescribeExportTasksInput) (*request.Request, *cloudwatchlogs.DescribeExportTasksOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeExportTasks"}}, nil
+}
+
+func (unsupportedClient) DescribeLogGroups(*cloudwatchlogs.DescribeLogGroupsInput) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeLogGroups"}
+}
+
+func (unsupportedClient) DescribeLogGroupsWithContext(aws.Context, *cloudwatchlogs.DescribeLogGroupsInput, ...request.Option) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeLogGroups"}
+}
+
+func (unsupportedClient) DescribeLogGroupsRequest(*cloudwatchlogs.DescribeLogGroupsInput) (*request.Request, *cloudwatchlogs.DescribeLogGroupsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeLogGroups"}}, nil
+}
+
+func (unsupportedClient) DescribeLogGroupsPages(*cloudwatchlogs.DescribeLogGroupsInput, func(*cloudwatchlogs.DescribeLogGroupsOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "DescribeLogGroups"}
+}
+
+func (unsupportedClient) DescribeLogGroupsPagesWithContext(aws.Context, *cloudwatchlogs.DescribeLogGroupsInput, func(*cloudwatchlogs.DescribeLogGroupsOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "DescribeLogGroups"}
+}
+
+func (unsupportedClient) DescribeLogStreams(*cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeLogStreams"}
+}
+
+func (unsupportedClient) DescribeLogStreamsWithContext(aws.Context, *cloudwatchlogs.DescribeLogStreamsInput, ...request.Option) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeLogStreams"}
+}
+
+func (unsupportedClient) DescribeLogStreamsRequest(*cloudwatchlogs.DescribeLogStreamsInput) (*request.Request, *cloudwatchlogs.DescribeLogStreamsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeLogStreams"}}, nil
+}
+
+func (unsupportedClient) DescribeLogStreamsPages(*cloudwatchlogs.DescribeLogStreamsInput, func(*cloudwatchlogs.DescribeLogStreamsOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "DescribeLogStreams"}
+}
+
+func (unsupportedClient) DescribeLogStreamsPagesWithContext(aws.Context, *cloudwatchlogs.DescribeLogStreamsInput, func(*cloudwatchlogs.DescribeLogStreamsOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "DescribeLogStreams"}
+}
+
+func (unsupportedClient) DescribeMetricFilters(*cloudwatchlogs.DescribeMetricFiltersInput) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeMetricFilters"}
+}
+
+func (unsupportedClient) DescribeMetricFiltersWithContext(aws.Context, *cloudwatchlogs.DescribeMetricFiltersInput, ...request.Option) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeMetricFilters"}
+}
+
+func (unsupportedClient) DescribeMetricFiltersRequest(*cloudwatchlogs.DescribeMetricFiltersInput) (*request.Request, *cloudwatchlogs.DescribeMetricFiltersOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeMetricFilters"}}, nil
+}
+
+func (unsupportedClient) DescribeMetricFiltersPages(*cloudwatchlogs.DescribeMetricFiltersInput, func(*cloudwatchlogs.DescribeMetricFiltersOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "DescribeMetricFilters"}
+}
+
+func (unsupportedClient) DescribeMetricFiltersPagesWithContext(aws.Context, *cloudwatchlogs.DescribeMetricFiltersInput, func(*cloudwatchlogs.DescribeMetricFiltersOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "DescribeMetricFilters"}
+}
+
+func (unsupportedClient) DescribeQueries(*cloudwatchlogs.DescribeQueriesInput) (*cloudwatchlogs.DescribeQueriesOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeQueries"}
+}
+
+func (unsupportedClient) DescribeQueriesWithContext(aws.Context, *cloudwatchlogs.DescribeQueriesInput, ...request.Option) (*cloudwatchlogs.DescribeQueriesOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeQueries"}
+}
+
+func (unsupportedClient) DescribeQueriesRequest(*cloudwatchlogs.DescribeQueriesInput) (*request.Request, *cloudwatchlogs.DescribeQueriesOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeQueries"}}, nil
+}
+
+func (unsupportedClient) DescribeQueryDefinitions(*cloudwatchlogs.DescribeQueryDefinitionsInput) (*cloudwatchlogs.DescribeQueryDefinitionsOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeQueryDefinitions"}
+}
+
+func (unsupportedClient) DescribeQueryDefinitionsWithContext(aws.Context, *cloudwatchlogs.DescribeQueryDefinitionsInput, ...request.Option) (*cloudwatchlogs.DescribeQueryDefinitionsOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeQueryDefinitions"}
+}
+
+func (unsupportedClient) DescribeQueryDefinitionsRequest(*cloudwatchlogs.DescribeQueryDefinitionsInput) (*request.Request, *cloudwatchlogs.DescribeQueryDefinitionsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeQueryDefinitions"}}, nil
+}
+
+func (unsupportedClient) DescribeResourcePolicies(*cloudwatchlogs.DescribeResourcePoliciesInput) (*cloudwatchlogs.DescribeResourcePoliciesOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeResourcePolicies"}
+}
+
+func (unsupportedClient) DescribeResourcePoliciesWithContext(aws.Context, *cloudwatchlogs.DescribeResourcePoliciesInput, ...request.Option) (*cloudwatchlogs.DescribeResourcePoliciesOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeResourcePolicies"}
+}
+
+func (unsupportedClient) DescribeResourcePoliciesRequest(*cloudwatchlogs.DescribeResourcePoliciesInput) (*request.Request, *cloudwatchlogs.DescribeResourcePoliciesOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeResourcePolicies"}}, nil
+}
+
+func (unsupportedClient) DescribeSubscriptionFilters(*cloudwatchlogs.DescribeSubscriptionFiltersInput) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeSubscriptionFilters"}
+}
+
+func (unsupportedClient) DescribeSubscriptionFiltersWithContext(aws.Context, *cloudwatchlogs.DescribeSubscriptionFiltersInput, ...request.Option) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	return nil, &UnsupportedError{Operation: "DescribeSubscriptionFilters"}
+}
+
+func (unsupportedClient) DescribeSubscriptionFiltersRequest(*cloudwatchlogs.DescribeSubscriptionFiltersInput) (*request.Request, *cloudwatchlogs.DescribeSubscriptionFiltersOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DescribeSubscriptionFilters"}}, nil
+}
+
+func (unsupportedClient) DescribeSubscriptionFiltersPages(*cloudwatchlogs.DescribeSubscriptionFiltersInput, func(*cloudwatchlogs.DescribeSubscriptionFiltersOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "DescribeSubscriptionFilters"}
+}
+
+func (unsupportedClient) DescribeSubscriptionFiltersPagesWithContext(aws.Context, *cloudwatchlogs.DescribeSubscriptionFiltersInput, func(*cloudwatchlogs.DescribeSubscriptionFiltersOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "DescribeSubscriptionFilters"}
+}
+
+func (unsupportedClient) DisassociateKmsKey(*cloudwatchlogs.DisassociateKmsKeyInput) (*cloudwatchlogs.DisassociateKmsKeyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DisassociateKmsKey"}
+}
+
+func (unsupportedClient) DisassociateKmsKeyWithContext(aws.Context, *cloudwatchlogs.DisassociateKmsKeyInput, ...request.Option) (*cloudwatchlogs.DisassociateKmsKeyOutput, error) {
+	return nil, &UnsupportedError{Operation: "DisassociateKmsKey"}
+}
+
+func (unsupportedClient) DisassociateKmsKeyRequest(*cloudwatchlogs.DisassociateKmsKeyInput) (*request.Request, *cloudwatchlogs.DisassociateKmsKeyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "DisassociateKmsKey"}}, nil
+}
+
+func (unsupportedClient) FilterLogEvents(*cloudwatchlogs.FilterLogEventsInput) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	return nil, &UnsupportedError{Operation: "FilterLogEvents"}
+}
+
+func (unsupportedClient) FilterLogEventsWithContext(aws.Context, *cloudwatchlogs.FilterLogEventsInput, ...request.Option) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	return nil, &UnsupportedError{Operation: "FilterLogEvents"}
+}
+
+func (unsupportedClient) FilterLogEventsRequest(*cloudwatchlogs.FilterLogEventsInput) (*request.Request, *cloudwatchlogs.FilterLogEventsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "FilterLogEvents"}}, nil
+}
+
+func (unsupportedClient) FilterLogEventsPages(*cloudwatchlogs.FilterLogEventsInput, func(*cloudwatchlogs.FilterLogEventsOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "FilterLogEvents"}
+}
+
+func (unsupportedClient) FilterLogEventsPagesWithContext(aws.Context, *cloudwatchlogs.FilterLogEventsInput, func(*cloudwatchlogs.FilterLogEventsOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "FilterLogEvents"}
+}
+
+func (unsupportedClient) GetDataProtectionPolicy(*cloudwatchlogs.GetDataProtectionPolicyInput) (*cloudwatchlogs.GetDataProtectionPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetDataProtectionPolicy"}
+}
+
+func (unsupportedClient) GetDataProtectionPolicyWithContext(aws.Context, *cloudwatchlogs.GetDataProtectionPolicyInput, ...request.Option) (*cloudwatchlogs.GetDataProtectionPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetDataProtectionPolicy"}
+}
+
+func (unsupportedClient) GetDataProtectionPolicyRequest(*cloudwatchlogs.GetDataProtectionPolicyInput) (*request.Request, *cloudwatchlogs.GetDataProtectionPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "GetDataProtectionPolicy"}}, nil
+}
+
+func (unsupportedClient) GetDelivery(*cloudwatchlogs.GetDeliveryInput) (*cloudwatchlogs.GetDeliveryOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetDelivery"}
+}
+
+func (unsupportedClient) GetDeliveryWithContext(aws.Context, *cloudwatchlogs.GetDeliveryInput, ...request.Option) (*cloudwatchlogs.GetDeliveryOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetDelivery"}
+}
+
+func (unsupportedClient) GetDeliveryRequest(*cloudwatchlogs.GetDeliveryInput) (*request.Request, *cloudwatchlogs.GetDeliveryOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "GetDelivery"}}, nil
+}
+
+func (unsupportedClient) GetDeliveryDestination(*cloudwatchlogs.GetDeliveryDestinationInput) (*cloudwatchlogs.GetDeliveryDestinationOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetDeliveryDestination"}
+}
+
+func (unsupportedClient) GetDeliveryDestinationWithContext(aws.Context, *cloudwatchlogs.GetDeliveryDestinationInput, ...request.Option) (*cloudwatchlogs.GetDeliveryDestinationOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetDeliveryDestination"}
+}
+
+func (unsupportedClient) GetDeliveryDestinationRequest(*cloudwatchlogs.GetDeliveryDestinationInput) (*request.Request, *cloudwatchlogs.GetDeliveryDestinationOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "GetDeliveryDestination"}}, nil
+}
+
+func (unsupportedClient) GetDeliveryDestinationPolicy(*cloudwatchlogs.GetDeliveryDestinationPolicyInput) (*cloudwatchlogs.GetDeliveryDestinationPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetDeliveryDestinationPolicy"}
+}
+
+func (unsupportedClient) GetDeliveryDestinationPolicyWithContext(aws.Context, *cloudwatchlogs.GetDeliveryDestinationPolicyInput, ...request.Option) (*cloudwatchlogs.GetDeliveryDestinationPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetDeliveryDestinationPolicy"}
+}
+
+func (unsupportedClient) GetDeliveryDestinationPolicyRequest(*cloudwatchlogs.GetDeliveryDestinationPolicyInput) (*request.Request, *cloudwatchlogs.GetDeliveryDestinationPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "GetDeliveryDestinationPolicy"}}, nil
+}
+
+func (unsupportedClient) GetDeliverySource(*cloudwatchlogs.GetDeliverySourceInput) (*cloudwatchlogs.GetDeliverySourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetDeliverySource"}
+}
+
+func (unsupportedClient) GetDeliverySourceWithContext(aws.Context, *cloudwatchlogs.GetDeliverySourceInput, ...request.Option) (*cloudwatchlogs.GetDeliverySourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetDeliverySource"}
+}
+
+func (unsupportedClient) GetDeliverySourceRequest(*cloudwatchlogs.GetDeliverySourceInput) (*request.Request, *cloudwatchlogs.GetDeliverySourceOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "GetDeliverySource"}}, nil
+}
+
+func (unsupportedClient) GetLogAnomalyDetector(*cloudwatchlogs.GetLogAnomalyDetectorInput) (*cloudwatchlogs.GetLogAnomalyDetectorOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetLogAnomalyDetector"}
+}
+
+func (unsupportedClient) GetLogAnomalyDetectorWithContext(aws.Context, *cloudwatchlogs.GetLogAnomalyDetectorInput, ...request.Option) (*cloudwatchlogs.GetLogAnomalyDetectorOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetLogAnomalyDetector"}
+}
+
+func (unsupportedClient) GetLogAnomalyDetectorRequest(*cloudwatchlogs.GetLogAnomalyDetectorInput) (*request.Request, *cloudwatchlogs.GetLogAnomalyDetectorOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "GetLogAnomalyDetector"}}, nil
+}
+
+func (unsupportedClient) GetLogEvents(*cloudwatchlogs.GetLogEventsInput) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetLogEvents"}
+}
+
+func (unsupportedClient) GetLogEventsWithContext(aws.Context, *cloudwatchlogs.GetLogEventsInput, ...request.Option) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetLogEvents"}
+}
+
+func (unsupportedClient) GetLogEventsRequest(*cloudwatchlogs.GetLogEventsInput) (*request.Request, *cloudwatchlogs.GetLogEventsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "GetLogEvents"}}, nil
+}
+
+func (unsupportedClient) GetLogEventsPages(*cloudwatchlogs.GetLogEventsInput, func(*cloudwatchlogs.GetLogEventsOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "GetLogEvents"}
+}
+
+func (unsupportedClient) GetLogEventsPagesWithContext(aws.Context, *cloudwatchlogs.GetLogEventsInput, func(*cloudwatchlogs.GetLogEventsOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "GetLogEvents"}
+}
+
+func (unsupportedClient) GetLogGroupFields(*cloudwatchlogs.GetLogGroupFieldsInput) (*cloudwatchlogs.GetLogGroupFieldsOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetLogGroupFields"}
+}
+
+func (unsupportedClient) GetLogGroupFieldsWithContext(aws.Context, *cloudwatchlogs.GetLogGroupFieldsInput, ...request.Option) (*cloudwatchlogs.GetLogGroupFieldsOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetLogGroupFields"}
+}
+
+func (unsupportedClient) GetLogGroupFieldsRequest(*cloudwatchlogs.GetLogGroupFieldsInput) (*request.Request, *cloudwatchlogs.GetLogGroupFieldsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "GetLogGroupFields"}}, nil
+}
+
+func (unsupportedClient) GetLogRecord(*cloudwatchlogs.GetLogRecordInput) (*cloudwatchlogs.GetLogRecordOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetLogRecord"}
+}
+
+func (unsupportedClient) GetLogRecordWithContext(aws.Context, *cloudwatchlogs.GetLogRecordInput, ...request.Option) (*cloudwatchlogs.GetLogRecordOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetLogRecord"}
+}
+
+func (unsupportedClient) GetLogRecordRequest(*cloudwatchlogs.GetLogRecordInput) (*request.Request, *cloudwatchlogs.GetLogRecordOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "GetLogRecord"}}, nil
+}
+
+func (unsupportedClient) GetQueryResults(*cloudwatchlogs.GetQueryResultsInput) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetQueryResults"}
+}
+
+func (unsupportedClient) GetQueryResultsWithContext(aws.Context, *cloudwatchlogs.GetQueryResultsInput, ...request.Option) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+	return nil, &UnsupportedError{Operation: "GetQueryResults"}
+}
+
+func (unsupportedClient) GetQueryResultsRequest(*cloudwatchlogs.GetQueryResultsInput) (*request.Request, *cloudwatchlogs.GetQueryResultsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "GetQueryResults"}}, nil
+}
+
+func (unsupportedClient) ListAnomalies(*cloudwatchlogs.ListAnomaliesInput) (*cloudwatchlogs.ListAnomaliesOutput, error) {
+	return nil, &UnsupportedError{Operation: "ListAnomalies"}
+}
+
+func (unsupportedClient) ListAnomaliesWithContext(aws.Context, *cloudwatchlogs.ListAnomaliesInput, ...request.Option) (*cloudwatchlogs.ListAnomaliesOutput, error) {
+	return nil, &UnsupportedError{Operation: "ListAnomalies"}
+}
+
+func (unsupportedClient) ListAnomaliesRequest(*cloudwatchlogs.ListAnomaliesInput) (*request.Request, *cloudwatchlogs.ListAnomaliesOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "ListAnomalies"}}, nil
+}
+
+func (unsupportedClient) ListAnomaliesPages(*cloudwatchlogs.ListAnomaliesInput, func(*cloudwatchlogs.ListAnomaliesOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "ListAnomalies"}
+}
+
+func (unsupportedClient) ListAnomaliesPagesWithContext(aws.Context, *cloudwatchlogs.ListAnomaliesInput, func(*cloudwatchlogs.ListAnomaliesOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "ListAnomalies"}
+}
+
+func (unsupportedClient) ListLogAnomalyDetectors(*cloudwatchlogs.ListLogAnomalyDetectorsInput) (*cloudwatchlogs.ListLogAnomalyDetectorsOutput, error) {
+	return nil, &UnsupportedError{Operation: "ListLogAnomalyDetectors"}
+}
+
+func (unsupportedClient) ListLogAnomalyDetectorsWithContext(aws.Context, *cloudwatchlogs.ListLogAnomalyDetectorsInput, ...request.Option) (*cloudwatchlogs.ListLogAnomalyDetectorsOutput, error) {
+	return nil, &UnsupportedError{Operation: "ListLogAnomalyDetectors"}
+}
+
+func (unsupportedClient) ListLogAnomalyDetectorsRequest(*cloudwatchlogs.ListLogAnomalyDetectorsInput) (*request.Request, *cloudwatchlogs.ListLogAnomalyDetectorsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "ListLogAnomalyDetectors"}}, nil
+}
+
+func (unsupportedClient) ListLogAnomalyDetectorsPages(*cloudwatchlogs.ListLogAnomalyDetectorsInput, func(*cloudwatchlogs.ListLogAnomalyDetectorsOutput, bool) bool) error {
+	return &UnsupportedError{Operation: "ListLogAnomalyDetectors"}
+}
+
+func (unsupportedClient) ListLogAnomalyDetectorsPagesWithContext(aws.Context, *cloudwatchlogs.ListLogAnomalyDetectorsInput, func(*cloudwatchlogs.ListLogAnomalyDetectorsOutput, bool) bool, ...request.Option) error {
+	return &UnsupportedError{Operation: "ListLogAnomalyDetectors"}
+}
+
+func (unsupportedClient) ListTagsForResource(*cloudwatchlogs.ListTagsForResourceInput) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "ListTagsForResource"}
+}
+
+func (unsupportedClient) ListTagsForResourceWithContext(aws.Context, *cloudwatchlogs.ListTagsForResourceInput, ...request.Option) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "ListTagsForResource"}
+}
+
+func (unsupportedClient) ListTagsForResourceRequest(*cloudwatchlogs.ListTagsForResourceInput) (*request.Request, *cloudwatchlogs.ListTagsForResourceOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "ListTagsForResource"}}, nil
+}
+
+func (unsupportedClient) ListTagsLogGroup(*cloudwatchlogs.ListTagsLogGroupInput) (*cloudwatchlogs.ListTagsLogGroupOutput, error) {
+	return nil, &UnsupportedError{Operation: "ListTagsLogGroup"}
+}
+
+func (unsupportedClient) ListTagsLogGroupWithContext(aws.Context, *cloudwatchlogs.ListTagsLogGroupInput, ...request.Option) (*cloudwatchlogs.ListTagsLogGroupOutput, error) {
+	return nil, &UnsupportedError{Operation: "ListTagsLogGroup"}
+}
+
+func (unsupportedClient) ListTagsLogGroupRequest(*cloudwatchlogs.ListTagsLogGroupInput) (*request.Request, *cloudwatchlogs.ListTagsLogGroupOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "ListTagsLogGroup"}}, nil
+}
+
+func (unsupportedClient) PutAccountPolicy(*cloudwatchlogs.PutAccountPolicyInput) (*cloudwatchlogs.PutAccountPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutAccountPolicy"}
+}
+
+func (unsupportedClient) PutAccountPolicyWithContext(aws.Context, *cloudwatchlogs.PutAccountPolicyInput, ...request.Option) (*cloudwatchlogs.PutAccountPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutAccountPolicy"}
+}
+
+func (unsupportedClient) PutAccountPolicyRequest(*cloudwatchlogs.PutAccountPolicyInput) (*request.Request, *cloudwatchlogs.PutAccountPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutAccountPolicy"}}, nil
+}
+
+func (unsupportedClient) PutDataProtectionPolicy(*cloudwatchlogs.PutDataProtectionPolicyInput) (*cloudwatchlogs.PutDataProtectionPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDataProtectionPolicy"}
+}
+
+func (unsupportedClient) PutDataProtectionPolicyWithContext(aws.Context, *cloudwatchlogs.PutDataProtectionPolicyInput, ...request.Option) (*cloudwatchlogs.PutDataProtectionPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDataProtectionPolicy"}
+}
+
+func (unsupportedClient) PutDataProtectionPolicyRequest(*cloudwatchlogs.PutDataProtectionPolicyInput) (*request.Request, *cloudwatchlogs.PutDataProtectionPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutDataProtectionPolicy"}}, nil
+}
+
+func (unsupportedClient) PutDeliveryDestination(*cloudwatchlogs.PutDeliveryDestinationInput) (*cloudwatchlogs.PutDeliveryDestinationOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDeliveryDestination"}
+}
+
+func (unsupportedClient) PutDeliveryDestinationWithContext(aws.Context, *cloudwatchlogs.PutDeliveryDestinationInput, ...request.Option) (*cloudwatchlogs.PutDeliveryDestinationOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDeliveryDestination"}
+}
+
+func (unsupportedClient) PutDeliveryDestinationRequest(*cloudwatchlogs.PutDeliveryDestinationInput) (*request.Request, *cloudwatchlogs.PutDeliveryDestinationOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutDeliveryDestination"}}, nil
+}
+
+func (unsupportedClient) PutDeliveryDestinationPolicy(*cloudwatchlogs.PutDeliveryDestinationPolicyInput) (*cloudwatchlogs.PutDeliveryDestinationPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDeliveryDestinationPolicy"}
+}
+
+func (unsupportedClient) PutDeliveryDestinationPolicyWithContext(aws.Context, *cloudwatchlogs.PutDeliveryDestinationPolicyInput, ...request.Option) (*cloudwatchlogs.PutDeliveryDestinationPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDeliveryDestinationPolicy"}
+}
+
+func (unsupportedClient) PutDeliveryDestinationPolicyRequest(*cloudwatchlogs.PutDeliveryDestinationPolicyInput) (*request.Request, *cloudwatchlogs.PutDeliveryDestinationPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutDeliveryDestinationPolicy"}}, nil
+}
+
+func (unsupportedClient) PutDeliverySource(*cloudwatchlogs.PutDeliverySourceInput) (*cloudwatchlogs.PutDeliverySourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDeliverySource"}
+}
+
+func (unsupportedClient) PutDeliverySourceWithContext(aws.Context, *cloudwatchlogs.PutDeliverySourceInput, ...request.Option) (*cloudwatchlogs.PutDeliverySourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDeliverySource"}
+}
+
+func (unsupportedClient) PutDeliverySourceRequest(*cloudwatchlogs.PutDeliverySourceInput) (*request.Request, *cloudwatchlogs.PutDeliverySourceOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutDeliverySource"}}, nil
+}
+
+func (unsupportedClient) PutDestination(*cloudwatchlogs.PutDestinationInput) (*cloudwatchlogs.PutDestinationOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDestination"}
+}
+
+func (unsupportedClient) PutDestinationWithContext(aws.Context, *cloudwatchlogs.PutDestinationInput, ...request.Option) (*cloudwatchlogs.PutDestinationOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDestination"}
+}
+
+func (unsupportedClient) PutDestinationRequest(*cloudwatchlogs.PutDestinationInput) (*request.Request, *cloudwatchlogs.PutDestinationOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutDestination"}}, nil
+}
+
+func (unsupportedClient) PutDestinationPolicy(*cloudwatchlogs.PutDestinationPolicyInput) (*cloudwatchlogs.PutDestinationPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDestinationPolicy"}
+}
+
+func (unsupportedClient) PutDestinationPolicyWithContext(aws.Context, *cloudwatchlogs.PutDestinationPolicyInput, ...request.Option) (*cloudwatchlogs.PutDestinationPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutDestinationPolicy"}
+}
+
+func (unsupportedClient) PutDestinationPolicyRequest(*cloudwatchlogs.PutDestinationPolicyInput) (*request.Request, *cloudwatchlogs.PutDestinationPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutDestinationPolicy"}}, nil
+}
+
+func (unsupportedClient) PutLogEvents(*cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutLogEvents"}
+}
+
+func (unsupportedClient) PutLogEventsWithContext(aws.Context, *cloudwatchlogs.PutLogEventsInput, ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutLogEvents"}
+}
+
+func (unsupportedClient) PutLogEventsRequest(*cloudwatchlogs.PutLogEventsInput) (*request.Request, *cloudwatchlogs.PutLogEventsOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutLogEvents"}}, nil
+}
+
+func (unsupportedClient) PutMetricFilter(*cloudwatchlogs.PutMetricFilterInput) (*cloudwatchlogs.PutMetricFilterOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutMetricFilter"}
+}
+
+func (unsupportedClient) PutMetricFilterWithContext(aws.Context, *cloudwatchlogs.PutMetricFilterInput, ...request.Option) (*cloudwatchlogs.PutMetricFilterOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutMetricFilter"}
+}
+
+func (unsupportedClient) PutMetricFilterRequest(*cloudwatchlogs.PutMetricFilterInput) (*request.Request, *cloudwatchlogs.PutMetricFilterOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutMetricFilter"}}, nil
+}
+
+func (unsupportedClient) PutQueryDefinition(*cloudwatchlogs.PutQueryDefinitionInput) (*cloudwatchlogs.PutQueryDefinitionOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutQueryDefinition"}
+}
+
+func (unsupportedClient) PutQueryDefinitionWithContext(aws.Context, *cloudwatchlogs.PutQueryDefinitionInput, ...request.Option) (*cloudwatchlogs.PutQueryDefinitionOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutQueryDefinition"}
+}
+
+func (unsupportedClient) PutQueryDefinitionRequest(*cloudwatchlogs.PutQueryDefinitionInput) (*request.Request, *cloudwatchlogs.PutQueryDefinitionOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutQueryDefinition"}}, nil
+}
+
+func (unsupportedClient) PutResourcePolicy(*cloudwatchlogs.PutResourcePolicyInput) (*cloudwatchlogs.PutResourcePolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutResourcePolicy"}
+}
+
+func (unsupportedClient) PutResourcePolicyWithContext(aws.Context, *cloudwatchlogs.PutResourcePolicyInput, ...request.Option) (*cloudwatchlogs.PutResourcePolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutResourcePolicy"}
+}
+
+func (unsupportedClient) PutResourcePolicyRequest(*cloudwatchlogs.PutResourcePolicyInput) (*request.Request, *cloudwatchlogs.PutResourcePolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutResourcePolicy"}}, nil
+}
+
+func (unsupportedClient) PutRetentionPolicy(*cloudwatchlogs.PutRetentionPolicyInput) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutRetentionPolicy"}
+}
+
+func (unsupportedClient) PutRetentionPolicyWithContext(aws.Context, *cloudwatchlogs.PutRetentionPolicyInput, ...request.Option) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutRetentionPolicy"}
+}
+
+func (unsupportedClient) PutRetentionPolicyRequest(*cloudwatchlogs.PutRetentionPolicyInput) (*request.Request, *cloudwatchlogs.PutRetentionPolicyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutRetentionPolicy"}}, nil
+}
+
+func (unsupportedClient) PutSubscriptionFilter(*cloudwatchlogs.PutSubscriptionFilterInput) (*cloudwatchlogs.PutSubscriptionFilterOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutSubscriptionFilter"}
+}
+
+func (unsupportedClient) PutSubscriptionFilterWithContext(aws.Context, *cloudwatchlogs.PutSubscriptionFilterInput, ...request.Option) (*cloudwatchlogs.PutSubscriptionFilterOutput, error) {
+	return nil, &UnsupportedError{Operation: "PutSubscriptionFilter"}
+}
+
+func (unsupportedClient) PutSubscriptionFilterRequest(*cloudwatchlogs.PutSubscriptionFilterInput) (*request.Request, *cloudwatchlogs.PutSubscriptionFilterOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "PutSubscriptionFilter"}}, nil
+}
+
+func (unsupportedClient) StartLiveTail(*cloudwatchlogs.StartLiveTailInput) (*cloudwatchlogs.StartLiveTailOutput, error) {
+	return nil, &UnsupportedError{Operation: "StartLiveTail"}
+}
+
+func (unsupportedClient) StartLiveTailWithContext(aws.Context, *cloudwatchlogs.StartLiveTailInput, ...request.Option) (*cloudwatchlogs.StartLiveTailOutput, error) {
+	return nil, &UnsupportedError{Operation: "StartLiveTail"}
+}
+
+func (unsupportedClient) StartLiveTailRequest(*cloudwatchlogs.StartLiveTailInput) (*request.Request, *cloudwatchlogs.StartLiveTailOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "StartLiveTail"}}, nil
+}
+
+func (unsupportedClient) StartQuery(*cloudwatchlogs.StartQueryInput) (*cloudwatchlogs.StartQueryOutput, error) {
+	return nil, &UnsupportedError{Operation: "StartQuery"}
+}
+
+func (unsupportedClient) StartQueryWithContext(aws.Context, *cloudwatchlogs.StartQueryInput, ...request.Option) (*cloudwatchlogs.StartQueryOutput, error) {
+	return nil, &UnsupportedError{Operation: "StartQuery"}
+}
+
+func (unsupportedClient) StartQueryRequest(*cloudwatchlogs.StartQueryInput) (*request.Request, *cloudwatchlogs.StartQueryOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "StartQuery"}}, nil
+}
+
+func (unsupportedClient) StopQuery(*cloudwatchlogs.StopQueryInput) (*cloudwatchlogs.StopQueryOutput, error) {
+	return nil, &UnsupportedError{Operation: "StopQuery"}
+}
+
+func (unsupportedClient) StopQueryWithContext(aws.Context, *cloudwatchlogs.StopQueryInput, ...request.Option) (*cloudwatchlogs.StopQueryOutput, error) {
+	return nil, &UnsupportedError{Operation: "StopQuery"}
+}
+
+func (unsupportedClient) StopQueryRequest(*cloudwatchlogs.StopQueryInput) (*request.Request, *cloudwatchlogs.StopQueryOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "StopQuery"}}, nil
+}
+
+func (unsupportedClient) TagLogGroup(*cloudwatchlogs.TagLogGroupInput) (*cloudwatchlogs.TagLogGroupOutput, error) {
+	return nil, &UnsupportedError{Operation: "TagLogGroup"}
+}
+
+func (unsupportedClient) TagLogGroupWithContext(aws.Context, *cloudwatchlogs.TagLogGroupInput, ...request.Option) (*cloudwatchlogs.TagLogGroupOutput, error) {
+	return nil, &UnsupportedError{Operation: "TagLogGroup"}
+}
+
+func (unsupportedClient) TagLogGroupRequest(*cloudwatchlogs.TagLogGroupInput) (*request.Request, *cloudwatchlogs.TagLogGroupOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "TagLogGroup"}}, nil
+}
+
+func (unsupportedClient) TagResource(*cloudwatchlogs.TagResourceInput) (*cloudwatchlogs.TagResourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "TagResource"}
+}
+
+func (unsupportedClient) TagResourceWithContext(aws.Context, *cloudwatchlogs.TagResourceInput, ...request.Option) (*cloudwatchlogs.TagResourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "TagResource"}
+}
+
+func (unsupportedClient) TagResourceRequest(*cloudwatchlogs.TagResourceInput) (*request.Request, *cloudwatchlogs.TagResourceOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "TagResource"}}, nil
+}
+
+func (unsupportedClient) TestMetricFilter(*cloudwatchlogs.TestMetricFilterInput) (*cloudwatchlogs.TestMetricFilterOutput, error) {
+	return nil, &UnsupportedError{Operation: "TestMetricFilter"}
+}
+
+func (unsupportedClient) TestMetricFilterWithContext(aws.Context, *cloudwatchlogs.TestMetricFilterInput, ...request.Option) (*cloudwatchlogs.TestMetricFilterOutput, error) {
+	return nil, &UnsupportedError{Operation: "TestMetricFilter"}
+}
+
+func (unsupportedClient) TestMetricFilterRequest(*cloudwatchlogs.TestMetricFilterInput) (*request.Request, *cloudwatchlogs.TestMetricFilterOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "TestMetricFilter"}}, nil
+}
+
+func (unsupportedClient) UntagLogGroup(*cloudwatchlogs.UntagLogGroupInput) (*cloudwatchlogs.UntagLogGroupOutput, error) {
+	return nil, &UnsupportedError{Operation: "UntagLogGroup"}
+}
+
+func (unsupportedClient) UntagLogGroupWithContext(aws.Context, *cloudwatchlogs.UntagLogGroupInput, ...request.Option) (*cloudwatchlogs.UntagLogGroupOutput, error) {
+	return nil, &UnsupportedError{Operation: "UntagLogGroup"}
+}
+
+func (unsupportedClient) UntagLogGroupRequest(*cloudwatchlogs.UntagLogGroupInput) (*request.Request, *cloudwatchlogs.UntagLogGroupOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "UntagLogGroup"}}, nil
+}
+
+func (unsupportedClient) UntagResource(*cloudwatchlogs.UntagResourceInput) (*cloudwatchlogs.UntagResourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "UntagResource"}
+}
+
+func (unsupportedClient) UntagResourceWithContext(aws.Context, *cloudwatchlogs.UntagResourceInput, ...request.Option) (*cloudwatchlogs.UntagResourceOutput, error) {
+	return nil, &UnsupportedError{Operation: "UntagResource"}
+}
+
+func (unsupportedClient) UntagResourceRequest(*cloudwatchlogs.UntagResourceInput) (*request.Request, *cloudwatchlogs.UntagResourceOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "UntagResource"}}, nil
+}
+
+func (unsupportedClient) UpdateAnomaly(*cloudwatchlogs.UpdateAnomalyInput) (*cloudwatchlogs.UpdateAnomalyOutput, error) {
+	return nil, &UnsupportedError{Operation: "UpdateAnomaly"}
+}
+
+func (unsupportedClient) UpdateAnomalyWithContext(aws.Context, *cloudwatchlogs.UpdateAnomalyInput, ...request.Option) (*cloudwatchlogs.UpdateAnomalyOutput, error) {
+	return nil, &UnsupportedError{Operation: "UpdateAnomaly"}
+}
+
+func (unsupportedClient) UpdateAnomalyRequest(*cloudwatchlogs.UpdateAnomalyInput) (*request.Request, *cloudwatchlogs.UpdateAnomalyOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "UpdateAnomaly"}}, nil
+}
+
+func (unsupportedClient) UpdateLogAnomalyDetector(*cloudwatchlogs.UpdateLogAnomalyDetectorInput) (*cloudwatchlogs.UpdateLogAnomalyDetectorOutput, error) {
+	return nil, &UnsupportedError{Operation: "UpdateLogAnomalyDetector"}
+}
+
+func (unsupportedClient) UpdateLogAnomalyDetectorWithContext(aws.Context, *cloudwatchlogs.UpdateLogAnomalyDetectorInput, ...request.Option) (*cloudwatchlogs.UpdateLogAnomalyDetectorOutput, error) {
+	return nil, &UnsupportedError{Operation: "UpdateLogAnomalyDetector"}
+}
+
+func (unsupportedClient) UpdateLogAnomalyDetectorRequest(*cloudwatchlogs.UpdateLogAnomalyDetectorInput) (*request.Request, *cloudwatchlogs.UpdateLogAnomalyDetectorOutput) {
+	return &request.Request{Error: &UnsupportedError{Operation: "UpdateLogAnomalyDetector"}}, nil
+}
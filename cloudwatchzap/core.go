@@ -0,0 +1,54 @@
+// Package cloudwatchzap provides a zapcore.Core sending log entries to AWS
+// CloudWatch Logs.
+package cloudwatchzap
+
+import (
+	"context"
+
+	"go.uber.org/zap/zapcore"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+// Core is a zapcore.Core writing entries to a CloudWatch Logs stream, one event
+// per entry. It must be closed to send the buffered entries and stop the
+// underlying writer.
+type Core struct {
+	zapcore.Core
+
+	writer cloudwatch.WriteFlushCloser
+}
+
+// NewZapCore creates the streamName log stream in g with opts, and returns a
+// Core writing entries encoded by enc to it, at the levels enabled by enab.
+// Syncing the core, for example using zap.Logger.Sync, flushes the buffered
+// entries.
+func NewZapCore(g cloudwatch.Group, ctx context.Context, streamName string, enc zapcore.Encoder, enab zapcore.LevelEnabler, opts ...cloudwatch.CreateOption) (*Core, error) {
+	writer, err := g.Create(ctx, streamName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Core{
+		Core:   zapcore.NewCore(enc, NewWriteSyncer(writer), enab),
+		writer: writer,
+	}, nil
+}
+
+// Close flushes the buffered entries and closes the underlying writer.
+func (c *Core) Close() error {
+	return c.writer.Close()
+}
+
+// NewWriteSyncer adapts w to a zapcore.WriteSyncer, whose Sync flushes w.
+func NewWriteSyncer(w cloudwatch.WriteFlushCloser) zapcore.WriteSyncer {
+	return writeSyncer{w}
+}
+
+type writeSyncer struct {
+	cloudwatch.WriteFlushCloser
+}
+
+func (w writeSyncer) Sync() error {
+	return w.Flush()
+}
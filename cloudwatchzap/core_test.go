@@ -0,0 +1,141 @@
+package cloudwatchzap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+)
+
+type mockAPI struct {
+	mock.Mock
+	iface.CloudWatchLogsAPI
+}
+
+func (m *mockAPI) CreateLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogStreamInput, opts ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.CreateLogStreamOutput), args.Error(1)
+}
+
+func (m *mockAPI) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.PutLogEventsInput, opts ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.PutLogEventsOutput), args.Error(1)
+}
+
+type coreTestSuite struct {
+	suite.Suite
+
+	api *mockAPI
+	ctx context.Context
+}
+
+func (c *coreTestSuite) SetupTest() {
+	c.api = new(mockAPI)
+	c.ctx = context.Background()
+}
+
+func (c *coreTestSuite) creatingLogStreamReturns(err error) {
+	c.api.On(
+		"CreateLogStreamWithContext",
+		c.ctx,
+		&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String("groupName"),
+			LogStreamName: aws.String("streamName"),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.CreateLogStreamOutput{}, err)
+}
+
+func (c *coreTestSuite) newCore() (*Core, error) {
+	return NewZapCore(
+		cloudwatch.NewGroup(c.api, "groupName"),
+		c.ctx,
+		"streamName",
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.InfoLevel,
+	)
+}
+
+func (c *coreTestSuite) TestLogger() {
+	c.creatingLogStreamReturns(nil)
+
+	var (
+		mu       sync.Mutex
+		messages []string
+	)
+	c.api.On(
+		"PutLogEventsWithContext",
+		mock.Anything,
+		mock.AnythingOfType("*cloudwatchlogs.PutLogEventsInput"),
+		[]request.Option(nil),
+	).Run(func(args mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, event := range args.Get(1).(*cloudwatchlogs.PutLogEventsInput).LogEvents {
+			messages = append(messages, *event.Message)
+		}
+	}).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	core, err := c.newCore()
+	c.Require().NoError(err)
+	defer core.Close()
+
+	logger := zap.New(core).With(zap.String("service", "orders"))
+	logger.Debug("Ignored")
+	logger.Info("Hello", zap.Int("order", 42))
+	c.Require().NoError(logger.Sync())
+
+	mu.Lock()
+	defer mu.Unlock()
+	c.Require().Len(messages, 1)
+
+	var entry map[string]interface{}
+	c.Require().NoError(json.Unmarshal([]byte(messages[0]), &entry))
+	c.Equal("Hello", entry["msg"])
+	c.Equal("info", entry["level"])
+	c.Equal("orders", entry["service"])
+	c.Equal(float64(42), entry["order"])
+}
+
+func (c *coreTestSuite) TestSyncError() {
+	c.creatingLogStreamReturns(nil)
+	c.api.On(
+		"PutLogEventsWithContext",
+		mock.Anything,
+		mock.AnythingOfType("*cloudwatchlogs.PutLogEventsInput"),
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.PutLogEventsOutput{}, errors.New("bacon"))
+
+	core, err := c.newCore()
+	c.Require().NoError(err)
+
+	logger := zap.New(core)
+	logger.Info("Hello")
+
+	c.EqualError(logger.Sync(), "bacon")
+}
+
+func (c *coreTestSuite) TestCreateError() {
+	c.creatingLogStreamReturns(errors.New("bacon"))
+
+	core, err := c.newCore()
+
+	c.Nil(core)
+	c.EqualError(err, "could not create the log stream: bacon")
+}
+
+func TestCore(t *testing.T) {
+	suite.Run(t, new(coreTestSuite))
+}
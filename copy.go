@@ -0,0 +1,192 @@
+package cloudwatch
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// CopyOption allows setting various options on Group.Copy.
+type CopyOption func(*copyOptions)
+
+type copyOptions struct {
+	start, end *time.Time
+	filter     *string
+	parallel   int
+}
+
+// WithCopyTimeRange only copies the events at or after start, and before end.
+// A zero time leaves that side of the range open.
+func WithCopyTimeRange(start, end time.Time) CopyOption {
+	return func(o *copyOptions) {
+		if !start.IsZero() {
+			o.start = &start
+		}
+		if !end.IsZero() {
+			o.end = &end
+		}
+	}
+}
+
+// WithCopyFilter only copies the events matching pattern, like
+// WithFilterPattern.
+func WithCopyFilter(pattern string) CopyOption {
+	return func(o *copyOptions) {
+		o.filter = aws.String(pattern)
+	}
+}
+
+// WithCopyParallel splits the time range to copy into n parts read
+// concurrently. Events are still written in order, so parts read ahead are
+// held in memory until their turn comes. Requests of all readers of the group
+// share its read rate limit.
+func WithCopyParallel(n int) CopyOption {
+	return func(o *copyOptions) {
+		if n > 0 {
+			o.parallel = n
+		}
+	}
+}
+
+func (g *groupImpl) Copy(ctx context.Context, srcStream, dstStream string, opts ...CopyOption) (int64, error) {
+	o := copyOptions{parallel: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ranges, err := g.copyRanges(ctx, srcStream, o)
+	if err != nil {
+		return 0, err
+	}
+
+	// Events may not be in timestamp order in the source stream, while they
+	// must be within each batch.
+	writer, err := g.create(ctx, dstStream, WithSortEvents())
+	if err != nil {
+		return 0, err
+	}
+	writer.throttle = time.NewTicker(writer.flushInterval)
+	go writer.start()
+
+	type part struct {
+		events []*cloudwatchlogs.OutputLogEvent
+		err    error
+		done   chan struct{}
+	}
+
+	// Reading stops early if writing fails.
+	readCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	parts := make([]*part, len(ranges))
+	for i, readOpts := range ranges {
+		p := &part{done: make(chan struct{})}
+		parts[i] = p
+
+		r := g.newReader(readCtx, srcStream, readOpts...)
+		r.onEvent = func(event *cloudwatchlogs.OutputLogEvent) error {
+			p.events = append(p.events, event)
+			return nil
+		}
+
+		go func() {
+			defer close(p.done)
+			defer r.Close()
+			p.err = r.readAll()
+		}()
+	}
+
+	var copied int64
+	for _, p := range parts {
+		<-p.done
+		if err = p.err; err != nil {
+			break
+		}
+
+		for _, event := range p.events {
+			t := time.Unix(0, aws.Int64Value(event.Timestamp)*int64(time.Millisecond))
+			if err = writer.enqueue([]byte(aws.StringValue(event.Message)), t); err != nil {
+				break
+			}
+			copied++
+		}
+		p.events = nil
+		if err != nil {
+			break
+		}
+	}
+
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	return copied, err
+}
+
+// copyRanges returns the options of the readers of each part of the copy.
+func (g *groupImpl) copyRanges(ctx context.Context, srcStream string, o copyOptions) ([][]ReadOption, error) {
+	var common []ReadOption
+	if o.filter != nil {
+		common = append(common, WithFilterPattern(*o.filter))
+	}
+
+	if o.parallel == 1 {
+		ret := common
+		if o.start != nil {
+			ret = append(ret, WithStartTime(*o.start))
+		}
+		if o.end != nil {
+			ret = append(ret, WithEndTime(*o.end))
+		}
+		return [][]ReadOption{ret}, nil
+	}
+
+	// The range needs bounds to be split up, so open sides are bounded by the
+	// timestamps events may have.
+	start, end := o.start, o.end
+	if start == nil || end == nil {
+		first, err := g.firstEventTime(ctx, srcStream)
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			start = &first
+		}
+		if end == nil {
+			future := time.Now().Add(maxEventFuture)
+			end = &future
+		}
+	}
+
+	step := end.Sub(*start) / time.Duration(o.parallel)
+	if step <= 0 {
+		step = end.Sub(*start)
+	}
+
+	var ret [][]ReadOption
+	for from := *start; from.Before(*end); from = from.Add(step) {
+		to := from.Add(step)
+		if to.After(*end) || end.Sub(to) < step {
+			to = *end
+		}
+		ret = append(ret, append(append([]ReadOption(nil), common...), WithStartTime(from), WithEndTime(to)))
+		if to.Equal(*end) {
+			break
+		}
+	}
+	return ret, nil
+}
+
+// firstEventTime returns the timestamp of the first event of the stream, or
+// the current time if it's empty.
+func (g *groupImpl) firstEventTime(ctx context.Context, streamName string) (time.Time, error) {
+	stream, err := g.DescribeStream(ctx, streamName)
+	if err == ErrNotFound || (err == nil && stream.FirstEventTimestamp == nil) {
+		return time.Now(), nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, *stream.FirstEventTimestamp*int64(time.Millisecond)), nil
+}
@@ -0,0 +1,49 @@
+package cloudwatch
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+)
+
+func (g *groupImpl) SetDataProtectionPolicy(ctx context.Context, policyDocument string) error {
+	if !json.Valid([]byte(policyDocument)) {
+		return errors.New("the data protection policy document isn't valid JSON")
+	}
+
+	_, err := g.PutDataProtectionPolicyWithContext(ctx, &cloudwatchlogs.PutDataProtectionPolicyInput{
+		LogGroupIdentifier: aws.String(g.groupName),
+		PolicyDocument:     aws.String(policyDocument),
+	})
+
+	return errors.Wrap(err, "could not put the data protection policy")
+}
+
+func (g *groupImpl) DataProtectionPolicy(ctx context.Context) (string, error) {
+	resp, err := g.GetDataProtectionPolicyWithContext(ctx, &cloudwatchlogs.GetDataProtectionPolicyInput{
+		LogGroupIdentifier: aws.String(g.groupName),
+	})
+	if _, ok := err.(*cloudwatchlogs.ResourceNotFoundException); ok {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "could not get the data protection policy")
+	}
+
+	return aws.StringValue(resp.PolicyDocument), nil
+}
+
+func (g *groupImpl) RemoveDataProtectionPolicy(ctx context.Context) error {
+	_, err := g.DeleteDataProtectionPolicyWithContext(ctx, &cloudwatchlogs.DeleteDataProtectionPolicyInput{
+		LogGroupIdentifier: aws.String(g.groupName),
+	})
+
+	if _, ok := err.(*cloudwatchlogs.ResourceNotFoundException); ok {
+		return ErrNotFound
+	}
+
+	return errors.Wrap(err, "could not delete the data protection policy")
+}
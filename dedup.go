@@ -0,0 +1,77 @@
+package cloudwatch
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WithDeduplication drops messages identical to one buffered less than window
+// earlier, such as health check logs written every second. Only exact
+// duplicates are dropped. The next message which isn't a duplicate is suffixed
+// with "[suppressed N times]", before any trailing newline, where N is the
+// number of messages dropped since the previous one.
+func WithDeduplication(window time.Duration) CreateOption {
+	return func(w *writerImpl) {
+		w.dedup = &deduplicator{window: window, seen: make(map[string]time.Time)}
+	}
+}
+
+type deduplicator struct {
+	window time.Duration
+
+	mu         sync.Mutex // This protects the fields below.
+	seen       map[string]time.Time
+	suppressed int
+	prunedAt   time.Time
+}
+
+// filter returns message, annotated with the number of messages suppressed
+// since the previous call which returned one, or nil if message is a duplicate
+// of one seen within the window before t.
+func (d *deduplicator) filter(message []byte, t time.Time) []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.prune(t)
+
+	key := string(message)
+	if last, ok := d.seen[key]; ok && t.Sub(last) < d.window {
+		d.suppressed++
+		return nil
+	}
+	d.seen[key] = t
+
+	if d.suppressed == 0 {
+		return message
+	}
+
+	trimmed := bytes.TrimSuffix(message, []byte("\n"))
+	ret := make([]byte, 0, len(message)+32)
+	ret = append(ret, trimmed...)
+	if len(trimmed) > 0 {
+		ret = append(ret, ' ')
+	}
+	ret = append(ret, "[suppressed "+strconv.Itoa(d.suppressed)+" times]"...)
+	ret = append(ret, message[len(trimmed):]...)
+
+	d.suppressed = 0
+	return ret
+}
+
+// prune forgets messages last seen more than a window before t, at most once
+// per window so that memory stays bounded by the number of distinct messages
+// in two windows. The caller must hold the lock.
+func (d *deduplicator) prune(t time.Time) {
+	if t.Sub(d.prunedAt) < d.window {
+		return
+	}
+
+	for message, last := range d.seen {
+		if t.Sub(last) >= d.window {
+			delete(d.seen, message)
+		}
+	}
+	d.prunedAt = t
+}
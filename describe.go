@@ -0,0 +1,79 @@
+package cloudwatch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+)
+
+// defaultDescribeCacheTTL is how long the result of Group.Describe is cached
+// by default.
+const defaultDescribeCacheTTL = 5 * time.Minute
+
+// describeCache holds the last description of the group.
+type describeCache struct {
+	ttl time.Duration
+
+	mu        sync.Mutex // This protects the fields below.
+	group     *cloudwatchlogs.LogGroup
+	expiresAt time.Time
+}
+
+// WithDescribeCacheTTL sets how long the result of Describe is cached.
+// Defaults to 5 minutes, and 0 disables the cache.
+func WithDescribeCacheTTL(d time.Duration) GroupOption {
+	return func(g *groupImpl) {
+		if d >= 0 {
+			g.describeCache.ttl = d
+		}
+	}
+}
+
+func (g *groupImpl) Describe(ctx context.Context) (*cloudwatchlogs.LogGroup, error) {
+	c := &g.describeCache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.group == nil || !time.Now().Before(c.expiresAt) {
+		group, err := g.describeGroup(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.group, c.expiresAt = group, time.Now().Add(c.ttl)
+	}
+
+	// Callers may modify the result without affecting the cache.
+	ret := *c.group
+	return &ret, nil
+}
+
+// describeGroup looks the group up using DescribeLogGroups. Its name is used
+// as a prefix, which may match other groups too. It returns ErrNotFound if the
+// group doesn't exist.
+func (g *groupImpl) describeGroup(ctx context.Context) (*cloudwatchlogs.LogGroup, error) {
+	input := &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(g.groupName),
+	}
+
+	for {
+		resp, err := g.DescribeLogGroupsWithContext(ctx, input)
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't get log group description")
+		}
+
+		for _, group := range resp.LogGroups {
+			if aws.StringValue(group.LogGroupName) == g.groupName {
+				return group, nil
+			}
+		}
+
+		if resp.NextToken == nil {
+			return nil, ErrNotFound
+		}
+		input.NextToken = resp.NextToken
+	}
+}
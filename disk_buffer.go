@@ -0,0 +1,312 @@
+package cloudwatch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+)
+
+// DiskEventBuffer is an EventBuffer keeping events in a write-ahead log on
+// disk, one JSON event per line, so that events which weren't sent before the
+// process stopped are sent once a buffer is created for the same file again.
+//
+// Events are only removed from the log once they've been sent, so events sent
+// just before the process stopped may be sent again. The log is split into
+// segments of at most maxBytes, named after the path with a numbered suffix
+// for all but the first. It's safe for concurrent use.
+type DiskEventBuffer struct {
+	path     string
+	maxBytes int64
+
+	mu       sync.Mutex // This protects the fields below.
+	segments []int      // Sequence numbers of the segments, oldest first.
+	active   *os.File   // The last segment, which events are appended to.
+	size     int64      // The size of the last segment.
+	count    int        // The number of events not drained yet.
+
+	// Position up to which events were drained, but not necessarily sent.
+	readSegment int
+	readOffset  int64
+}
+
+// NewDiskEventBuffer opens the write-ahead log at path, creating it if it
+// doesn't exist, and returns a buffer holding the events it contains. Segments
+// are rotated once they reach maxBytes, and are never rotated if maxBytes is
+// 0.
+func NewDiskEventBuffer(path string, maxBytes int64) (*DiskEventBuffer, error) {
+	ret := &DiskEventBuffer{path: path, maxBytes: maxBytes}
+
+	segments, err := ret.findSegments()
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		segments = []int{0}
+	}
+	ret.segments = segments
+
+	for i, seq := range segments {
+		last := i == len(segments)-1
+		if err := ret.replay(seq, last); err != nil {
+			return nil, err
+		}
+	}
+
+	ret.active, err = os.OpenFile(ret.segmentPath(segments[len(segments)-1]), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open the event log")
+	}
+
+	return ret, nil
+}
+
+// findSegments returns the sequence numbers of the existing segments, in
+// ascending order.
+func (b *DiskEventBuffer) findSegments() ([]int, error) {
+	var ret []int
+	if _, err := os.Stat(b.path); err == nil {
+		ret = append(ret, 0)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "could not open the event log")
+	}
+
+	matches, err := filepath.Glob(b.path + ".*")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list the event log segments")
+	}
+	for _, match := range matches {
+		seq, err := strconv.Atoi(strings.TrimPrefix(match, b.path+"."))
+		if err == nil && seq > 0 {
+			ret = append(ret, seq)
+		}
+	}
+
+	sort.Ints(ret)
+	return ret, nil
+}
+
+func (b *DiskEventBuffer) segmentPath(seq int) string {
+	if seq == 0 {
+		return b.path
+	}
+	return b.path + "." + strconv.Itoa(seq)
+}
+
+// replay counts the events of a segment. The last segment may end with an
+// event only partially written before the process stopped, which is discarded.
+func (b *DiskEventBuffer) replay(seq int, last bool) error {
+	data, err := ioutil.ReadFile(b.segmentPath(seq))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "could not read the event log")
+	}
+
+	complete := bytes.LastIndexByte(data, '\n') + 1
+	if last && complete < len(data) {
+		if err := os.Truncate(b.segmentPath(seq), int64(complete)); err != nil {
+			return errors.Wrap(err, "could not discard a partially written event")
+		}
+	}
+
+	b.count += bytes.Count(data[:complete], []byte{'\n'})
+	if last {
+		b.size = int64(complete)
+	}
+	return nil
+}
+
+// Add appends the event to the log, rotating the last segment if it would
+// exceed the maximum size. It never blocks waiting for space.
+func (b *DiskEventBuffer) Add(_ context.Context, event *cloudwatchlogs.InputLogEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "could not encode the event")
+	}
+	line = append(line, '\n')
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.maxBytes > 0 && b.size > 0 && b.size+int64(len(line)) > b.maxBytes {
+		if err := b.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := b.active.Write(line)
+	b.size += int64(n)
+	if err != nil {
+		return errors.Wrap(err, "could not write to the event log")
+	}
+
+	b.count++
+	return nil
+}
+
+// rotate starts a new segment. The caller must hold the lock.
+func (b *DiskEventBuffer) rotate() error {
+	seq := b.segments[len(b.segments)-1] + 1
+	f, err := os.OpenFile(b.segmentPath(seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Wrap(err, "could not rotate the event log")
+	}
+
+	b.active.Close()
+	b.active = f
+	b.size = 0
+	b.segments = append(b.segments, seq)
+	return nil
+}
+
+// Drain returns up to one batch of the largest size CloudWatch accepts of the
+// events not drained yet, which a writer splits further if it's configured with
+// smaller batches. They stay in the log until Commit is called.
+func (b *DiskEventBuffer) Drain() []*cloudwatchlogs.InputLogEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch := new(logBatch)
+	for {
+		full, err := b.readSegmentInto(batch)
+		if err != nil || full || b.readSegment == len(b.segments)-1 {
+			break
+		}
+		b.readSegment++
+		b.readOffset = 0
+	}
+
+	b.count -= len(batch.events)
+	return batch.events
+}
+
+// readSegmentInto reads events from the current read position into batch,
+// until the end of the segment or the batch is full. The caller must hold the
+// lock.
+func (b *DiskEventBuffer) readSegmentInto(batch *logBatch) (full bool, err error) {
+	f, err := os.Open(b.segmentPath(b.segments[b.readSegment]))
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(b.readOffset, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			// Only complete lines are events.
+			return false, nil
+		}
+
+		event := new(cloudwatchlogs.InputLogEvent)
+		if err := json.Unmarshal(line, event); err != nil || event.Message == nil {
+			// Skip corrupted lines rather than getting stuck on them.
+			b.readOffset += int64(len(line))
+			b.count--
+			continue
+		}
+
+		if batch.add(event, maxBatchSizeBytes, maxBatchSizeEvents) != batch {
+			// The event starts a new batch, so it's left for the next drain.
+			batch.next = nil
+			return true, nil
+		}
+		b.readOffset += int64(len(line))
+	}
+}
+
+// HasMore reports whether the log holds events which weren't drained yet.
+func (b *DiskEventBuffer) HasMore() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count > 0
+}
+
+// Len returns the number of events which weren't drained yet.
+func (b *DiskEventBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count
+}
+
+// Commit removes the drained events from the log, once they've been sent.
+// Segments which were entirely drained are deleted, and the rest of the
+// current one is rewritten, or truncated if it's the last one.
+func (b *DiskEventBuffer) Commit() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, seq := range b.segments[:b.readSegment] {
+		if err := os.Remove(b.segmentPath(seq)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "could not remove an event log segment")
+		}
+	}
+	b.segments = b.segments[b.readSegment:]
+	b.readSegment = 0
+
+	if b.readOffset == 0 {
+		return nil
+	}
+
+	seq := b.segments[0]
+	if len(b.segments) == 1 && b.readOffset == b.size {
+		if err := b.active.Truncate(0); err != nil {
+			return errors.Wrap(err, "could not truncate the event log")
+		}
+		b.size, b.readOffset = 0, 0
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(b.segmentPath(seq))
+	if err != nil {
+		return errors.Wrap(err, "could not read the event log")
+	}
+
+	// Write the rest of the segment to a new file first, so that no events
+	// are lost if the process stops halfway.
+	rest := data[b.readOffset:]
+	tmp := b.segmentPath(seq) + ".tmp"
+	if err := ioutil.WriteFile(tmp, rest, 0644); err != nil {
+		return errors.Wrap(err, "could not rewrite the event log")
+	}
+	if err := os.Rename(tmp, b.segmentPath(seq)); err != nil {
+		return errors.Wrap(err, "could not rewrite the event log")
+	}
+	b.readOffset = 0
+
+	if len(b.segments) == 1 {
+		f, err := os.OpenFile(b.segmentPath(seq), os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return errors.Wrap(err, "could not reopen the event log")
+		}
+		b.active.Close()
+		b.active = f
+		b.size = int64(len(rest))
+	}
+
+	return nil
+}
+
+// Close closes the log. Events which weren't committed are kept for the next
+// buffer using the same path.
+func (b *DiskEventBuffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.active.Close()
+}
@@ -0,0 +1,171 @@
+package cloudwatch
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEvents(n int) []*cloudwatchlogs.InputLogEvent {
+	var ret []*cloudwatchlogs.InputLogEvent
+	for i := 0; i < n; i++ {
+		ret = append(ret, &cloudwatchlogs.InputLogEvent{
+			Message:   aws.String(fmt.Sprintf("event %d", i)),
+			Timestamp: aws.Int64(int64(1000 + i)),
+		})
+	}
+	return ret
+}
+
+// tempLogPath returns the path of an event log in a new temporary directory,
+// and a function removing it.
+func tempLogPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "cloudwatch")
+	require.NoError(t, err)
+	return filepath.Join(dir, "events.wal"), func() { os.RemoveAll(dir) }
+}
+
+func newTestDiskBuffer(t *testing.T, path string, maxBytes int64) *DiskEventBuffer {
+	buf, err := NewDiskEventBuffer(path, maxBytes)
+	require.NoError(t, err)
+	return buf
+}
+
+func TestDiskEventBufferRecovery(t *testing.T) {
+	path, cleanup := tempLogPath(t)
+	defer cleanup()
+	events := newTestEvents(3)
+
+	buf := newTestDiskBuffer(t, path, 0)
+	for _, event := range events {
+		require.NoError(t, buf.Add(context.Background(), event))
+	}
+
+	// Events drained but not committed before a restart are replayed.
+	require.Equal(t, events, buf.Drain())
+	require.False(t, buf.HasMore())
+	require.NoError(t, buf.Close())
+
+	buf = newTestDiskBuffer(t, path, 0)
+	require.True(t, buf.HasMore())
+	require.Equal(t, 3, buf.Len())
+	require.Equal(t, events, buf.Drain())
+
+	require.NoError(t, buf.Commit())
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Zero(t, info.Size())
+	require.NoError(t, buf.Close())
+
+	buf = newTestDiskBuffer(t, path, 0)
+	defer buf.Close()
+	require.False(t, buf.HasMore())
+}
+
+func TestDiskEventBufferPartialEvent(t *testing.T) {
+	path, cleanup := tempLogPath(t)
+	defer cleanup()
+	events := newTestEvents(1)
+
+	buf := newTestDiskBuffer(t, path, 0)
+	require.NoError(t, buf.Add(context.Background(), events[0]))
+	require.NoError(t, buf.Close())
+
+	// Simulate the process stopping while writing an event.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"Message":"trunc`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	buf = newTestDiskBuffer(t, path, 0)
+	defer buf.Close()
+	require.Equal(t, 1, buf.Len())
+	require.Equal(t, events, buf.Drain())
+
+	// The partial event was discarded, so new events are read correctly.
+	more := newTestEvents(2)[1:]
+	require.NoError(t, buf.Add(context.Background(), more[0]))
+	require.Equal(t, more, buf.Drain())
+}
+
+func TestDiskEventBufferRotation(t *testing.T) {
+	path, cleanup := tempLogPath(t)
+	defer cleanup()
+	events := newTestEvents(5)
+
+	// Each event takes about 40 bytes, so each segment holds two.
+	buf := newTestDiskBuffer(t, path, 100)
+	for _, event := range events {
+		require.NoError(t, buf.Add(context.Background(), event))
+	}
+
+	segments, err := filepath.Glob(path + "*")
+	require.NoError(t, err)
+	require.Len(t, segments, 3)
+	require.NoError(t, buf.Close())
+
+	buf = newTestDiskBuffer(t, path, 100)
+	defer buf.Close()
+	require.Equal(t, events, buf.Drain())
+	require.NoError(t, buf.Commit())
+
+	segments, err = filepath.Glob(path + "*")
+	require.NoError(t, err)
+	require.Equal(t, []string{path + ".2"}, segments)
+
+	b, err := ioutil.ReadFile(path + ".2")
+	require.NoError(t, err)
+	require.Empty(t, b)
+}
+
+func TestDiskEventBufferWriter(t *testing.T) {
+	path, cleanup := tempLogPath(t)
+	defer cleanup()
+	buf := newTestDiskBuffer(t, path, 0)
+	defer buf.Close()
+
+	api := new(mockAPI)
+	api.On(
+		"PutLogEventsWithContext",
+		context.Background(),
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String("groupName"),
+			LogStreamName: aws.String("streamName"),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	writer := &writerImpl{
+		client:     api,
+		ctx:        context.Background(),
+		events:     newEventsBuffer(),
+		groupName:  aws.String("groupName"),
+		retry:      newBackoff(),
+		streamName: aws.String("streamName"),
+	}
+	freezeTime(time.Unix(1, 0))(writer)
+	WithEventBuffer(buf)(writer)
+
+	_, err := writer.WriteString("Hello")
+	require.NoError(t, err)
+	require.NoError(t, writer.flushBatch())
+	api.AssertExpectations(t)
+
+	// Sent events were removed from the log.
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Zero(t, info.Size())
+}
@@ -1,45 +1,389 @@
 package cloudwatch
 
 import (
+	"context"
+	"sort"
 	"sync"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 )
 
+// BufferFullPolicy determines what happens to new log events when the writer's
+// buffer has reached its capacity.
+type BufferFullPolicy int
+
+const (
+	// BufferFullPolicyBlock blocks Write until the buffer is flushed or the
+	// writer's context is cancelled. This is the default.
+	BufferFullPolicyBlock BufferFullPolicy = iota
+
+	// BufferFullPolicyDropOldest evicts the oldest buffered event to make room
+	// for the new one.
+	BufferFullPolicyDropOldest
+)
+
+// EventBuffer holds a writer's events between flushes. Implementations must be
+// safe for concurrent use.
+type EventBuffer interface {
+	// Add adds the event to the buffer. It may block while the buffer is
+	// full, in which case it returns ctx.Err() if ctx is done first.
+	Add(ctx context.Context, event *cloudwatchlogs.InputLogEvent) error
+
+	// Drain removes all events from the buffer, returning them in the order
+	// they are to be sent.
+	Drain() []*cloudwatchlogs.InputLogEvent
+
+	// HasMore reports whether the buffer holds any events.
+	HasMore() bool
+}
+
+// NewMemoryEventBuffer returns the unbounded in-memory buffer writers use by
+// default.
+func NewMemoryEventBuffer() EventBuffer {
+	return newEventsBuffer()
+}
+
+// NewBoundedEventBuffer returns an in-memory buffer holding at most maxEvents
+// events, on which Add blocks while it's full.
+func NewBoundedEventBuffer(maxEvents int) EventBuffer {
+	ret := newEventsBuffer()
+	ret.capacity = maxEvents
+	return ret
+}
+
+// WithEventBuffer makes the writer buffer events in buf, for example to keep
+// them on disk until they're sent. If buf has a Commit() error method, like
+// DiskEventBuffer, it's called once all drained events have been sent, or
+// dropped, so that it can discard them. Options configuring the buffer, such as
+// WithBufferCapacity, only apply to buffers returned by NewMemoryEventBuffer
+// or NewBoundedEventBuffer, and only when passed after WithEventBuffer. The
+// events drained from other buffers are split into batches of at most
+// WithMaxBatchBytes and WithMaxBatchEvents, whichever order they're passed in.
+func WithEventBuffer(buf EventBuffer) CreateOption {
+	return func(w *writerImpl) {
+		if b, ok := buf.(*eventsBuffer); ok {
+			w.events = b
+			return
+		}
+		w.store = &eventBufferAdapter{buf: buf, limits: w.events}
+	}
+}
+
+// eventStore is the part of eventsBuffer a writer uses to add and flush
+// events, which custom buffers are adapted to and tests substitute to inject
+// errors.
+type eventStore interface {
+	add(ctx context.Context, event *cloudwatchlogs.InputLogEvent) error
+	addUnbounded(event *cloudwatchlogs.InputLogEvent)
+	drain() [][]*cloudwatchlogs.InputLogEvent
+	requeue(batches [][]*cloudwatchlogs.InputLogEvent)
+	len() int
+	hasMore() bool
+}
+
 // eventsBuffer represents a buffer of cloudwatch events that are protected by a
 // mutex.
 type eventsBuffer struct {
 	sync.RWMutex
 	head, tail *logBatch
+
+	// Limits applied to each batch, in bytes (including padding) and events.
+	maxBytes, maxEvents int
+
+	// Totals across all pending batches, in bytes (including padding) and
+	// events.
+	pendingBytes, pendingEvents int
+
+	// When either pending total reaches its threshold, onThreshold is called.
+	// Zero thresholds are disabled.
+	thresholdBytes, thresholdEvents int
+	onThreshold                     func()
+
+	// Maximum number of pending events, and what to do once it's reached.
+	// Zero capacity means the buffer is unbounded.
+	capacity   int
+	fullPolicy BufferFullPolicy
+
+	// Whether events are kept in timestamp order within each batch.
+	sorted bool
+
+	// drained is closed and replaced every time the buffer is drained, waking
+	// up writers blocked waiting for space.
+	drained chan struct{}
 }
 
 func newEventsBuffer() *eventsBuffer {
 	batch := new(logBatch)
-	return &eventsBuffer{head: batch, tail: batch}
+	return &eventsBuffer{
+		head:      batch,
+		tail:      batch,
+		maxBytes:  maxBatchSizeBytes,
+		maxEvents: maxBatchSizeEvents,
+		drained:   make(chan struct{}),
+	}
 }
 
-func (b *eventsBuffer) add(event *cloudwatchlogs.InputLogEvent) {
+// add appends the event to the buffer, applying the buffer full policy if the
+// buffer is at capacity. An error is only returned if ctx is done while
+// waiting for space.
+func (b *eventsBuffer) add(ctx context.Context, event *cloudwatchlogs.InputLogEvent) error {
+	if event.Message == nil {
+		return nil
+	}
+
 	b.Lock()
-	defer b.Unlock()
-	b.tail = b.tail.add(event)
+	for b.isFull() {
+		if b.fullPolicy == BufferFullPolicyDropOldest {
+			b.dropOldest()
+			continue
+		}
+
+		drained := b.drained
+		b.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-drained:
+		}
+
+		b.Lock()
+	}
+
+	b.push(event)
+	return nil
 }
 
-func (b *eventsBuffer) drain() []*cloudwatchlogs.InputLogEvent {
+// addUnbounded appends the event to the buffer without enforcing its capacity.
+func (b *eventsBuffer) addUnbounded(event *cloudwatchlogs.InputLogEvent) {
+	if event.Message == nil {
+		return
+	}
+
 	b.Lock()
-	defer b.Unlock()
+	b.push(event)
+}
 
-	ret := b.head.events
-	if b.head == b.tail {
-		b.head = new(logBatch)
-		b.tail = b.head
+// push adds the event and releases the lock, which the caller must hold.
+func (b *eventsBuffer) push(event *cloudwatchlogs.InputLogEvent) {
+	if b.sorted {
+		b.addSorted(event)
 	} else {
+		b.tail = b.tail.add(event, b.maxBytes, b.maxEvents)
+	}
+	b.pendingBytes += len(*event.Message) + paddingSize
+	b.pendingEvents++
+	crossed := b.crossedThreshold()
+	b.Unlock()
+
+	// The callback is called without holding the lock so that it's free to
+	// drain the buffer.
+	if crossed && b.onThreshold != nil {
+		b.onThreshold()
+	}
+}
+
+// addSorted adds the event to the last batch, or a new one, in ascending
+// timestamp order. Batches are sent one after the other, so events only need
+// to be in order within each batch. The caller must hold the lock.
+func (b *eventsBuffer) addSorted(event *cloudwatchlogs.InputLogEvent) {
+	b.tail = b.tail.add(event, b.maxBytes, b.maxEvents)
+
+	// The event was appended, so move it to the first position after all
+	// events with a timestamp at or before its own.
+	events := b.tail.events
+	last := len(events) - 1
+	ts := aws.Int64Value(event.Timestamp)
+	i := sort.Search(last, func(i int) bool {
+		return aws.Int64Value(events[i].Timestamp) > ts
+	})
+	copy(events[i+1:], events[i:last])
+	events[i] = event
+}
+
+func (b *eventsBuffer) isFull() bool {
+	return b.capacity > 0 && b.pendingEvents >= b.capacity
+}
+
+func (b *eventsBuffer) crossedThreshold() bool {
+	if b.thresholdBytes > 0 && b.pendingBytes >= b.thresholdBytes {
+		return true
+	}
+	return b.thresholdEvents > 0 && b.pendingEvents >= b.thresholdEvents
+}
+
+// dropOldest removes the first pending event. The caller must hold the lock.
+func (b *eventsBuffer) dropOldest() {
+	if len(b.head.events) == 0 {
+		return
+	}
+
+	size := len(*b.head.events[0].Message) + paddingSize
+	b.head.events = b.head.events[1:]
+	b.head.count--
+	b.head.size -= size
+	b.pendingBytes -= size
+	b.pendingEvents--
+
+	if len(b.head.events) == 0 && b.head.next != nil {
 		b.head = b.head.next
 	}
+}
+
+// drain empties the buffer, returning its contents split up into batches which
+// respect the batch size limits.
+func (b *eventsBuffer) drain() [][]*cloudwatchlogs.InputLogEvent {
+	b.Lock()
+	defer b.Unlock()
+
+	var ret [][]*cloudwatchlogs.InputLogEvent
+	for batch := b.head; batch != nil; batch = batch.next {
+		if len(batch.events) > 0 {
+			ret = append(ret, batch.events)
+		}
+	}
+
+	b.head = new(logBatch)
+	b.tail = b.head
+	b.pendingBytes, b.pendingEvents = 0, 0
+
+	close(b.drained)
+	b.drained = make(chan struct{})
+
 	return ret
 }
 
+// requeue puts previously drained batches back at the front of the buffer, in
+// order. Capacity is not enforced for requeued events.
+func (b *eventsBuffer) requeue(batches [][]*cloudwatchlogs.InputLogEvent) {
+	b.Lock()
+	defer b.Unlock()
+
+	for i := len(batches) - 1; i >= 0; i-- {
+		batch := &logBatch{events: batches[i], count: len(batches[i]), next: b.head}
+		for _, event := range batch.events {
+			batch.size += len(*event.Message) + paddingSize
+		}
+
+		b.head = batch
+		b.pendingBytes += batch.size
+		b.pendingEvents += batch.count
+	}
+}
+
+// len returns the number of pending events.
+func (b *eventsBuffer) len() int {
+	b.RLock()
+	defer b.RUnlock()
+	return b.pendingEvents
+}
+
 func (b *eventsBuffer) hasMore() bool {
 	b.RLock()
 	defer b.RUnlock()
 	return len(b.head.events) > 0
 }
+
+func (b *eventsBuffer) Add(ctx context.Context, event *cloudwatchlogs.InputLogEvent) error {
+	return b.add(ctx, event)
+}
+
+func (b *eventsBuffer) Drain() []*cloudwatchlogs.InputLogEvent {
+	var ret []*cloudwatchlogs.InputLogEvent
+	for _, batch := range b.drain() {
+		ret = append(ret, batch...)
+	}
+	return ret
+}
+
+func (b *eventsBuffer) HasMore() bool {
+	return b.hasMore()
+}
+
+// eventBufferAdapter splits the events of a custom buffer into batches, and
+// keeps batches which couldn't be sent until the next flush.
+type eventBufferAdapter struct {
+	buf    EventBuffer
+	limits *eventsBuffer // The writer's buffer, whose batch limits are used.
+
+	mu       sync.Mutex // This protects requeued.
+	requeued [][]*cloudwatchlogs.InputLogEvent
+}
+
+func (a *eventBufferAdapter) add(ctx context.Context, event *cloudwatchlogs.InputLogEvent) error {
+	if event.Message == nil {
+		return nil
+	}
+	return a.buf.Add(ctx, event)
+}
+
+// addUnbounded adds the event using Add, since custom buffers can't be asked
+// to ignore their capacity.
+func (a *eventBufferAdapter) addUnbounded(event *cloudwatchlogs.InputLogEvent) {
+	_ = a.add(context.Background(), event)
+}
+
+func (a *eventBufferAdapter) drain() [][]*cloudwatchlogs.InputLogEvent {
+	a.mu.Lock()
+	ret := a.requeued
+	a.requeued = nil
+	a.mu.Unlock()
+
+	head := new(logBatch)
+	tail := head
+	for _, event := range a.buf.Drain() {
+		tail = tail.add(event, a.limits.maxBytes, a.limits.maxEvents)
+	}
+	for batch := head; batch != nil; batch = batch.next {
+		if len(batch.events) > 0 {
+			ret = append(ret, batch.events)
+		}
+	}
+
+	return ret
+}
+
+func (a *eventBufferAdapter) requeue(batches [][]*cloudwatchlogs.InputLogEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.requeued = append(append([][]*cloudwatchlogs.InputLogEvent(nil), batches...), a.requeued...)
+}
+
+// len returns the number of requeued events, plus the number of events in the
+// buffer if it has a Len method.
+func (a *eventBufferAdapter) len() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var ret int
+	for _, batch := range a.requeued {
+		ret += len(batch)
+	}
+	if l, ok := a.buf.(interface{ Len() int }); ok {
+		ret += l.Len()
+	}
+	return ret
+}
+
+// commit lets the buffer discard the events drained so far, unless some of
+// them are waiting to be sent again.
+func (a *eventBufferAdapter) commit() error {
+	a.mu.Lock()
+	requeued := len(a.requeued) > 0
+	a.mu.Unlock()
+
+	c, ok := a.buf.(interface{ Commit() error })
+	if requeued || !ok {
+		return nil
+	}
+	return c.Commit()
+}
+
+func (a *eventBufferAdapter) hasMore() bool {
+	a.mu.Lock()
+	requeued := len(a.requeued) > 0
+	a.mu.Unlock()
+
+	return requeued || a.buf.HasMore()
+}
@@ -0,0 +1,67 @@
+package cloudwatch
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/pkg/errors"
+)
+
+// GroupExists reports whether the log group exists. It requires the
+// logs:DescribeLogGroups IAM permission.
+func GroupExists(ctx context.Context, client iface.CloudWatchLogsAPI, groupName string) (bool, error) {
+	input := &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(groupName),
+	}
+
+	// Other groups may share the name as a prefix, so the group may not be
+	// on the first page.
+	for {
+		resp, err := client.DescribeLogGroupsWithContext(ctx, input)
+		if err != nil {
+			return false, errors.Wrap(err, "couldn't get log group description")
+		}
+
+		for _, group := range resp.LogGroups {
+			if aws.StringValue(group.LogGroupName) == groupName {
+				return true, nil
+			}
+		}
+
+		if resp.NextToken == nil {
+			return false, nil
+		}
+		input.NextToken = resp.NextToken
+	}
+}
+
+func (g *groupImpl) StreamExists(ctx context.Context, streamName string) (bool, error) {
+	it := g.StreamIterator(ctx, streamName)
+	for name, ok := it.Next(); ok; name, ok = it.Next() {
+		if name == streamName {
+			return true, nil
+		}
+	}
+
+	return false, it.Err()
+}
+
+func (g *groupImpl) WaitForStream(ctx context.Context, streamName string, pollInterval time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if exists, err := g.StreamExists(ctx, streamName); err != nil || exists {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
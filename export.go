@@ -0,0 +1,85 @@
+package cloudwatch
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+)
+
+// ExportOptions describes the log events exported by Group.ExportToS3.
+type ExportOptions struct {
+	// Destination is the name of the S3 bucket, whose policy must allow
+	// CloudWatch Logs to write to it.
+	Destination string
+
+	// DestinationPrefix is prepended to the exported objects' keys. It
+	// defaults to "exportedlogs".
+	DestinationPrefix string
+
+	// From and To restrict the export to events at or after From, and
+	// before To.
+	From, To time.Time
+
+	// StreamName restricts the export to the log streams whose name starts
+	// with it, if not empty. The API does not support selecting a stream by
+	// its exact name.
+	StreamName string
+}
+
+func (g *groupImpl) ExportToS3(ctx context.Context, opts ExportOptions) (*cloudwatchlogs.ExportTask, error) {
+	input := &cloudwatchlogs.CreateExportTaskInput{
+		Destination:  aws.String(opts.Destination),
+		From:         aws.Int64(toMillis(opts.From)),
+		LogGroupName: aws.String(g.groupName),
+		To:           aws.Int64(toMillis(opts.To)),
+	}
+	if opts.DestinationPrefix != "" {
+		input.DestinationPrefix = aws.String(opts.DestinationPrefix)
+	}
+	if opts.StreamName != "" {
+		input.LogStreamNamePrefix = aws.String(opts.StreamName)
+	}
+
+	resp, err := g.CreateExportTaskWithContext(ctx, input)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create the export task")
+	}
+
+	retry := newBackoff()
+	for attempt := 0; ; attempt++ {
+		if err := aws.SleepWithContext(ctx, retry.delay(attempt)); err != nil {
+			return nil, err
+		}
+
+		task, err := g.exportTask(ctx, resp.TaskId)
+		if err != nil {
+			return nil, err
+		}
+
+		switch code := aws.StringValue(task.Status.Code); code {
+		case cloudwatchlogs.ExportTaskStatusCodeCompleted:
+			return task, nil
+		case cloudwatchlogs.ExportTaskStatusCodeFailed, cloudwatchlogs.ExportTaskStatusCodeCancelled:
+			return task, errors.Errorf("export task %s ended with status %s: %s", *resp.TaskId, code, aws.StringValue(task.Status.Message))
+		}
+	}
+}
+
+// exportTask describes the export task with the given ID.
+func (g *groupImpl) exportTask(ctx context.Context, taskID *string) (*cloudwatchlogs.ExportTask, error) {
+	resp, err := g.DescribeExportTasksWithContext(ctx, &cloudwatchlogs.DescribeExportTasksInput{
+		TaskId: taskID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not describe the export task")
+	}
+
+	if len(resp.ExportTasks) == 0 || resp.ExportTasks[0].Status == nil {
+		return nil, errors.Errorf("export task %s not found", aws.StringValue(taskID))
+	}
+
+	return resp.ExportTasks[0], nil
+}
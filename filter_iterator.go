@@ -0,0 +1,100 @@
+package cloudwatch
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/pkg/errors"
+)
+
+// filterLogEventsLimit is the maximum number of events returned by a
+// FilterLogEvents request.
+const filterLogEventsLimit = 10000
+
+type filterIterator struct {
+	client    iface.CloudWatchLogsAPI
+	ctx       context.Context
+	groupName *string
+	opts      FilterOptions
+
+	page      []*cloudwatchlogs.FilteredLogEvent
+	nextToken *string
+	started   bool
+	returned  int
+	err       error
+}
+
+func (it *filterIterator) Next() (*cloudwatchlogs.FilteredLogEvent, bool) {
+	if it.opts.Limit > 0 && it.returned >= it.opts.Limit {
+		return nil, false
+	}
+
+	for len(it.page) == 0 {
+		if it.err != nil || (it.started && it.nextToken == nil) {
+			return nil, false
+		}
+		it.fetch()
+	}
+
+	event := it.page[0]
+	it.page = it.page[1:]
+	it.returned++
+	return event, true
+}
+
+func (it *filterIterator) Err() error {
+	return it.err
+}
+
+// fetch requests the next page of events, waiting for readThrottle first
+// unless it's the first page.
+func (it *filterIterator) fetch() {
+	if it.started {
+		if err := aws.SleepWithContext(it.ctx, readThrottle); err != nil {
+			it.err = err
+			return
+		}
+	}
+
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: it.groupName,
+		NextToken:    it.nextToken,
+	}
+	if !it.opts.StartTime.IsZero() {
+		input.StartTime = aws.Int64(toMillis(it.opts.StartTime))
+	}
+	if !it.opts.EndTime.IsZero() {
+		input.EndTime = aws.Int64(toMillis(it.opts.EndTime))
+	}
+	if it.opts.FilterPattern != "" {
+		input.FilterPattern = aws.String(it.opts.FilterPattern)
+	}
+	if len(it.opts.StreamNames) > 0 {
+		input.LogStreamNames = aws.StringSlice(it.opts.StreamNames)
+	}
+	if it.opts.Limit > 0 {
+		input.Limit = aws.Int64(int64(minInt(it.opts.Limit-it.returned, filterLogEventsLimit)))
+	}
+
+	resp, err := it.client.FilterLogEventsWithContext(it.ctx, input)
+	if err != nil && it.ctx.Err() != nil {
+		it.err = it.ctx.Err()
+		return
+	} else if err != nil {
+		it.err = errors.Wrap(err, "couldn't filter log events")
+		return
+	}
+
+	it.started = true
+	it.page = resp.Events
+	it.nextToken = resp.NextToken
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
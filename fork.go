@@ -0,0 +1,51 @@
+package cloudwatch
+
+import "io"
+
+type fork struct {
+	writers []io.WriteCloser
+}
+
+// Fork returns an io.WriteCloser writing to all writers, typically writers
+// returned by Group.Create for different log streams. Unlike Tee, all writers
+// are treated alike: a failing writer doesn't keep the others from being
+// written to, and the errors of all failing writers are returned as a
+// MultiError.
+//
+// Closing it closes all writers.
+func Fork(writers ...io.WriteCloser) io.WriteCloser {
+	return &fork{writers: writers}
+}
+
+func (f *fork) Write(b []byte) (int, error) {
+	ret := len(b)
+	var errs MultiError
+	for _, w := range f.writers {
+		n, err := w.Write(b)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if n < ret {
+			ret = n
+		}
+	}
+
+	if len(errs) == 0 {
+		return ret, nil
+	}
+	return ret, errs
+}
+
+func (f *fork) Close() error {
+	var errs MultiError
+	for _, w := range f.writers {
+		if err := w.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
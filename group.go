@@ -3,14 +3,18 @@ package cloudwatch
 import (
 	"context"
 	"io"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/enfipy/locker"
 
 	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 )
 
 // Throttling and limits from http://docs.aws.amazon.com/AmazonCloudWatch/latest/DeveloperGuide/cloudwatch_limits.html
@@ -18,10 +22,26 @@ const (
 	// The maximum rate of a GetLogEvents request is 10 requests per second per AWS account.
 	readThrottle = time.Second / 10
 
+	// The default maximum interval between two GetLogEvents requests of a
+	// reader tailing a stream without new events.
+	defaultMaxPollInterval = 30 * time.Second
+
 	// The maximum rate of a PutLogEvents request is 5 requests per second per log stream.
 	writeThrottle = time.Second / 5
 )
 
+// ErrNotFound is returned when deleting a log group or stream that doesn't
+// exist.
+var ErrNotFound = errors.New("log group or stream not found")
+
+// ErrClientOptions is returned by the writers and readers of a group created
+// by NewGroup with WithHTTPClient, WithHTTPTransport or WithEndpointURL, which
+// can't apply to a client that was already built.
+var ErrClientOptions = errors.New("HTTP client and endpoint options require building the client")
+
+// retentionDays are the retention periods accepted by PutRetentionPolicy.
+var retentionDays = []int{1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1827, 3653}
+
 // now is a function that returns the current time.Time. It's a variable so that
 // it can be stubbed out in unit tests.
 // var now = time.Now
@@ -30,97 +50,597 @@ type groupImpl struct {
 	iface.CloudWatchLogsAPI
 	groupName string
 	locker    *locker.Locker
+
+	groupARN *string
+	arnMu    sync.Mutex // This protects groupARN.
+
+	describeCache describeCache
+
+	// Open writers, mapped to the name of their stream. A stream may have
+	// several.
+	writers sync.Map
+
+	// Readers started by Open, Events and OpenMany which haven't stopped yet.
+	readers sync.Map
+
+	// Shared by all readers of the group, so that they don't exceed the
+	// account's request limit together.
+	readLimiter *rate.Limiter
+
+	// Default flush interval of the writers of the group.
+	writeInterval time.Duration
+
+	metrics GroupMetricsCollector
+	logger  GroupLogger
+
+	// KMS key the group is encrypted with when it's created.
+	kmsKeyID *string
+
+	// Role whose credentials are used instead of those of the client.
+	assumeRole *assumeRoleConfig
+
+	// HTTP client, transport and endpoint of the clients built by the group.
+	httpClient    *http.Client
+	httpTransport http.RoundTripper
+	endpointURL   *string
+
+	// Error of an invalid option or of setting up the client, returned by
+	// NewGroupFromConfig, or by the first call creating a writer or reading
+	// events of a group created by NewGroup.
+	optionErr error
+}
+
+// GroupMetricsCollector receives measurements of the activity of all writers
+// and readers of a group. Implementations must be safe for concurrent use.
+type GroupMetricsCollector interface {
+	// Metrics is used by writers unless they're created using WithMetrics.
+	Metrics
+
+	// ObserveRead is called after each request of a reader for events.
+	ObserveRead(duration time.Duration, events int, err error)
 }
 
-// NewGroup returns a new Group instance.
-func NewGroup(client iface.CloudWatchLogsAPI, groupName string) Group {
-	return &groupImpl{
-		CloudWatchLogsAPI: client,
-		groupName:         groupName,
-		locker:            locker.Initialize(),
+// NewGroup returns a new Group instance. If an option is invalid, or the role
+// of WithAssumeRole can't be set up, the error is logged and returned by every
+// request of the group and its writers and readers, instead of sending it
+// using client.
+func NewGroup(client iface.CloudWatchLogsAPI, groupName string, opts ...GroupOption) Group {
+	ret := newGroup(groupName, opts...)
+
+	// Unless the client is built again to assume a role, it's too late to
+	// change how it sends requests.
+	if ret.optionErr == nil && ret.assumeRole == nil && ret.hasClientOptions() {
+		ret.optionErr = ErrClientOptions
+	}
+	if ret.optionErr == nil {
+		ret.optionErr = ret.setClient(client)
+	}
+	if ret.optionErr != nil {
+		ret.logger.Error("could not set up the group", "group", groupName, "error", ret.optionErr)
+		ret.CloudWatchLogsAPI = unavailableClient{err: ret.optionErr}
 	}
+
+	return ret
 }
 
-func (g *groupImpl) Create(ctx context.Context, streamName string, opts ...CreateOption) (io.WriteCloser, error) {
-	ret, err := g.create(ctx, streamName)
-	if err != nil {
-		return nil, err
+// newGroup returns a group without a client, with opts applied.
+func newGroup(groupName string, opts ...GroupOption) *groupImpl {
+	ret := &groupImpl{
+		groupName:     groupName,
+		locker:        locker.Initialize(),
+		readLimiter:   rate.NewLimiter(rate.Every(readThrottle), 1),
+		writeInterval: writeThrottle,
+		logger:        defaultGroupLogger{},
+		describeCache: describeCache{ttl: defaultDescribeCacheTTL},
 	}
 
 	for _, opt := range opts {
 		opt(ret)
 	}
 
+	return ret
+}
+
+// setClient sets the client of the group, which is replaced by one using the
+// credentials of a role if WithAssumeRole was used.
+func (g *groupImpl) setClient(client iface.CloudWatchLogsAPI) error {
+	g.CloudWatchLogsAPI = client
+	if g.assumeRole != nil {
+		return g.useAssumedRole()
+	}
+	return nil
+}
+
+// WithReadRateLimit sets the number of requests per second all readers of the
+// group may make together. Defaults to 10, the GetLogEvents quota of an AWS
+// account. Since the quota applies to the whole account, groups of the same
+// account should share an AccountReadLimit instead.
+func WithReadRateLimit(perSecond float64) GroupOption {
+	return func(g *groupImpl) {
+		if perSecond > 0 {
+			g.readLimiter = rate.NewLimiter(rate.Limit(perSecond), 1)
+		}
+	}
+}
+
+// AccountReadLimit is a limit on the GetLogEvents requests of the readers of
+// several groups, given to each of them using WithAccountReadLimit.
+type AccountReadLimit struct {
+	limiter *rate.Limiter
+}
+
+// NewAccountReadLimit returns a limit of perSecond requests per second.
+// Values which aren't positive default to 10, the GetLogEvents quota of an AWS
+// account.
+func NewAccountReadLimit(perSecond float64) *AccountReadLimit {
+	limit := rate.Every(readThrottle)
+	if perSecond > 0 {
+		limit = rate.Limit(perSecond)
+	}
+	return &AccountReadLimit{limiter: rate.NewLimiter(limit, 1)}
+}
+
+// WithAccountReadLimit makes the readers of the group share l with the readers
+// of the other groups it's given to, so that together they don't exceed the
+// quota of the account they're in.
+func WithAccountReadLimit(l *AccountReadLimit) GroupOption {
+	return func(g *groupImpl) {
+		if l != nil {
+			g.readLimiter = l.limiter
+		}
+	}
+}
+
+// WithWriteRateLimit sets the number of requests per second each writer of the
+// group may make to its stream. Defaults to 5, which is also the maximum
+// accepted by CloudWatch Logs, so higher rates are lowered to 5. It's
+// overridden by WithFlushInterval.
+func WithWriteRateLimit(perSecond float64) GroupOption {
+	return func(g *groupImpl) {
+		if perSecond > 0 {
+			g.writeInterval = time.Duration(float64(time.Second) / perSecond)
+			if g.writeInterval < writeThrottle {
+				g.writeInterval = writeThrottle
+			}
+		}
+	}
+}
+
+// WithGroupMetrics reports measurements of the activity of the writers and
+// readers of the group to m.
+func WithGroupMetrics(m GroupMetricsCollector) GroupOption {
+	return func(g *groupImpl) {
+		g.metrics = m
+	}
+}
+
+// WithGroupLogger logs the internal operations of the group and its writers
+// and readers to l. By default, only warnings and errors are logged, to the
+// standard logger. Use NoopGroupLogger to discard them.
+func WithGroupLogger(l GroupLogger) GroupOption {
+	return func(g *groupImpl) {
+		g.logger = l
+	}
+}
+
+// WithKMSKeyID encrypts the log group with the KMS key keyID, given as an ARN,
+// when it's created by a writer using WithCreateGroupIfMissing. It has no
+// effect on existing groups, see Group.AssociateKMSKey.
+func WithKMSKeyID(keyID string) GroupOption {
+	return func(g *groupImpl) {
+		g.kmsKeyID = aws.String(keyID)
+	}
+}
+
+// NewGroupFromConfig returns a new Group instance using a CloudWatch Logs
+// client built from cfg.
+func NewGroupFromConfig(cfg *aws.Config, groupName string, opts ...GroupOption) (Group, error) {
+	ret := newGroup(groupName, opts...)
+	if ret.optionErr != nil {
+		return nil, ret.optionErr
+	}
+
+	sess, err := session.NewSession(ret.clientConfig(cfg))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create an AWS session")
+	}
+
+	if err := ret.setClient(cloudwatchlogs.New(sess)); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (g *groupImpl) Create(ctx context.Context, streamName string, opts ...CreateOption) (Writer, error) {
+	ret, err := g.create(ctx, streamName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.throttle = time.NewTicker(ret.flushInterval)
+	ret.adaptive = newAdaptiveThrottle(ret.flushInterval)
+	g.register(ret, streamName)
+
 	go ret.start()
 	return ret, nil
 }
 
+func (g *groupImpl) CreateEphemeral(ctx context.Context, prefix string, opts ...CreateOption) (Writer, string, error) {
+	streamName := prefix + "-" + newUUID()
+	ret, err := g.Create(ctx, streamName, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return ret, streamName, nil
+}
+
 func (g *groupImpl) Name() string {
 	return g.groupName
 }
 
-func (g *groupImpl) Open(ctx context.Context, streamName string) io.ReadCloser {
+func (g *groupImpl) Open(ctx context.Context, streamName string, opts ...ReadOption) Reader {
+	ret := g.newReader(ctx, streamName, opts...)
+
+	g.readers.Store(ret, struct{}{})
+	go func() {
+		defer g.readers.Delete(ret)
+		ret.start()
+	}()
+	return ret
+}
+
+func (g *groupImpl) Events(ctx context.Context, streamName string, opts ...ReadOption) (<-chan *cloudwatchlogs.OutputLogEvent, <-chan error) {
+	events := make(chan *cloudwatchlogs.OutputLogEvent)
+	errs := make(chan error, 1)
+
+	r := g.newReader(ctx, streamName, opts...)
+	r.onEvent = func(event *cloudwatchlogs.OutputLogEvent) error {
+		select {
+		case events <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	g.readers.Store(r, struct{}{})
+	go func() {
+		defer close(errs)
+		defer close(events)
+		defer g.readers.Delete(r)
+
+		// The reader is only closed when the group is deleted, so start
+		// usually returns once the context is done or reading fails.
+		r.start()
+		r.throttle.Stop()
+
+		if err := r.getErr(); err != nil && err != io.EOF && err != ctx.Err() {
+			errs <- err
+		}
+	}()
+
+	return events, errs
+}
+
+func (g *groupImpl) OpenMany(ctx context.Context, streamNames []string, opts ...ReadOption) io.ReadCloser {
+	ret := &multiReader{
+		readerImpl: &readerImpl{
+			cacheSize: defaultReadAtCacheBytes,
+			closeChan: make(chan struct{}),
+			ctx:       ctx,
+			throttle:  time.NewTicker(readThrottle),
+		},
+	}
+
+	for _, opt := range opts {
+		opt(ret.readerImpl)
+	}
+
+	for _, streamName := range streamNames {
+		stream := g.newReader(ctx, streamName, opts...)
+
+		// Streams are polled by the multiReader, not on their own.
+		stream.throttle.Stop()
+
+		ret.streams = append(ret.streams, &mergedStream{readerImpl: stream})
+	}
+
+	g.readers.Store(ret, struct{}{})
+	go func() {
+		defer g.readers.Delete(ret)
+		ret.start()
+	}()
+	return ret
+}
+
+func (g *groupImpl) SetRetention(ctx context.Context, days int) error {
+	if !validRetention(days) {
+		return errors.Errorf("invalid retention of %d days, must be one of %v", days, retentionDays)
+	}
+
+	_, err := g.PutRetentionPolicyWithContext(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String(g.groupName),
+		RetentionInDays: aws.Int64(int64(days)),
+	})
+
+	return errors.Wrap(err, "could not set the log group retention policy")
+}
+
+func (g *groupImpl) DeleteRetention(ctx context.Context) error {
+	_, err := g.DeleteRetentionPolicyWithContext(ctx, &cloudwatchlogs.DeleteRetentionPolicyInput{
+		LogGroupName: aws.String(g.groupName),
+	})
+
+	return errors.Wrap(err, "could not delete the log group retention policy")
+}
+
+func (g *groupImpl) GetRecord(ctx context.Context, logRecordPointer string) (map[string]string, error) {
+	resp, err := g.GetLogRecordWithContext(ctx, &cloudwatchlogs.GetLogRecordInput{
+		LogRecordPointer: aws.String(logRecordPointer),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the log record")
+	}
+
+	return aws.StringValueMap(resp.LogRecord), nil
+}
+
+func (g *groupImpl) AssociateKMSKey(ctx context.Context, keyID string) error {
+	_, err := g.AssociateKmsKeyWithContext(ctx, &cloudwatchlogs.AssociateKmsKeyInput{
+		KmsKeyId:     aws.String(keyID),
+		LogGroupName: aws.String(g.groupName),
+	})
+
+	return errors.Wrap(err, "could not associate the KMS key with the log group")
+}
+
+func (g *groupImpl) DisassociateKMSKey(ctx context.Context) error {
+	_, err := g.DisassociateKmsKeyWithContext(ctx, &cloudwatchlogs.DisassociateKmsKeyInput{
+		LogGroupName: aws.String(g.groupName),
+	})
+
+	return errors.Wrap(err, "could not disassociate the KMS key from the log group")
+}
+
+func (g *groupImpl) DeleteStream(ctx context.Context, streamName string) error {
+	_, err := g.DeleteLogStreamWithContext(ctx, &cloudwatchlogs.DeleteLogStreamInput{
+		LogGroupName:  aws.String(g.groupName),
+		LogStreamName: aws.String(streamName),
+	})
+
+	if _, ok := err.(*cloudwatchlogs.ResourceNotFoundException); ok {
+		return ErrNotFound
+	}
+
+	return errors.Wrap(err, "could not delete the log stream")
+}
+
+func (g *groupImpl) Delete(ctx context.Context) error {
+	// Writers would otherwise keep sending events, recreating their stream
+	// in a group which no longer exists, and readers polling it.
+	g.readers.Range(func(key, _ interface{}) bool {
+		key.(io.Closer).Close()
+		return true
+	})
+	if err := g.CloseAll(); err != nil {
+		g.logger.Warn("could not close the writers of the deleted group", "group", g.groupName, "error", err)
+	}
+
+	_, err := g.DeleteLogGroupWithContext(ctx, &cloudwatchlogs.DeleteLogGroupInput{
+		LogGroupName: aws.String(g.groupName),
+	})
+
+	if _, ok := err.(*cloudwatchlogs.ResourceNotFoundException); ok {
+		return ErrNotFound
+	}
+
+	return errors.Wrap(err, "could not delete the log group")
+}
+
+func validRetention(days int) bool {
+	for _, d := range retentionDays {
+		if d == days {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *groupImpl) ListStreams(ctx context.Context, prefix string) ([]string, error) {
+	var names []string
+
+	it := g.StreamIterator(ctx, prefix)
+	for name, ok := it.Next(); ok; name, ok = it.Next() {
+		names = append(names, name)
+	}
+
+	return names, it.Err()
+}
+
+func (g *groupImpl) StreamIterator(ctx context.Context, prefix string) StreamIterator {
+	return &streamIterator{
+		client:    g,
+		ctx:       ctx,
+		groupName: aws.String(g.groupName),
+		prefix:    prefix,
+	}
+}
+
+func (g *groupImpl) Filter(ctx context.Context, opts FilterOptions) FilterIterator {
+	return &filterIterator{
+		client:    g,
+		ctx:       ctx,
+		groupName: aws.String(g.groupName),
+		opts:      opts,
+	}
+}
+
+func (g *groupImpl) newReader(ctx context.Context, streamName string, opts ...ReadOption) *readerImpl {
 	ret := &readerImpl{
+		cacheSize:  defaultReadAtCacheBytes,
 		client:     g,
+		closeChan:  make(chan struct{}),
 		ctx:        ctx,
 		groupName:  aws.String(g.groupName),
+		limiter:    g.readLimiter,
+		logger:     g.logger,
+		metrics:    g.metrics,
+		optionErr:  g.optionErr,
 		streamName: aws.String(streamName),
 		throttle:   time.NewTicker(readThrottle),
 	}
 
-	go ret.start()
+	for _, opt := range opts {
+		opt(ret)
+	}
+
+	ret.validate()
 	return ret
 }
 
-func (g *groupImpl) create(ctx context.Context, streamName string) (*writerImpl, error) {
+func (g *groupImpl) create(ctx context.Context, streamName string, opts ...CreateOption) (*writerImpl, error) {
+	if g.optionErr != nil {
+		return nil, g.optionErr
+	}
+
 	ret := &writerImpl{
-		client:     g,
-		closeChan:  make(chan struct{}),
-		ctx:        ctx,
-		events:     newEventsBuffer(),
-		groupName:  aws.String(g.groupName),
-		streamName: aws.String(streamName),
-		throttle:   time.NewTicker(writeThrottle),
+		autoRecreateStream: true,
+		client:             g,
+		closeChan:          make(chan struct{}),
+		group:              g,
+		ctx:                ctx,
+		events:             newEventsBuffer(),
+		flushChan:          make(chan struct{}, 1),
+		flushInterval:      g.writeInterval,
+		groupName:          aws.String(g.groupName),
+		logger:             g.logger,
+		metrics:            g.metrics,
+		retry:              newBackoff(),
+		streamName:         aws.String(streamName),
+		tokenRetry:         newBackoff(),
 	}
 
+	for _, opt := range opts {
+		opt(ret)
+	}
+
+	if err := g.openStream(ctx, ret, streamName); err != nil {
+		return nil, err
+	}
+	if err := ret.replayPending(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// openStream creates the log stream for w, or gets the sequence token to write
+// to it with if it already exists.
+func (g *groupImpl) openStream(ctx context.Context, w *writerImpl, streamName string) error {
 	unlock := g.locker.Lock(streamName)
 	defer unlock()
 
+	err := g.createStream(ctx, streamName)
+
+	if _, ok := err.(*cloudwatchlogs.ResourceNotFoundException); ok && w.createGroup {
+		if err = g.createGroup(ctx, w.groupRetention); err != nil {
+			return err
+		}
+		w.log().Info("created log group", "group", g.groupName)
+		err = g.createStream(ctx, streamName)
+	}
+
+	if err == nil {
+		w.log().Info("created log stream", "stream", streamName)
+		return nil
+	} else if _, ok := err.(*cloudwatchlogs.ResourceAlreadyExistsException); !ok {
+		return errors.Wrap(err, "could not create the log stream")
+	}
+
+	// A token set using FromToken takes precedence over the stream's.
+	if w.sequenceToken != nil {
+		return nil
+	}
+
+	if w.tokenStore != nil {
+		if token, ok := w.tokenStore.Load(streamName); ok {
+			w.sequenceToken = aws.String(token)
+			w.log().Info("loaded sequence token", "stream", streamName, "token", token)
+			return nil
+		}
+	}
+
+	if w.sequenceToken, err = g.getSequenceTokenWithBackoff(ctx, streamName, w.tokenRetry); err != nil {
+		return err
+	}
+	w.log().Info("described sequence token", "stream", streamName, "token", aws.StringValue(w.sequenceToken))
+
+	return nil
+}
+
+func (g *groupImpl) createStream(ctx context.Context, streamName string) error {
 	_, err := g.CreateLogStreamWithContext(ctx, &cloudwatchlogs.CreateLogStreamInput{
 		LogGroupName:  aws.String(g.groupName),
 		LogStreamName: aws.String(streamName),
 	})
+	return err
+}
 
-	if err == nil {
-		return ret, nil
-	} else if _, ok := err.(*cloudwatchlogs.ResourceAlreadyExistsException); !ok {
-		return nil, errors.Wrap(err, "could not create the log stream")
+// createGroup creates the log group, tolerating it having been created
+// concurrently, and sets its retention policy if retentionDays is not nil.
+func (g *groupImpl) createGroup(ctx context.Context, retentionDays *int64) error {
+	_, err := g.CreateLogGroupWithContext(ctx, &cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String(g.groupName),
+		KmsKeyId:     g.kmsKeyID,
+	})
+
+	if _, ok := err.(*cloudwatchlogs.ResourceAlreadyExistsException); err != nil && !ok {
+		return errors.Wrap(err, "could not create the log group")
 	}
 
-	if ret.sequenceToken, err = g.getSequenceTokenWithBackoff(ctx, streamName); err != nil {
-		return nil, err
+	if retentionDays == nil {
+		return nil
 	}
 
-	return ret, nil
+	_, err = g.PutRetentionPolicyWithContext(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String(g.groupName),
+		RetentionInDays: retentionDays,
+	})
+
+	return errors.Wrap(err, "could not set the log group retention policy")
 }
 
-func (g *groupImpl) getSequenceTokenWithBackoff(ctx context.Context, streamName string) (*string, error) {
-	var err error
-	var token *string
+// getSequenceTokenWithBackoff gets the stream's sequence token, retrying
+// failures according to retry. Writers are often created at the same time,
+// for example when a fleet of instances starts up, so randomized and
+// exponentially increasing delays spread out their retries rather than
+// hitting the DescribeLogStreams rate limit all at once.
+func (g *groupImpl) getSequenceTokenWithBackoff(ctx context.Context, streamName string, retry backoff) (*string, error) {
+	for attempt := 0; ; attempt++ {
+		token, err := g.getSequenceToken(ctx, streamName)
+		if err == nil || attempt >= retry.maxRetries {
+			return token, err
+		}
 
-	for i := 1; i <= 3; i++ {
-		if token, err = g.getSequenceToken(ctx, streamName); err != nil {
-			time.Sleep(time.Duration(i) * time.Second)
-			continue
+		if err := aws.SleepWithContext(ctx, retry.delay(attempt)); err != nil {
+			return nil, err
 		}
+	}
+}
 
-		break
+func (g *groupImpl) getSequenceToken(ctx context.Context, streamName string) (*string, error) {
+	stream, err := g.DescribeStream(ctx, streamName)
+	if err == ErrNotFound {
+		return nil, errors.Errorf("logs streams data missing for %s", streamName)
+	} else if err != nil {
+		return nil, err
 	}
 
-	return token, err
+	return stream.UploadSequenceToken, nil
 }
 
-func (g *groupImpl) getSequenceToken(ctx context.Context, streamName string) (*string, error) {
+func (g *groupImpl) DescribeStream(ctx context.Context, streamName string) (*cloudwatchlogs.LogStream, error) {
+	// Streams are listed in name order, so the stream named exactly like the
+	// prefix comes first among those matching it.
 	description, err := g.DescribeLogStreamsWithContext(ctx, &cloudwatchlogs.DescribeLogStreamsInput{
 		LogGroupName:        aws.String(g.groupName),
 		LogStreamNamePrefix: aws.String(streamName),
@@ -130,9 +650,11 @@ func (g *groupImpl) getSequenceToken(ctx context.Context, streamName string) (*s
 		return nil, errors.Wrap(err, "couldn't get log stream description")
 	}
 
-	if len(description.LogStreams) == 0 {
-		return nil, errors.Errorf("logs streams data missing for %s", streamName)
+	for _, stream := range description.LogStreams {
+		if aws.StringValue(stream.LogStreamName) == streamName {
+			return stream, nil
+		}
 	}
 
-	return description.LogStreams[0].UploadSequenceToken, nil
+	return nil, ErrNotFound
 }
@@ -1,14 +1,32 @@
 package cloudwatch
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/time/rate"
 )
 
 type groupTestSuite struct {
@@ -44,7 +62,7 @@ func (gs *groupTestSuite) TestCreateWithExistingStream_OK() {
 	gs.creatingLogStreamReturns(new(cloudwatchlogs.ResourceAlreadyExistsException))
 
 	gs.describingStreamsReturns([]*cloudwatchlogs.LogStream{
-		{UploadSequenceToken: aws.String(sequenceToken)},
+		{LogStreamName: aws.String(gs.streamName), UploadSequenceToken: aws.String(sequenceToken)},
 	}, nil)
 
 	writer, err := gs.sut.Create(gs.ctx, gs.streamName)
@@ -55,6 +73,63 @@ func (gs *groupTestSuite) TestCreateWithExistingStream_OK() {
 	gs.Equal(sequenceToken, *writer.(*writerImpl).sequenceToken)
 }
 
+func (gs *groupTestSuite) TestCreateWithExistingStream_TokenStore() {
+	dir, err := ioutil.TempDir("", "cloudwatch")
+	gs.Require().NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "tokens.json")
+	FileTokenStore(path).Save(gs.streamName, "sequenceToken")
+
+	gs.creatingLogStreamReturns(new(cloudwatchlogs.ResourceAlreadyExistsException))
+
+	writer, err := gs.sut.Create(gs.ctx, gs.streamName, WithSequenceTokenStore(FileTokenStore(path)))
+
+	gs.Require().NoError(err)
+	gs.Equal("sequenceToken", *writer.(*writerImpl).sequenceToken)
+	gs.api.AssertNotCalled(gs.T(), "DescribeLogStreamsWithContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (gs *groupTestSuite) TestWriters() {
+	gs.creatingLogStreamReturns(nil)
+
+	writer, err := gs.sut.Create(gs.ctx, gs.streamName)
+	gs.Require().NoError(err)
+	gs.Equal([]string{gs.streamName}, gs.sut.Writers())
+
+	gs.NoError(writer.Close())
+	gs.Empty(gs.sut.Writers())
+
+	_, err = gs.sut.Create(gs.ctx, gs.streamName)
+	gs.Require().NoError(err)
+
+	gs.NoError(gs.sut.CloseAll())
+	gs.Empty(gs.sut.Writers())
+}
+
+func (gs *groupTestSuite) TestCloseAll_SameStream() {
+	gs.creatingLogStreamReturns(nil)
+
+	first, err := gs.sut.Create(gs.ctx, gs.streamName)
+	gs.Require().NoError(err)
+	second, err := gs.sut.Create(gs.ctx, gs.streamName)
+	gs.Require().NoError(err)
+	gs.Equal([]string{gs.streamName}, gs.sut.Writers())
+
+	// Both writers are closed, and closing them again is harmless.
+	gs.NoError(gs.sut.CloseAll())
+	gs.Empty(gs.sut.Writers())
+	gs.True(first.(*writerImpl).closed)
+	gs.True(second.(*writerImpl).closed)
+	gs.NoError(first.Close())
+	gs.NoError(second.Close())
+}
+
+func (gs *groupTestSuite) TestMultiError() {
+	gs.EqualError(MultiError{errors.New("bacon")}, "bacon")
+	gs.EqualError(MultiError{errors.New("bacon"), errors.New("eggs")}, "2 errors occurred: bacon; eggs")
+}
+
 func (gs *groupTestSuite) TestCreateWithExistingStream_UnexpectedFailure() {
 	gs.creatingLogStreamReturns(errors.New("bacon"))
 
@@ -74,6 +149,29 @@ func (gs *groupTestSuite) TestCreateDescribingStreamFails() {
 	gs.Nil(writer)
 }
 
+func (gs *groupTestSuite) TestCreateDescribingStreamRetries() {
+	const sequenceToken = "sequenceToken"
+
+	gs.creatingLogStreamReturns(new(cloudwatchlogs.ResourceAlreadyExistsException))
+	gs.api.On(
+		"DescribeLogStreamsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeLogStreamsInput{
+			LogGroupName:        aws.String(gs.groupName),
+			LogStreamNamePrefix: aws.String(gs.streamName),
+		},
+		[]request.Option(nil),
+	).Twice().Return((*cloudwatchlogs.DescribeLogStreamsOutput)(nil), errors.New("bacon"))
+	gs.describingStreamsReturns([]*cloudwatchlogs.LogStream{
+		{LogStreamName: aws.String(gs.streamName), UploadSequenceToken: aws.String(sequenceToken)},
+	}, nil)
+
+	writer, err := gs.sut.Create(gs.ctx, gs.streamName, WithSequenceTokenRetries(2))
+
+	gs.Require().NoError(err)
+	gs.Equal(sequenceToken, *writer.(*writerImpl).sequenceToken)
+}
+
 func (gs *groupTestSuite) TestCreateDescribingStream_MissingLogStreamData() {
 	gs.creatingLogStreamReturns(new(cloudwatchlogs.ResourceAlreadyExistsException))
 	gs.describingStreamsReturns(nil, nil)
@@ -84,19 +182,89 @@ func (gs *groupTestSuite) TestCreateDescribingStream_MissingLogStreamData() {
 	gs.Nil(writer)
 }
 
-func (gs *groupTestSuite) describingStreamsReturns(result []*cloudwatchlogs.LogStream, err error) {
+func (gs *groupTestSuite) TestCreateWithMissingGroup() {
+	gs.creatingLogStreamReturns(new(cloudwatchlogs.ResourceNotFoundException))
+
+	writer, err := gs.sut.Create(gs.ctx, gs.streamName)
+
+	gs.Nil(writer)
+	gs.EqualError(err, "could not create the log stream: ResourceNotFoundException: ")
+}
+
+func (gs *groupTestSuite) TestCreateWithMissingGroup_CreateGroup() {
 	gs.api.On(
-		"DescribeLogStreamsWithContext",
+		"CreateLogStreamWithContext",
 		gs.ctx,
-		&cloudwatchlogs.DescribeLogStreamsInput{
-			LogGroupName:        aws.String(gs.groupName),
-			LogStreamNamePrefix: aws.String(gs.streamName),
+		&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(gs.groupName),
+			LogStreamName: aws.String(gs.streamName),
 		},
 		[]request.Option(nil),
-	).Return(&cloudwatchlogs.DescribeLogStreamsOutput{LogStreams: result}, err)
+	).Once().Return(&cloudwatchlogs.CreateLogStreamOutput{}, new(cloudwatchlogs.ResourceNotFoundException))
+
+	gs.api.On(
+		"CreateLogGroupWithContext",
+		gs.ctx,
+		&cloudwatchlogs.CreateLogGroupInput{LogGroupName: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+
+	gs.api.On(
+		"PutRetentionPolicyWithContext",
+		gs.ctx,
+		&cloudwatchlogs.PutRetentionPolicyInput{
+			LogGroupName:    aws.String(gs.groupName),
+			RetentionInDays: aws.Int64(7),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+
+	gs.creatingLogStreamReturns(nil)
+
+	writer, err := gs.sut.Create(gs.ctx, gs.streamName, WithCreateGroupIfMissing(), WithLogGroupRetention(7))
+
+	gs.Require().NoError(err)
+	gs.NotNil(writer)
+	gs.api.AssertExpectations(gs.T())
 }
 
-func (gs *groupTestSuite) creatingLogStreamReturns(err error) {
+func (gs *groupTestSuite) TestSetRetention() {
+	gs.api.On(
+		"PutRetentionPolicyWithContext",
+		gs.ctx,
+		&cloudwatchlogs.PutRetentionPolicyInput{
+			LogGroupName:    aws.String(gs.groupName),
+			RetentionInDays: aws.Int64(30),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+
+	gs.NoError(gs.sut.SetRetention(gs.ctx, 30))
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestSetRetention_Invalid() {
+	err := gs.sut.SetRetention(gs.ctx, 2)
+
+	gs.Error(err)
+	gs.Contains(err.Error(), "invalid retention of 2 days")
+	gs.api.AssertNotCalled(gs.T(), "PutRetentionPolicyWithContext")
+}
+
+func (gs *groupTestSuite) TestDeleteRetention() {
+	gs.api.On(
+		"DeleteRetentionPolicyWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DeleteRetentionPolicyInput{LogGroupName: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DeleteRetentionPolicyOutput{}, errors.New("bacon"))
+
+	gs.EqualError(gs.sut.DeleteRetention(gs.ctx), "could not delete the log group retention policy: bacon")
+}
+
+func (gs *groupTestSuite) TestCreateWithMissingGroup_KMSKey() {
+	gs.sut = NewGroup(gs.api, gs.groupName, WithKMSKeyID("arn:aws:kms:key"))
+
 	gs.api.On(
 		"CreateLogStreamWithContext",
 		gs.ctx,
@@ -105,9 +273,1522 @@ func (gs *groupTestSuite) creatingLogStreamReturns(err error) {
 			LogStreamName: aws.String(gs.streamName),
 		},
 		[]request.Option(nil),
-	).Return(&cloudwatchlogs.CreateLogStreamOutput{}, err)
+	).Once().Return(&cloudwatchlogs.CreateLogStreamOutput{}, new(cloudwatchlogs.ResourceNotFoundException))
+
+	gs.api.On(
+		"CreateLogGroupWithContext",
+		gs.ctx,
+		&cloudwatchlogs.CreateLogGroupInput{
+			KmsKeyId:     aws.String("arn:aws:kms:key"),
+			LogGroupName: aws.String(gs.groupName),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+
+	gs.creatingLogStreamReturns(nil)
+
+	writer, err := gs.sut.Create(gs.ctx, gs.streamName, WithCreateGroupIfMissing())
+
+	gs.Require().NoError(err)
+	gs.NotNil(writer)
+	gs.api.AssertExpectations(gs.T())
 }
 
-func TestGroup(t *testing.T) {
-	suite.Run(t, new(groupTestSuite))
+func (gs *groupTestSuite) TestGetRecord() {
+	gs.api.On(
+		"GetLogRecordWithContext",
+		gs.ctx,
+		&cloudwatchlogs.GetLogRecordInput{LogRecordPointer: aws.String("CmAKJgoi")},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogRecordOutput{
+		LogRecord: map[string]*string{
+			"@message":   aws.String("Hello"),
+			"@timestamp": aws.String("1000"),
+			"@logStream": aws.String(gs.streamName),
+		},
+	}, nil)
+
+	record, err := gs.sut.GetRecord(gs.ctx, "CmAKJgoi")
+	gs.Require().NoError(err)
+	gs.Equal(map[string]string{
+		"@message":   "Hello",
+		"@timestamp": "1000",
+		"@logStream": gs.streamName,
+	}, record)
+}
+
+func (gs *groupTestSuite) TestGetRecord_Error() {
+	gs.api.On(
+		"GetLogRecordWithContext",
+		gs.ctx,
+		&cloudwatchlogs.GetLogRecordInput{LogRecordPointer: aws.String("expired")},
+		[]request.Option(nil),
+	).Once().Return((*cloudwatchlogs.GetLogRecordOutput)(nil), errors.New("bacon"))
+
+	_, err := gs.sut.GetRecord(gs.ctx, "expired")
+	gs.EqualError(err, "could not get the log record: bacon")
+}
+
+func (gs *groupTestSuite) TestAssociateKMSKey() {
+	gs.api.On(
+		"AssociateKmsKeyWithContext",
+		gs.ctx,
+		&cloudwatchlogs.AssociateKmsKeyInput{
+			KmsKeyId:     aws.String("arn:aws:kms:key"),
+			LogGroupName: aws.String(gs.groupName),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.AssociateKmsKeyOutput{}, nil)
+
+	gs.NoError(gs.sut.AssociateKMSKey(gs.ctx, "arn:aws:kms:key"))
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestDisassociateKMSKey() {
+	gs.api.On(
+		"DisassociateKmsKeyWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DisassociateKmsKeyInput{LogGroupName: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DisassociateKmsKeyOutput{}, errors.New("bacon"))
+
+	gs.EqualError(gs.sut.DisassociateKMSKey(gs.ctx), "could not disassociate the KMS key from the log group: bacon")
+}
+
+func (gs *groupTestSuite) TestDeleteStream() {
+	gs.deletingStreamReturns(nil)
+
+	gs.NoError(gs.sut.DeleteStream(gs.ctx, gs.streamName))
+}
+
+func (gs *groupTestSuite) TestDeleteStream_NotFound() {
+	gs.deletingStreamReturns(new(cloudwatchlogs.ResourceNotFoundException))
+
+	gs.Equal(ErrNotFound, gs.sut.DeleteStream(gs.ctx, gs.streamName))
+}
+
+func (gs *groupTestSuite) TestDeleteStream_UnexpectedFailure() {
+	gs.deletingStreamReturns(errors.New("bacon"))
+
+	gs.EqualError(gs.sut.DeleteStream(gs.ctx, gs.streamName), "could not delete the log stream: bacon")
+}
+
+func (gs *groupTestSuite) TestDelete() {
+	gs.api.On(
+		"DeleteLogGroupWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DeleteLogGroupInput{LogGroupName: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DeleteLogGroupOutput{}, new(cloudwatchlogs.ResourceNotFoundException))
+
+	gs.Equal(ErrNotFound, gs.sut.Delete(gs.ctx))
+}
+
+func (gs *groupTestSuite) TestDelete_StopsWritersAndReaders() {
+	gs.creatingLogStreamReturns(nil)
+	writer, err := gs.sut.Create(gs.ctx, gs.streamName)
+	gs.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(gs.ctx)
+	defer cancel()
+	gs.api.On("GetLogEventsWithContext", ctx, mock.Anything, []request.Option(nil)).Return(&cloudwatchlogs.GetLogEventsOutput{}, nil)
+	reader := gs.sut.Open(ctx, gs.streamName, WithTailMode())
+
+	gs.api.On(
+		"DeleteLogGroupWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DeleteLogGroupInput{LogGroupName: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Run(func(mock.Arguments) {
+		gs.True(writer.(*writerImpl).closed)
+		gs.Empty(gs.sut.Writers())
+	}).Return(&cloudwatchlogs.DeleteLogGroupOutput{}, nil)
+
+	gs.NoError(gs.sut.Delete(gs.ctx))
+
+	// The reader stopped, so it reaches the end of the stream.
+	_, err = ioutil.ReadAll(reader)
+	gs.NoError(err)
+	gs.Equal(io.ErrClosedPipe, writer.Flush())
+}
+
+func (gs *groupTestSuite) TestTags() {
+	const arn = "arn:aws:logs:eu-west-1:123456789012:log-group:groupName"
+
+	gs.api.On(
+		"DescribeLogGroupsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{
+			{LogGroupName: aws.String("groupNameOther"), Arn: aws.String("other:*")},
+			{LogGroupName: aws.String(gs.groupName), Arn: aws.String(arn + ":*")},
+		},
+	}, nil)
+
+	gs.api.On(
+		"TagResourceWithContext",
+		gs.ctx,
+		&cloudwatchlogs.TagResourceInput{
+			ResourceArn: aws.String(arn),
+			Tags:        map[string]*string{"team": aws.String("logistics")},
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.TagResourceOutput{}, nil)
+
+	gs.api.On(
+		"UntagResourceWithContext",
+		gs.ctx,
+		&cloudwatchlogs.UntagResourceInput{
+			ResourceArn: aws.String(arn),
+			TagKeys:     []*string{aws.String("env")},
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.UntagResourceOutput{}, nil)
+
+	gs.api.On(
+		"ListTagsForResourceWithContext",
+		gs.ctx,
+		&cloudwatchlogs.ListTagsForResourceInput{ResourceArn: aws.String(arn)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.ListTagsForResourceOutput{
+		Tags: map[string]*string{"team": aws.String("logistics")},
+	}, nil)
+
+	gs.NoError(gs.sut.Tag(gs.ctx, map[string]string{"team": "logistics"}))
+	gs.NoError(gs.sut.Untag(gs.ctx, []string{"env"}))
+
+	tags, err := gs.sut.ListTags(gs.ctx)
+	gs.NoError(err)
+	gs.Equal(map[string]string{"team": "logistics"}, tags)
+
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestTags_GroupNotFound() {
+	gs.api.On(
+		"DescribeLogGroupsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeLogGroupsOutput{}, nil)
+
+	gs.Equal(ErrNotFound, gs.sut.Tag(gs.ctx, map[string]string{"team": "logistics"}))
+}
+
+func (gs *groupTestSuite) TestDescribeStream() {
+	gs.describingStreamsReturns([]*cloudwatchlogs.LogStream{
+		{LogStreamName: aws.String(gs.streamName), LastIngestionTime: aws.Int64(2000), StoredBytes: aws.Int64(1024)},
+		{LogStreamName: aws.String(gs.streamName + "-other")},
+	}, nil)
+
+	stream, err := gs.sut.DescribeStream(gs.ctx, gs.streamName)
+	gs.Require().NoError(err)
+	gs.Equal(int64(2000), aws.Int64Value(stream.LastIngestionTime))
+	gs.Equal(int64(1024), aws.Int64Value(stream.StoredBytes))
+}
+
+func (gs *groupTestSuite) TestDescribeStream_NotFound() {
+	gs.describingStreamsReturns([]*cloudwatchlogs.LogStream{
+		{LogStreamName: aws.String(gs.streamName + "-other")},
+	}, nil)
+
+	_, err := gs.sut.DescribeStream(gs.ctx, gs.streamName)
+	gs.Equal(ErrNotFound, err)
+}
+
+func (gs *groupTestSuite) TestDescribeStream_UnexpectedFailure() {
+	gs.describingStreamsReturns(nil, errors.New("bacon"))
+
+	_, err := gs.sut.DescribeStream(gs.ctx, gs.streamName)
+	gs.EqualError(err, "couldn't get log stream description: bacon")
+}
+
+func (gs *groupTestSuite) TestDescribe() {
+	gs.api.On(
+		"DescribeLogGroupsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{
+			{LogGroupName: aws.String("groupName-a")},
+			{LogGroupName: aws.String("groupName-b")},
+		},
+		NextToken: aws.String("next"),
+	}, nil)
+
+	gs.api.On(
+		"DescribeLogGroupsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{
+			LogGroupNamePrefix: aws.String(gs.groupName),
+			NextToken:          aws.String("next"),
+		},
+		[]request.Option(nil),
+	).Twice().Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{
+			{LogGroupName: aws.String("groupName-c")},
+			{LogGroupName: aws.String(gs.groupName), StoredBytes: aws.Int64(1024)},
+		},
+	}, nil)
+
+	group, err := gs.sut.Describe(gs.ctx)
+	gs.Require().NoError(err)
+	gs.Equal(gs.groupName, aws.StringValue(group.LogGroupName))
+	gs.Equal(int64(1024), aws.Int64Value(group.StoredBytes))
+
+	// The result is cached until it expires.
+	group.StoredBytes = nil
+	group, err = gs.sut.Describe(gs.ctx)
+	gs.Require().NoError(err)
+	gs.Equal(int64(1024), aws.Int64Value(group.StoredBytes))
+	gs.api.AssertNumberOfCalls(gs.T(), "DescribeLogGroupsWithContext", 2)
+
+	gs.sut.(*groupImpl).describeCache.expiresAt = time.Now()
+	gs.api.On(
+		"DescribeLogGroupsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeLogGroupsOutput{NextToken: aws.String("next")}, nil)
+
+	_, err = gs.sut.Describe(gs.ctx)
+	gs.Require().NoError(err)
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestDescribe_NotFound() {
+	gs.api.On(
+		"DescribeLogGroupsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{{LogGroupName: aws.String("groupNameOther")}},
+	}, nil)
+
+	_, err := gs.sut.Describe(gs.ctx)
+	gs.Equal(ErrNotFound, err)
+}
+
+func (gs *groupTestSuite) TestSubscriptions() {
+	gs.api.On(
+		"PutSubscriptionFilterWithContext",
+		gs.ctx,
+		&cloudwatchlogs.PutSubscriptionFilterInput{
+			DestinationArn: aws.String("arn:aws:kinesis:eu-west-1:123456789012:stream/logs"),
+			Distribution:   aws.String(cloudwatchlogs.DistributionRandom),
+			FilterName:     aws.String("errors"),
+			FilterPattern:  aws.String("ERROR"),
+			LogGroupName:   aws.String(gs.groupName),
+			RoleArn:        aws.String("arn:aws:iam::123456789012:role/logs"),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutSubscriptionFilterOutput{}, nil)
+
+	gs.api.On(
+		"DeleteSubscriptionFilterWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DeleteSubscriptionFilterInput{
+			FilterName:   aws.String("errors"),
+			LogGroupName: aws.String(gs.groupName),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DeleteSubscriptionFilterOutput{}, nil)
+
+	gs.api.On(
+		"DescribeSubscriptionFiltersWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeSubscriptionFiltersInput{LogGroupName: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeSubscriptionFiltersOutput{
+		SubscriptionFilters: []*cloudwatchlogs.SubscriptionFilter{{FilterName: aws.String("one")}},
+		NextToken:           aws.String("page2"),
+	}, nil)
+
+	gs.api.On(
+		"DescribeSubscriptionFiltersWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeSubscriptionFiltersInput{
+			LogGroupName: aws.String(gs.groupName),
+			NextToken:    aws.String("page2"),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeSubscriptionFiltersOutput{
+		SubscriptionFilters: []*cloudwatchlogs.SubscriptionFilter{{FilterName: aws.String("two")}},
+	}, nil)
+
+	gs.NoError(gs.sut.PutSubscription(gs.ctx, SubscriptionFilterOptions{
+		DestinationArn: "arn:aws:kinesis:eu-west-1:123456789012:stream/logs",
+		Distribution:   cloudwatchlogs.DistributionRandom,
+		FilterName:     "errors",
+		FilterPattern:  "ERROR",
+		RoleArn:        "arn:aws:iam::123456789012:role/logs",
+	}))
+	gs.NoError(gs.sut.DeleteSubscription(gs.ctx, "errors"))
+
+	filters, err := gs.sut.ListSubscriptions(gs.ctx)
+	gs.NoError(err)
+	gs.Equal([]cloudwatchlogs.SubscriptionFilter{
+		{FilterName: aws.String("one")},
+		{FilterName: aws.String("two")},
+	}, filters)
+
+	gs.api.AssertExpectations(gs.T())
 }
+
+func (gs *groupTestSuite) TestDeleteSubscription_NotFound() {
+	gs.api.On(
+		"DeleteSubscriptionFilterWithContext",
+		gs.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.DeleteSubscriptionFilterInput"),
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DeleteSubscriptionFilterOutput{}, new(cloudwatchlogs.ResourceNotFoundException))
+
+	gs.Equal(ErrNotFound, gs.sut.DeleteSubscription(gs.ctx, "errors"))
+}
+
+func (gs *groupTestSuite) TestMetricFilters() {
+	transformations := []*cloudwatchlogs.MetricTransformation{{
+		MetricName:      aws.String("Errors"),
+		MetricNamespace: aws.String("App"),
+		MetricValue:     aws.String("1"),
+	}}
+
+	gs.api.On(
+		"PutMetricFilterWithContext",
+		gs.ctx,
+		&cloudwatchlogs.PutMetricFilterInput{
+			FilterName:            aws.String("errors"),
+			FilterPattern:         aws.String("ERROR"),
+			LogGroupName:          aws.String(gs.groupName),
+			MetricTransformations: transformations,
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutMetricFilterOutput{}, nil)
+
+	gs.api.On(
+		"DeleteMetricFilterWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DeleteMetricFilterInput{
+			FilterName:   aws.String("errors"),
+			LogGroupName: aws.String(gs.groupName),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DeleteMetricFilterOutput{}, nil)
+
+	gs.api.On(
+		"DescribeMetricFiltersWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeMetricFiltersInput{LogGroupName: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeMetricFiltersOutput{
+		MetricFilters: []*cloudwatchlogs.MetricFilter{{FilterName: aws.String("one")}},
+		NextToken:     aws.String("page2"),
+	}, nil)
+
+	gs.api.On(
+		"DescribeMetricFiltersWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeMetricFiltersInput{
+			LogGroupName: aws.String(gs.groupName),
+			NextToken:    aws.String("page2"),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeMetricFiltersOutput{
+		MetricFilters: []*cloudwatchlogs.MetricFilter{{FilterName: aws.String("two")}},
+	}, nil)
+
+	gs.NoError(gs.sut.SetMetricFilter(gs.ctx, MetricFilterOptions{
+		FilterName:            "errors",
+		FilterPattern:         "ERROR",
+		MetricTransformations: transformations,
+	}))
+	gs.NoError(gs.sut.RemoveMetricFilter(gs.ctx, "errors"))
+
+	filters, err := gs.sut.ListMetricFilters(gs.ctx)
+	gs.NoError(err)
+	gs.Equal([]cloudwatchlogs.MetricFilter{
+		{FilterName: aws.String("one")},
+		{FilterName: aws.String("two")},
+	}, filters)
+
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestRemoveMetricFilter_NotFound() {
+	gs.api.On(
+		"DeleteMetricFilterWithContext",
+		gs.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.DeleteMetricFilterInput"),
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DeleteMetricFilterOutput{}, new(cloudwatchlogs.ResourceNotFoundException))
+
+	gs.Equal(ErrNotFound, gs.sut.RemoveMetricFilter(gs.ctx, "errors"))
+}
+
+func (gs *groupTestSuite) TestMatchMetricFilter() {
+	lines := []string{"ERROR one", "INFO two", "ERROR three"}
+
+	gs.api.On(
+		"TestMetricFilterWithContext",
+		gs.ctx,
+		&cloudwatchlogs.TestMetricFilterInput{
+			FilterPattern:    aws.String("ERROR"),
+			LogEventMessages: aws.StringSlice(lines),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.TestMetricFilterOutput{
+		Matches: []*cloudwatchlogs.MetricFilterMatchRecord{
+			{EventNumber: aws.Int64(1), EventMessage: aws.String("ERROR one")},
+			{EventNumber: aws.Int64(3), EventMessage: aws.String("ERROR three")},
+		},
+	}, nil)
+
+	matches, err := gs.sut.MatchMetricFilter(gs.ctx, "ERROR", lines)
+	gs.Require().NoError(err)
+	gs.Equal([]cloudwatchlogs.MetricFilterMatchRecord{
+		{EventNumber: aws.Int64(1), EventMessage: aws.String("ERROR one")},
+		{EventNumber: aws.Int64(3), EventMessage: aws.String("ERROR three")},
+	}, matches)
+
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestMatchMetricFilter_NoMatches() {
+	gs.api.On(
+		"TestMetricFilterWithContext",
+		gs.ctx,
+		&cloudwatchlogs.TestMetricFilterInput{
+			FilterPattern:    aws.String("ERROR"),
+			LogEventMessages: aws.StringSlice([]string{"INFO one"}),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.TestMetricFilterOutput{}, nil)
+
+	matches, err := gs.sut.MatchMetricFilter(gs.ctx, "ERROR", []string{"INFO one"})
+	gs.Require().NoError(err)
+	gs.Empty(matches)
+}
+
+func (gs *groupTestSuite) TestMatchMetricFilter_Error() {
+	gs.api.On(
+		"TestMetricFilterWithContext",
+		gs.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.TestMetricFilterInput"),
+		[]request.Option(nil),
+	).Once().Return((*cloudwatchlogs.TestMetricFilterOutput)(nil), new(cloudwatchlogs.InvalidParameterException))
+
+	_, err := gs.sut.MatchMetricFilter(gs.ctx, "{", []string{"INFO one"})
+	gs.Error(err)
+}
+
+const samplePolicy = `{
+	"Name": "data-protection-policy",
+	"Version": "2021-06-01",
+	"Statement": [
+		{
+			"Sid": "audit-policy",
+			"DataIdentifier": ["arn:aws:dataprotection::aws:data-identifier/EmailAddress"],
+			"Operation": {"Audit": {"FindingsDestination": {}}}
+		},
+		{
+			"Sid": "redact-policy",
+			"DataIdentifier": ["arn:aws:dataprotection::aws:data-identifier/EmailAddress"],
+			"Operation": {"Deidentify": {"MaskConfig": {}}}
+		}
+	]
+}`
+
+func (gs *groupTestSuite) TestDataProtectionPolicy() {
+	gs.api.On(
+		"PutDataProtectionPolicyWithContext",
+		gs.ctx,
+		&cloudwatchlogs.PutDataProtectionPolicyInput{
+			LogGroupIdentifier: aws.String(gs.groupName),
+			PolicyDocument:     aws.String(samplePolicy),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutDataProtectionPolicyOutput{}, nil)
+
+	gs.api.On(
+		"GetDataProtectionPolicyWithContext",
+		gs.ctx,
+		&cloudwatchlogs.GetDataProtectionPolicyInput{LogGroupIdentifier: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetDataProtectionPolicyOutput{PolicyDocument: aws.String(samplePolicy)}, nil)
+
+	gs.api.On(
+		"DeleteDataProtectionPolicyWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DeleteDataProtectionPolicyInput{LogGroupIdentifier: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DeleteDataProtectionPolicyOutput{}, nil)
+
+	gs.NoError(gs.sut.SetDataProtectionPolicy(gs.ctx, samplePolicy))
+
+	policy, err := gs.sut.DataProtectionPolicy(gs.ctx)
+	gs.NoError(err)
+	gs.Equal(samplePolicy, policy)
+
+	gs.NoError(gs.sut.RemoveDataProtectionPolicy(gs.ctx))
+
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestSetDataProtectionPolicy_InvalidJSON() {
+	err := gs.sut.SetDataProtectionPolicy(gs.ctx, `{"Name": "data-protection-policy",`)
+	gs.EqualError(err, "the data protection policy document isn't valid JSON")
+	gs.api.AssertNotCalled(gs.T(), "PutDataProtectionPolicyWithContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (gs *groupTestSuite) TestDataProtectionPolicy_NotFound() {
+	gs.api.On(
+		"GetDataProtectionPolicyWithContext",
+		gs.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.GetDataProtectionPolicyInput"),
+		[]request.Option(nil),
+	).Once().Return((*cloudwatchlogs.GetDataProtectionPolicyOutput)(nil), new(cloudwatchlogs.ResourceNotFoundException))
+
+	gs.api.On(
+		"DeleteDataProtectionPolicyWithContext",
+		gs.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.DeleteDataProtectionPolicyInput"),
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DeleteDataProtectionPolicyOutput{}, new(cloudwatchlogs.ResourceNotFoundException))
+
+	_, err := gs.sut.DataProtectionPolicy(gs.ctx)
+	gs.Equal(ErrNotFound, err)
+	gs.Equal(ErrNotFound, gs.sut.RemoveDataProtectionPolicy(gs.ctx))
+}
+
+func (gs *groupTestSuite) TestLiveTail() {
+	ctx, cancel := context.WithCancel(gs.ctx)
+	defer cancel()
+
+	gs.describingGroupReturns(ctx, "arn:aws:logs:eu-west-1:123456789012:log-group:groupName")
+
+	update := &cloudwatchlogs.LiveTailSessionUpdate{
+		SessionResults: []*cloudwatchlogs.LiveTailSessionLogEvent{
+			{Message: aws.String("one")},
+			{Message: aws.String("two")},
+		},
+	}
+	gs.api.On(
+		"StartLiveTailWithContext",
+		ctx,
+		&cloudwatchlogs.StartLiveTailInput{
+			LogGroupIdentifiers:   aws.StringSlice([]string{"arn:aws:logs:eu-west-1:123456789012:log-group:groupName"}),
+			LogStreamNames:        aws.StringSlice([]string{"streamName"}),
+			LogEventFilterPattern: aws.String("ERROR"),
+		},
+		[]request.Option(nil),
+	).Once().Return(newLiveTailOutput(nil, new(cloudwatchlogs.LiveTailSessionStart), update), nil)
+
+	events, errs := gs.sut.LiveTail(ctx, []string{"streamName"}, "ERROR")
+
+	gs.Equal("one", *(<-events).Message)
+	gs.Equal("two", *(<-events).Message)
+
+	cancel()
+
+	_, ok := <-events
+	gs.False(ok)
+	gs.NoError(<-errs)
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestLiveTail_Reconnect() {
+	gs.describingGroupReturns(gs.ctx, "arn")
+
+	timeout := new(cloudwatchlogs.SessionTimeoutException)
+	gs.api.On(
+		"StartLiveTailWithContext",
+		gs.ctx,
+		&cloudwatchlogs.StartLiveTailInput{LogGroupIdentifiers: aws.StringSlice([]string{"arn"})},
+		[]request.Option(nil),
+	).Twice().Return(newLiveTailOutput(timeout), nil)
+
+	events, errs := gs.sut.LiveTail(gs.ctx, nil, "", WithMaxReconnects(1))
+
+	_, ok := <-events
+	gs.False(ok)
+	gs.Equal(timeout, <-errs)
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) describingGroupReturns(ctx context.Context, arn string) {
+	gs.api.On(
+		"DescribeLogGroupsWithContext",
+		ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{
+			{LogGroupName: aws.String(gs.groupName), Arn: aws.String(arn + ":*")},
+		},
+	}, nil)
+}
+
+func (gs *groupTestSuite) TestExportToS3() {
+	gs.api.On(
+		"CreateExportTaskWithContext",
+		gs.ctx,
+		&cloudwatchlogs.CreateExportTaskInput{
+			Destination:         aws.String("bucket"),
+			DestinationPrefix:   aws.String("2020/01"),
+			From:                aws.Int64(1000),
+			LogGroupName:        aws.String(gs.groupName),
+			LogStreamNamePrefix: aws.String("web-"),
+			To:                  aws.Int64(2000),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.CreateExportTaskOutput{TaskId: aws.String("taskID")}, nil)
+
+	gs.describingExportTaskReturns(cloudwatchlogs.ExportTaskStatusCodeRunning).Once()
+	gs.describingExportTaskReturns(cloudwatchlogs.ExportTaskStatusCodeCompleted).Once()
+
+	task, err := gs.sut.ExportToS3(gs.ctx, ExportOptions{
+		Destination:       "bucket",
+		DestinationPrefix: "2020/01",
+		From:              time.Unix(1, 0),
+		To:                time.Unix(2, 0),
+		StreamName:        "web-",
+	})
+
+	gs.Require().NoError(err)
+	gs.Equal(cloudwatchlogs.ExportTaskStatusCodeCompleted, *task.Status.Code)
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestExportToS3_Failed() {
+	gs.api.On(
+		"CreateExportTaskWithContext",
+		gs.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.CreateExportTaskInput"),
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.CreateExportTaskOutput{TaskId: aws.String("taskID")}, nil)
+
+	gs.describingExportTaskReturns(cloudwatchlogs.ExportTaskStatusCodeFailed).Once()
+
+	task, err := gs.sut.ExportToS3(gs.ctx, ExportOptions{Destination: "bucket"})
+
+	gs.EqualError(err, "export task taskID ended with status FAILED: bacon")
+	gs.Require().NotNil(task)
+	gs.Equal("bacon", *task.Status.Message)
+}
+
+func (gs *groupTestSuite) describingExportTaskReturns(code string) *mock.Call {
+	return gs.api.On(
+		"DescribeExportTasksWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeExportTasksInput{TaskId: aws.String("taskID")},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.DescribeExportTasksOutput{
+		ExportTasks: []*cloudwatchlogs.ExportTask{{
+			TaskId: aws.String("taskID"),
+			Status: &cloudwatchlogs.ExportTaskStatus{Code: aws.String(code), Message: aws.String("bacon")},
+		}},
+	}, nil)
+}
+
+func (gs *groupTestSuite) TestEvents() {
+	ctx, cancel := context.WithCancel(gs.ctx)
+	defer cancel()
+
+	expected := &cloudwatchlogs.OutputLogEvent{
+		Message:   aws.String("Hello"),
+		Timestamp: aws.Int64(1000),
+	}
+
+	gs.api.On(
+		"GetLogEventsWithContext",
+		ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(gs.groupName),
+			LogStreamName: aws.String(gs.streamName),
+			StartFromHead: aws.Bool(true),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events:           []*cloudwatchlogs.OutputLogEvent{expected},
+		NextForwardToken: aws.String("next"),
+	}, nil)
+
+	gs.api.On(
+		"GetLogEventsWithContext",
+		ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(gs.groupName),
+			LogStreamName: aws.String(gs.streamName),
+			StartFromHead: aws.Bool(true),
+			NextToken:     aws.String("next"),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.GetLogEventsOutput{}, nil)
+
+	events, errs := gs.sut.Events(ctx, gs.streamName)
+
+	gs.Equal(expected, <-events)
+
+	cancel()
+
+	for range events {
+	}
+	gs.NoError(<-errs)
+}
+
+func (gs *groupTestSuite) TestEventsError() {
+	gs.api.On(
+		"GetLogEventsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(gs.groupName),
+			LogStreamName: aws.String(gs.streamName),
+			StartFromHead: aws.Bool(true),
+		},
+		[]request.Option(nil),
+	).Return((*cloudwatchlogs.GetLogEventsOutput)(nil), errors.New("bacon"))
+
+	events, errs := gs.sut.Events(gs.ctx, gs.streamName)
+
+	_, ok := <-events
+	gs.False(ok)
+	gs.EqualError(<-errs, "bacon")
+}
+
+func (gs *groupTestSuite) TestOpenMany() {
+	gs.gettingLogEventsReturns("a", nil, []int64{1, 3}, "a1")
+	gs.gettingLogEventsReturns("a", aws.String("a1"), nil, "a1")
+	gs.gettingLogEventsReturns("b", nil, []int64{2}, "b1")
+	gs.gettingLogEventsReturns("b", aws.String("b1"), []int64{4}, "b2")
+	gs.gettingLogEventsReturns("b", aws.String("b2"), nil, "b2")
+
+	reader := gs.sut.OpenMany(gs.ctx, []string{"a", "b"})
+	defer reader.Close()
+
+	var out bytes.Buffer
+	buffer := make([]byte, 100)
+	for {
+		n, err := reader.Read(buffer)
+		out.Write(buffer[:n])
+		if err == io.EOF {
+			break
+		}
+		gs.Require().NoError(err)
+		time.Sleep(time.Millisecond)
+	}
+
+	gs.Equal("a1b2a3b4", out.String())
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestOpenMany_MergesAcrossPages() {
+	// b's second page holds an event older than the last one of a's first page.
+	gs.gettingLogEventsReturns("a", nil, []int64{1, 5}, "a1")
+	gs.gettingLogEventsReturns("a", aws.String("a1"), nil, "a1")
+	gs.gettingLogEventsReturns("b", nil, []int64{2}, "b1")
+	gs.gettingLogEventsReturns("b", aws.String("b1"), []int64{3}, "b2")
+	gs.gettingLogEventsReturns("b", aws.String("b2"), nil, "b2")
+
+	reader := gs.sut.OpenMany(gs.ctx, []string{"a", "b"})
+	defer reader.Close()
+
+	var out bytes.Buffer
+	buffer := make([]byte, 100)
+	for {
+		n, err := reader.Read(buffer)
+		out.Write(buffer[:n])
+		if err == io.EOF {
+			break
+		}
+		gs.Require().NoError(err)
+		time.Sleep(time.Millisecond)
+	}
+
+	gs.Equal("a1b2b3a5", out.String())
+	gs.api.AssertExpectations(gs.T())
+}
+
+// gettingLogEventsReturns mocks a GetLogEvents call on streamName returning
+// events with the given timestamps, whose messages are the stream name
+// followed by the timestamp.
+func (gs *groupTestSuite) gettingLogEventsReturns(streamName string, token *string, timestamps []int64, nextToken string) {
+	var events []*cloudwatchlogs.OutputLogEvent
+	for _, ts := range timestamps {
+		events = append(events, &cloudwatchlogs.OutputLogEvent{
+			Message:   aws.String(fmt.Sprintf("%s%d", streamName, ts)),
+			Timestamp: aws.Int64(ts),
+		})
+	}
+
+	gs.api.On(
+		"GetLogEventsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(gs.groupName),
+			LogStreamName: aws.String(streamName),
+			StartFromHead: aws.Bool(true),
+			NextToken:     token,
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events:           events,
+		NextForwardToken: aws.String(nextToken),
+	}, nil)
+}
+
+func (gs *groupTestSuite) TestListStreams() {
+	gs.listingStreamsReturns("web-", nil, []string{"web-1", "web-2"}, aws.String("page2"), nil)
+	gs.listingStreamsReturns("web-", aws.String("page2"), nil, aws.String("page3"), nil)
+	gs.listingStreamsReturns("web-", aws.String("page3"), []string{"web-3"}, nil, nil)
+
+	names, err := gs.sut.ListStreams(gs.ctx, "web-")
+
+	gs.NoError(err)
+	gs.Equal([]string{"web-1", "web-2", "web-3"}, names)
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestStreamExists() {
+	gs.listingStreamsReturns("web", nil, []string{"web-1", "web-2"}, aws.String("page2"), nil)
+	gs.listingStreamsReturns("web", aws.String("page2"), []string{"web"}, nil, nil)
+
+	exists, err := gs.sut.StreamExists(gs.ctx, "web")
+
+	gs.NoError(err)
+	gs.True(exists)
+}
+
+func (gs *groupTestSuite) TestStreamExists_NotFound() {
+	gs.listingStreamsReturns("web", nil, []string{"web-1"}, nil, nil)
+
+	exists, err := gs.sut.StreamExists(gs.ctx, "web")
+
+	gs.NoError(err)
+	gs.False(exists)
+}
+
+func (gs *groupTestSuite) TestWaitForStream() {
+	gs.listingStreamsReturns("web", nil, nil, nil, nil)
+	gs.listingStreamsReturns("web", nil, []string{"web"}, nil, nil)
+
+	gs.NoError(gs.sut.WaitForStream(gs.ctx, "web", time.Millisecond))
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestWaitForStream_ContextDone() {
+	ctx, cancel := context.WithTimeout(gs.ctx, 10*time.Millisecond)
+	defer cancel()
+
+	gs.api.On(
+		"DescribeLogStreamsWithContext",
+		ctx,
+		&cloudwatchlogs.DescribeLogStreamsInput{
+			LogGroupName:        aws.String(gs.groupName),
+			LogStreamNamePrefix: aws.String("web"),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.DescribeLogStreamsOutput{}, nil)
+
+	gs.Equal(context.DeadlineExceeded, gs.sut.WaitForStream(ctx, "web", time.Millisecond))
+}
+
+func (gs *groupTestSuite) TestGroupExists() {
+	gs.api.On(
+		"DescribeLogGroupsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(gs.groupName)},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{{LogGroupName: aws.String(gs.groupName + "-staging")}},
+		NextToken: aws.String("page2"),
+	}, nil)
+	gs.api.On(
+		"DescribeLogGroupsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(gs.groupName), NextToken: aws.String("page2")},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{{LogGroupName: aws.String(gs.groupName)}},
+	}, nil)
+
+	exists, err := GroupExists(gs.ctx, gs.api, gs.groupName)
+
+	gs.NoError(err)
+	gs.True(exists)
+}
+
+func (gs *groupTestSuite) TestStreamIterator_Error() {
+	gs.listingStreamsReturns("", nil, []string{"one"}, aws.String("page2"), nil)
+	gs.listingStreamsReturns("", aws.String("page2"), nil, nil, errors.New("bacon"))
+
+	it := gs.sut.StreamIterator(gs.ctx, "")
+
+	name, ok := it.Next()
+	gs.True(ok)
+	gs.Equal("one", name)
+
+	_, ok = it.Next()
+	gs.False(ok)
+	gs.EqualError(it.Err(), "couldn't list log streams: bacon")
+}
+
+func (gs *groupTestSuite) TestFilter() {
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName:   aws.String(gs.groupName),
+		StartTime:      aws.Int64(1000),
+		FilterPattern:  aws.String("ERROR"),
+		LogStreamNames: aws.StringSlice([]string{"web-1", "web-2"}),
+	}
+	gs.filteringEventsReturns(gs.ctx, input, nil, []string{"ERROR one", "ERROR two"}, aws.String("page2"))
+	gs.filteringEventsReturns(gs.ctx, input, aws.String("page2"), nil, aws.String("page3"))
+	gs.filteringEventsReturns(gs.ctx, input, aws.String("page3"), []string{"ERROR three"}, nil)
+
+	it := gs.sut.Filter(gs.ctx, FilterOptions{
+		StartTime:     time.Unix(1, 0),
+		FilterPattern: "ERROR",
+		StreamNames:   []string{"web-1", "web-2"},
+	})
+
+	var messages []string
+	for event, ok := it.Next(); ok; event, ok = it.Next() {
+		messages = append(messages, *event.Message)
+	}
+
+	gs.NoError(it.Err())
+	gs.Equal([]string{"ERROR one", "ERROR two", "ERROR three"}, messages)
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestFilter_Limit() {
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(gs.groupName),
+		Limit:        aws.Int64(3),
+	}
+	gs.filteringEventsReturns(gs.ctx, input, nil, []string{"one", "two"}, aws.String("page2"))
+
+	input = &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(gs.groupName),
+		Limit:        aws.Int64(1),
+	}
+	gs.filteringEventsReturns(gs.ctx, input, aws.String("page2"), []string{"three", "four"}, aws.String("page3"))
+
+	it := gs.sut.Filter(gs.ctx, FilterOptions{Limit: 3})
+
+	var count int
+	for _, ok := it.Next(); ok; _, ok = it.Next() {
+		count++
+	}
+
+	gs.NoError(it.Err())
+	gs.Equal(3, count)
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) TestFilter_ContextCancelled() {
+	ctx, cancel := context.WithCancel(gs.ctx)
+	defer cancel()
+
+	input := &cloudwatchlogs.FilterLogEventsInput{LogGroupName: aws.String(gs.groupName)}
+	gs.filteringEventsReturns(ctx, input, nil, []string{"one"}, aws.String("page2"))
+
+	it := gs.sut.Filter(ctx, FilterOptions{})
+
+	_, ok := it.Next()
+	gs.True(ok)
+
+	cancel()
+
+	_, ok = it.Next()
+	gs.False(ok)
+	gs.Equal(context.Canceled, it.Err())
+	gs.api.AssertExpectations(gs.T())
+}
+
+func (gs *groupTestSuite) filteringEventsReturns(ctx context.Context, input *cloudwatchlogs.FilterLogEventsInput, token *string, messages []string, nextToken *string) {
+	page := *input
+	page.NextToken = token
+
+	var events []*cloudwatchlogs.FilteredLogEvent
+	for _, message := range messages {
+		events = append(events, &cloudwatchlogs.FilteredLogEvent{Message: aws.String(message)})
+	}
+
+	gs.api.On(
+		"FilterLogEventsWithContext",
+		ctx,
+		&page,
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.FilterLogEventsOutput{
+		Events:    events,
+		NextToken: nextToken,
+	}, nil)
+}
+
+func (gs *groupTestSuite) listingStreamsReturns(prefix string, token *string, names []string, nextToken *string, err error) {
+	input := &cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName: aws.String(gs.groupName),
+		NextToken:    token,
+	}
+	if prefix != "" {
+		input.LogStreamNamePrefix = aws.String(prefix)
+	}
+
+	var streams []*cloudwatchlogs.LogStream
+	for _, name := range names {
+		streams = append(streams, &cloudwatchlogs.LogStream{LogStreamName: aws.String(name)})
+	}
+
+	gs.api.On(
+		"DescribeLogStreamsWithContext",
+		gs.ctx,
+		input,
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.DescribeLogStreamsOutput{
+		LogStreams: streams,
+		NextToken:  nextToken,
+	}, err)
+}
+
+func (gs *groupTestSuite) describingStreamsReturns(result []*cloudwatchlogs.LogStream, err error) {
+	gs.api.On(
+		"DescribeLogStreamsWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DescribeLogStreamsInput{
+			LogGroupName:        aws.String(gs.groupName),
+			LogStreamNamePrefix: aws.String(gs.streamName),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.DescribeLogStreamsOutput{LogStreams: result}, err)
+}
+
+func (gs *groupTestSuite) deletingStreamReturns(err error) {
+	gs.api.On(
+		"DeleteLogStreamWithContext",
+		gs.ctx,
+		&cloudwatchlogs.DeleteLogStreamInput{
+			LogGroupName:  aws.String(gs.groupName),
+			LogStreamName: aws.String(gs.streamName),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.DeleteLogStreamOutput{}, err)
+}
+
+func (gs *groupTestSuite) TestReadersShareRateLimit() {
+	gs.api.On(
+		"GetLogEventsWithContext",
+		gs.ctx,
+		mock.Anything,
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.GetLogEventsOutput{}, nil)
+
+	group := NewGroup(gs.api, gs.groupName, WithReadRateLimit(20)).(*groupImpl)
+
+	first := group.newReader(gs.ctx, "first")
+	defer first.Close()
+	second := group.newReader(gs.ctx, "second")
+	defer second.Close()
+
+	gs.Same(first.limiter, second.limiter)
+
+	// Requests of both readers are spaced by 50ms.
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		gs.NoError(first.read())
+		gs.NoError(second.read())
+	}
+	gs.GreaterOrEqual(int64(time.Since(start)), int64(250*time.Millisecond))
+}
+
+func (gs *groupTestSuite) TestGroupsShareAccountReadLimit() {
+	limit := NewAccountReadLimit(0)
+	gs.Equal(rate.Every(readThrottle), limit.limiter.Limit())
+
+	first := NewGroup(gs.api, "first", WithAccountReadLimit(limit)).(*groupImpl)
+	second := NewGroup(gs.api, "second", WithAccountReadLimit(limit)).(*groupImpl)
+	gs.Same(first.readLimiter, second.readLimiter)
+
+	// Groups don't share a limit unless they're given one.
+	other := NewGroup(gs.api, "other").(*groupImpl)
+	gs.NotSame(first.readLimiter, other.readLimiter)
+	gs.Equal(rate.Every(readThrottle), other.readLimiter.Limit())
+}
+
+func (gs *groupTestSuite) TestWriteRateLimit() {
+	group := NewGroup(gs.api, gs.groupName, WithWriteRateLimit(2)).(*groupImpl)
+	gs.Equal(500*time.Millisecond, group.writeInterval)
+
+	// Rates above the CloudWatch Logs limit are lowered to it.
+	group = NewGroup(gs.api, gs.groupName, WithWriteRateLimit(1000)).(*groupImpl)
+	gs.Equal(writeThrottle, group.writeInterval)
+
+	group = NewGroup(gs.api, gs.groupName, WithWriteRateLimit(-1)).(*groupImpl)
+	gs.Equal(writeThrottle, group.writeInterval)
+}
+
+func (gs *groupTestSuite) TestGroupOptions() {
+	gs.creatingLogStreamReturns(nil)
+	gs.api.On(
+		"GetLogEventsWithContext",
+		gs.ctx,
+		mock.Anything,
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+		},
+	}, nil)
+
+	metrics := new(groupMetrics)
+	logger := new(recordingLogger)
+	group := NewGroup(
+		gs.api,
+		gs.groupName,
+		WithWriteRateLimit(2),
+		WithGroupMetrics(metrics),
+		WithGroupLogger(logger),
+	).(*groupImpl)
+
+	writer, err := group.create(gs.ctx, gs.streamName)
+	gs.Require().NoError(err)
+	gs.Equal(500*time.Millisecond, writer.flushInterval)
+	gs.Equal(metrics, writer.metrics)
+
+	writer.dropEvents([]*cloudwatchlogs.InputLogEvent{{}}, errors.New("boom"))
+	gs.Equal([]string{
+		"info: created log stream stream=streamName",
+		"warn: dropping log events stream=streamName events=1 error=boom",
+	}, logger.lines)
+
+	reader := group.newReader(gs.ctx, gs.streamName)
+	defer reader.Close()
+	gs.NoError(reader.read())
+	gs.Equal([]int{1}, metrics.reads)
+}
+
+func (gs *groupTestSuite) creatingLogStreamReturns(err error) {
+	gs.api.On(
+		"CreateLogStreamWithContext",
+		gs.ctx,
+		&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(gs.groupName),
+			LogStreamName: aws.String(gs.streamName),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.CreateLogStreamOutput{}, err)
+}
+
+// groupMetrics records the number of events read by each request.
+type groupMetrics struct {
+	mu    sync.Mutex
+	reads []int
+}
+
+func (m *groupMetrics) ObserveFlush(time.Duration, int, int, error) {}
+
+func (m *groupMetrics) SetBufferSize(int) {}
+
+func (m *groupMetrics) ObserveRead(_ time.Duration, events int, _ error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reads = append(m.reads, events)
+}
+
+// recordingLogger records the lines logged, without the prefix.
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) record(level, msg string, fields []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, strings.TrimPrefix(formatLog(level, msg, fields), "cloudwatch: "))
+}
+
+func (l *recordingLogger) Info(msg string, fields ...interface{})  { l.record("info", msg, fields) }
+func (l *recordingLogger) Warn(msg string, fields ...interface{})  { l.record("warn", msg, fields) }
+func (l *recordingLogger) Error(msg string, fields ...interface{}) { l.record("error", msg, fields) }
+
+func TestStdGroupLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	flags := log.Flags()
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(flags)
+	}()
+
+	StdGroupLogger{}.Warn("retrying throttled request", "attempt", 2, "delay", time.Second)
+
+	require.Equal(t, "cloudwatch: warn: retrying throttled request attempt=2 delay=1s\n", buf.String())
+}
+
+func TestDefaultGroupLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	flags := log.Flags()
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(flags)
+	}()
+
+	// Only warnings and errors are logged by default.
+	NewGroup(new(mockAPI), "groupName", WithEndpointURL("localhost:4566"))
+	defaultGroupLogger{}.Info("updated sequence token")
+
+	require.Equal(t, "cloudwatch: error: could not set up the group group=groupName error=invalid endpoint URL \"localhost:4566\": missing scheme or host\n", buf.String())
+}
+
+func TestNewGroupFromConfig(t *testing.T) {
+	group, err := NewGroupFromConfig(aws.NewConfig().WithRegion("eu-west-1"), "groupName")
+
+	require.NoError(t, err)
+	require.Equal(t, "groupName", group.Name())
+
+	_, ok := group.(*groupImpl).CloudWatchLogsAPI.(*cloudwatchlogs.CloudWatchLogs)
+	require.True(t, ok)
+}
+
+func TestNewGroupFromConfig_HTTPClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+	transport := new(http.Transport)
+
+	// The client is a copy, since the SDK may modify it, for example to load
+	// a custom CA bundle.
+	group, err := NewGroupFromConfig(aws.NewConfig().WithRegion("eu-west-1"), "groupName", WithHTTPClient(httpClient))
+	require.NoError(t, err)
+	got := group.(*groupImpl).CloudWatchLogsAPI.(*cloudwatchlogs.CloudWatchLogs).Config.HTTPClient
+	require.NotSame(t, httpClient, got)
+	require.Equal(t, time.Minute, got.Timeout)
+	require.Nil(t, httpClient.Transport)
+
+	// Only the transport is replaced, and httpClient isn't modified.
+	group, err = NewGroupFromConfig(aws.NewConfig().WithRegion("eu-west-1"), "groupName", WithHTTPClient(httpClient), WithHTTPTransport(transport))
+	require.NoError(t, err)
+	got = group.(*groupImpl).CloudWatchLogsAPI.(*cloudwatchlogs.CloudWatchLogs).Config.HTTPClient
+	require.Equal(t, transport, got.Transport)
+	require.Equal(t, time.Minute, got.Timeout)
+	require.Nil(t, httpClient.Transport)
+}
+
+func TestNewGroupFromConfig_EndpointURL(t *testing.T) {
+	group, err := NewGroupFromConfig(aws.NewConfig().WithRegion("eu-west-1"), "groupName", WithEndpointURL("https://vpce-123.logs.eu-west-1.vpce.amazonaws.com"))
+	require.NoError(t, err)
+	require.Equal(t, "https://vpce-123.logs.eu-west-1.vpce.amazonaws.com", group.(*groupImpl).CloudWatchLogsAPI.(*cloudwatchlogs.CloudWatchLogs).Endpoint)
+
+	_, err = NewGroupFromConfig(aws.NewConfig().WithRegion("eu-west-1"), "groupName", WithEndpointURL("localhost:4566"))
+	require.EqualError(t, err, `invalid endpoint URL "localhost:4566": missing scheme or host`)
+}
+
+func TestNewGroup_HTTPClientRejected(t *testing.T) {
+	logger := new(recordingLogger)
+	api := new(mockAPI)
+
+	group := NewGroup(api, "groupName", WithHTTPTransport(new(http.Transport)), WithGroupLogger(logger))
+
+	require.Equal(t, unavailableClient{err: ErrClientOptions}, group.(*groupImpl).CloudWatchLogsAPI)
+	require.Equal(t, []string{
+		"error: could not set up the group group=groupName error=HTTP client and endpoint options require building the client",
+	}, logger.lines)
+
+	_, err := group.Create(context.Background(), "streamName")
+	require.Equal(t, ErrClientOptions, err)
+
+	_, err = ioutil.ReadAll(group.Open(context.Background(), "streamName"))
+	require.Equal(t, ErrClientOptions, err)
+
+	// Requests which aren't made by writers and readers fail too, rather than
+	// being sent using the client.
+	err = group.Delete(context.Background())
+	require.True(t, errors.Is(err, ErrClientOptions))
+
+	_, err = group.DescribeLogGroupsWithContext(context.Background(), &cloudwatchlogs.DescribeLogGroupsInput{})
+	require.Equal(t, ErrClientOptions, err)
+
+	req, _ := group.DescribeLogGroupsRequest(&cloudwatchlogs.DescribeLogGroupsInput{})
+	require.Equal(t, ErrClientOptions, req.Send())
+	api.AssertExpectations(t)
+}
+
+func TestNewGroup_InvalidOption(t *testing.T) {
+	group := NewGroup(new(mockAPI), "groupName", WithEndpointURL("localhost:4566"))
+
+	_, err := group.Create(context.Background(), "streamName")
+	require.EqualError(t, err, `invalid endpoint URL "localhost:4566": missing scheme or host`)
+}
+
+func TestAssumeRole(t *testing.T) {
+	stsAPI, logsAPI := new(mockSTS), new(mockAPI)
+	var logsConfig *aws.Config
+
+	origSTSClient, origLogsClient := newSTSClient, newLogsClient
+	defer func() {
+		newSTSClient, newLogsClient = origSTSClient, origLogsClient
+	}()
+	newSTSClient = func(cfg *aws.Config) (stsiface.STSAPI, error) {
+		require.Equal(t, "eu-west-1", aws.StringValue(cfg.Region))
+		require.Nil(t, cfg.Endpoint)
+		return stsAPI, nil
+	}
+	newLogsClient = func(cfg *aws.Config) (iface.CloudWatchLogsAPI, error) {
+		logsConfig = cfg
+		return logsAPI, nil
+	}
+
+	input := &sts.AssumeRoleInput{
+		DurationSeconds: aws.Int64(900),
+		RoleArn:         aws.String("arn:aws:iam::123456789012:role/logs"),
+		RoleSessionName: aws.String("app"),
+	}
+	assumingRoleReturns := func(accessKeyID string, expiration time.Time) {
+		stsAPI.On("AssumeRoleWithContext", context.Background(), input, []request.Option(nil)).Once().Return(&sts.AssumeRoleOutput{
+			Credentials: &sts.Credentials{
+				AccessKeyId:     aws.String(accessKeyID),
+				Expiration:      aws.Time(expiration),
+				SecretAccessKey: aws.String("secret"),
+				SessionToken:    aws.String("token"),
+			},
+		}, nil)
+	}
+
+	// The first credentials expire quickly, so they're refreshed when they're
+	// next needed.
+	assumingRoleReturns("first", time.Now().Add(100*time.Millisecond))
+	assumingRoleReturns("second", time.Now().Add(time.Hour))
+
+	client := cloudwatchlogs.New(session.Must(session.NewSession(aws.NewConfig().WithRegion("eu-west-1"))))
+	group := NewGroup(
+		client,
+		"groupName",
+		WithAssumeRole("arn:aws:iam::123456789012:role/logs", "app", 15*time.Minute),
+		WithEndpointURL("http://localhost:4566"),
+	)
+
+	require.Equal(t, logsAPI, group.(*groupImpl).CloudWatchLogsAPI)
+	require.Equal(t, "eu-west-1", aws.StringValue(logsConfig.Region))
+	require.Equal(t, "http://localhost:4566", aws.StringValue(logsConfig.Endpoint))
+
+	creds, err := logsConfig.Credentials.Get()
+	require.NoError(t, err)
+	require.Equal(t, "first", creds.AccessKeyID)
+	require.Equal(t, "token", creds.SessionToken)
+
+	require.Eventually(t, func() bool {
+		creds, err := logsConfig.Credentials.Get()
+		return err == nil && creds.AccessKeyID == "second"
+	}, time.Second, 10*time.Millisecond)
+	stsAPI.AssertExpectations(t)
+}
+
+func TestAssumeRole_RefreshFails(t *testing.T) {
+	stsAPI := new(mockSTS)
+	input := &sts.AssumeRoleInput{
+		RoleArn:         aws.String("arn:aws:iam::123456789012:role/logs"),
+		RoleSessionName: aws.String("app"),
+	}
+	provider := &assumeRoleProvider{client: stsAPI, input: input, logger: NoopGroupLogger{}}
+
+	stsAPI.On("AssumeRoleWithContext", context.Background(), input, []request.Option(nil)).Once().Return(&sts.AssumeRoleOutput{
+		Credentials: &sts.Credentials{
+			AccessKeyId: aws.String("first"),
+			Expiration:  aws.Time(time.Now().Add(time.Hour)),
+		},
+	}, nil)
+	stsAPI.On("AssumeRoleWithContext", context.Background(), input, []request.Option(nil)).Once().Return((*sts.AssumeRoleOutput)(nil), errors.New("bacon"))
+	require.NoError(t, provider.refresh())
+
+	// Credentials which are due to be refreshed but still valid are used
+	// until the next attempt.
+	provider.refreshAt = time.Now()
+	require.True(t, provider.IsExpired())
+	creds, err := provider.Retrieve()
+	require.NoError(t, err)
+	require.Equal(t, "first", creds.AccessKeyID)
+	require.False(t, provider.IsExpired())
+
+	// Expired ones aren't.
+	provider.current.Expiration = aws.Time(time.Now())
+	provider.refreshAt = time.Now()
+	stsAPI.On("AssumeRoleWithContext", context.Background(), input, []request.Option(nil)).Once().Return((*sts.AssumeRoleOutput)(nil), errors.New("bacon"))
+	_, err = provider.Retrieve()
+	require.EqualError(t, err, "could not assume role: bacon")
+	stsAPI.AssertExpectations(t)
+}
+
+func TestAssumeRole_ClientError(t *testing.T) {
+	origSTSClient := newSTSClient
+	defer func() {
+		newSTSClient = origSTSClient
+	}()
+	newSTSClient = func(*aws.Config) (stsiface.STSAPI, error) {
+		return nil, errors.New("bacon")
+	}
+
+	_, err := NewGroupFromConfig(aws.NewConfig().WithRegion("eu-west-1"), "groupName", WithAssumeRole("arn:aws:iam::123456789012:role/logs", "app", 0))
+	require.EqualError(t, err, "could not create an STS client to assume role arn:aws:iam::123456789012:role/logs: bacon")
+
+	// The group doesn't fall back to the credentials of the client.
+	api := new(mockAPI)
+	group := NewGroup(api, "groupName", WithAssumeRole("arn:aws:iam::123456789012:role/logs", "app", 0))
+	_, err = group.Create(context.Background(), "streamName")
+	require.EqualError(t, err, "could not create an STS client to assume role arn:aws:iam::123456789012:role/logs: bacon")
+
+	// Deleting the log group doesn't delete the one of the client's account.
+	err = group.Delete(context.Background())
+	require.EqualError(t, err, "could not delete the log group: could not create an STS client to assume role arn:aws:iam::123456789012:role/logs: bacon")
+	api.AssertExpectations(t)
+}
+
+func TestGroup(t *testing.T) {
+	suite.Run(t, new(groupTestSuite))
+}
+
+// liveTailReaders maps the sessions returned by newLiveTailOutput to their
+// readers.
+var liveTailReaders sync.Map
+
+func init() {
+	liveTailStream = func(resp *cloudwatchlogs.StartLiveTailOutput) cloudwatchlogs.StartLiveTailResponseStreamReader {
+		r, _ := liveTailReaders.Load(resp)
+		return r.(*liveTailReader)
+	}
+}
+
+// liveTailReader replays events, and ends with err if it's not nil.
+type liveTailReader struct {
+	events chan cloudwatchlogs.StartLiveTailResponseStreamEvent
+	err    error
+}
+
+// newLiveTailOutput returns a session sending events. Unless err is not nil,
+// the session stays open.
+func newLiveTailOutput(err error, events ...cloudwatchlogs.StartLiveTailResponseStreamEvent) *cloudwatchlogs.StartLiveTailOutput {
+	r := &liveTailReader{
+		events: make(chan cloudwatchlogs.StartLiveTailResponseStreamEvent, len(events)),
+		err:    err,
+	}
+	for _, event := range events {
+		r.events <- event
+	}
+	if err != nil {
+		close(r.events)
+	}
+
+	resp := new(cloudwatchlogs.StartLiveTailOutput)
+	liveTailReaders.Store(resp, r)
+	return resp
+}
+
+func (r *liveTailReader) Events() <-chan cloudwatchlogs.StartLiveTailResponseStreamEvent {
+	return r.events
+}
+
+func (r *liveTailReader) Close() error { return nil }
+
+func (r *liveTailReader) Err() error { return r.err }
@@ -0,0 +1,207 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	cloudwatch "github.com/deliveroo/cloudwatch-go"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	localstackImage = "localstack/localstack:3.7"
+	localstackPort  = "4566/tcp"
+
+	// The maximum size of the message of a log event, excluding the 26 bytes
+	// of padding counted by AWS CloudWatch Logs.
+	maxEventMessageBytes = 262144 - 26
+
+	readThrottle = time.Second / 10
+)
+
+// integrationTestSuite runs against LocalStack, which is started in Docker
+// unless LOCALSTACK_ENDPOINT points to a running instance.
+type integrationTestSuite struct {
+	suite.Suite
+	container testcontainers.Container
+	ctx       context.Context
+	cfg       *aws.Config
+	groupName string
+	sut       cloudwatch.Group
+}
+
+func (is *integrationTestSuite) SetupSuite() {
+	is.ctx = context.Background()
+
+	endpoint := os.Getenv("LOCALSTACK_ENDPOINT")
+	if endpoint == "" {
+		var err error
+		is.container, err = testcontainers.GenericContainer(is.ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:        localstackImage,
+				ExposedPorts: []string{localstackPort},
+				Env:          map[string]string{"SERVICES": "logs"},
+				WaitingFor:   wait.ForHTTP("/_localstack/health").WithPort(localstackPort),
+			},
+			Started: true,
+		})
+		is.Require().NoError(err)
+
+		endpoint, err = is.container.PortEndpoint(is.ctx, localstackPort, "http")
+		is.Require().NoError(err)
+	}
+
+	is.cfg = &aws.Config{
+		Credentials: credentials.NewStaticCredentials("test", "test", ""),
+		Endpoint:    aws.String(endpoint),
+		Region:      aws.String("us-east-1"),
+	}
+}
+
+func (is *integrationTestSuite) TearDownSuite() {
+	if is.container != nil {
+		is.NoError(is.container.Terminate(is.ctx))
+	}
+}
+
+func (is *integrationTestSuite) SetupTest() {
+	is.groupName = fmt.Sprintf("cloudwatch-go-%d", time.Now().UnixNano())
+
+	var err error
+	is.sut, err = cloudwatch.NewGroupFromConfig(is.cfg, is.groupName)
+	is.Require().NoError(err)
+}
+
+func (is *integrationTestSuite) TearDownTest() {
+	if err := is.sut.Delete(is.ctx); err != nil && err != cloudwatch.ErrNotFound {
+		is.NoError(err)
+	}
+}
+
+func (is *integrationTestSuite) TestWriteAndRead() {
+	const count = 1000
+
+	writer, err := is.sut.Create(is.ctx, "streamName", cloudwatch.WithCreateGroupIfMissing())
+	is.Require().NoError(err)
+
+	for i := 0; i < count; i++ {
+		_, err = fmt.Fprintf(writer, "event %04d\n", i)
+		is.Require().NoError(err)
+	}
+	is.Require().NoError(writer.Close())
+
+	lines := is.readLines("streamName", count)
+	is.Require().Len(lines, count)
+	for i, line := range lines {
+		is.Equal(fmt.Sprintf("event %04d", i), line)
+	}
+}
+
+func (is *integrationTestSuite) TestInvalidSequenceToken() {
+	writer, err := is.sut.Create(is.ctx, "streamName", cloudwatch.WithCreateGroupIfMissing(), cloudwatch.FromToken("invalid"))
+	is.Require().NoError(err)
+
+	_, err = io.WriteString(writer, "Hello\n")
+	is.Require().NoError(err)
+	is.Require().NoError(writer.Close())
+
+	is.Equal([]string{"Hello"}, is.readLines("streamName", 1))
+}
+
+func (is *integrationTestSuite) TestResourceNotFound() {
+	is.Equal(cloudwatch.ErrNotFound, is.sut.DeleteStream(is.ctx, "streamName"))
+
+	writer, err := is.sut.Create(is.ctx, "streamName", cloudwatch.WithCreateGroupIfMissing())
+	is.Require().NoError(err)
+
+	// The writer recreates the stream once it's been deleted.
+	is.Require().NoError(is.sut.DeleteStream(is.ctx, "streamName"))
+
+	_, err = io.WriteString(writer, "Hello\n")
+	is.Require().NoError(err)
+	is.Require().NoError(writer.Close())
+
+	is.Equal([]string{"Hello"}, is.readLines("streamName", 1))
+}
+
+func (is *integrationTestSuite) TestOversizedBatch() {
+	writer, err := is.sut.Create(
+		is.ctx,
+		"streamName",
+		cloudwatch.WithCreateGroupIfMissing(),
+		cloudwatch.WithOversizePolicy(cloudwatch.OversizePolicyError),
+	)
+	is.Require().NoError(err)
+
+	// A single event over the size limit is rejected before being sent.
+	_, err = io.WriteString(writer, strings.Repeat("x", maxEventMessageBytes+1)+"\n")
+	is.Equal(cloudwatch.ErrEventTooLarge, err)
+
+	// More than a batch's worth of events is split into several batches.
+	line := strings.Repeat("x", 100*1024)
+	for i := 0; i < 12; i++ {
+		_, err = io.WriteString(writer, line+"\n")
+		is.Require().NoError(err)
+	}
+	is.Require().NoError(writer.Close())
+
+	is.Len(is.readLines("streamName", 12), 12)
+}
+
+// readLines reads the stream until n lines have been read, or a timeout.
+func (is *integrationTestSuite) readLines(streamName string, n int) []string {
+	ctx, cancel := context.WithTimeout(is.ctx, 30*time.Second)
+	defer cancel()
+
+	reader := is.sut.Open(ctx, streamName)
+	defer reader.Close()
+
+	var ret []string
+	scanner := bufio.NewScanner(&retryingReader{ctx: ctx, r: reader})
+	scanner.Buffer(nil, maxEventMessageBytes+1)
+	for len(ret) < n && scanner.Scan() {
+		ret = append(ret, scanner.Text())
+	}
+	is.Require().NoError(scanner.Err())
+
+	return ret
+}
+
+// retryingReader retries reads returning no data until ctx is done, since a
+// reader which has not received events yet does not block.
+type retryingReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *retryingReader) Read(b []byte) (int, error) {
+	for {
+		n, err := r.r.Read(b)
+		if n > 0 || err != nil {
+			return n, err
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return 0, io.EOF
+		case <-time.After(readThrottle):
+		}
+	}
+}
+
+func TestIntegration(t *testing.T) {
+	suite.Run(t, new(integrationTestSuite))
+}
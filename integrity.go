@@ -0,0 +1,81 @@
+package cloudwatch
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+)
+
+const signatureField = " sig="
+
+// The number of bytes the signature adds to each message.
+const signatureFieldBytes = len(signatureField) + 2*sha256.Size
+
+// WithIntegrityHMAC appends " sig=<hex>" to each log event's message, where
+// hex is the HMAC-SHA256 using key of the event's timestamp in milliseconds,
+// as 8 big-endian bytes, followed by its message, so that events changed after
+// being written can be detected with VerifyEvent. The signature is the last
+// field of the message, before its trailing line break if any, and is added
+// after all other processing. Heartbeats aren't signed.
+//
+// This only provides integrity, not confidentiality: messages are still stored
+// in clear text. CloudWatch Logs isn't a tamper-proof store either, as events
+// can still be deleted, along with their stream.
+func WithIntegrityHMAC(key []byte) CreateOption {
+	return func(w *writerImpl) {
+		w.hmacKey = append([]byte(nil), key...)
+	}
+}
+
+// sign appends the signature to the message of event.
+func (w *writerImpl) sign(event *cloudwatchlogs.InputLogEvent) {
+	body, trailer := splitTrailingNewline(aws.StringValue(event.Message))
+	sig := signature(w.hmacKey, body+trailer, aws.Int64Value(event.Timestamp))
+	event.Message = aws.String(body + signatureField + hex.EncodeToString(sig) + trailer)
+}
+
+// VerifyEvent reports whether the signature added to the message of event by a
+// writer created using WithIntegrityHMAC(key) matches its message and
+// timestamp. It returns an error if the message isn't signed.
+func VerifyEvent(key []byte, event *cloudwatchlogs.OutputLogEvent) (bool, error) {
+	message, trailer := splitTrailingNewline(aws.StringValue(event.Message))
+
+	i := strings.LastIndex(message, signatureField)
+	if i < 0 {
+		return false, errors.New("log event isn't signed")
+	}
+
+	sig, err := hex.DecodeString(message[i+len(signatureField):])
+	if err != nil || len(sig) != sha256.Size {
+		return false, errors.New("log event has an invalid signature")
+	}
+
+	expected := signature(key, message[:i]+trailer, aws.Int64Value(event.Timestamp))
+	return hmac.Equal(sig, expected), nil
+}
+
+// splitTrailingNewline returns message without its trailing line break, and
+// the line break.
+func splitTrailingNewline(message string) (string, string) {
+	body := strings.TrimSuffix(message, "\n")
+	return body, message[len(body):]
+}
+
+// signature returns the HMAC of the timestamp, as 8 big-endian bytes, followed
+// by the message. The timestamp having a fixed width, no other pair of message
+// and timestamp has the same input.
+func signature(key []byte, message string, timestamp int64) []byte {
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(timestamp))
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(ts[:])
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
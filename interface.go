@@ -3,6 +3,7 @@ package cloudwatch
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 
@@ -13,27 +14,335 @@ import (
 // the AWS SDK, and makes it an implementation of Go's error interface.
 type RejectedLogEventsInfoError struct {
 	Info *cloudwatchlogs.RejectedLogEventsInfo
+
+	// Events is the batch of events which was sent, which the indexes in
+	// Info refer to.
+	Events []*cloudwatchlogs.InputLogEvent
 }
 
 func (e *RejectedLogEventsInfoError) Error() string {
 	return "log messages were rejected"
 }
 
+// TooOldEvents returns the events rejected for being more than 14 days old.
+func (e *RejectedLogEventsInfoError) TooOldEvents() []*cloudwatchlogs.InputLogEvent {
+	return e.eventsBefore(e.Info.TooOldLogEventEndIndex)
+}
+
+// TooNewEvents returns the events rejected for being more than 2 hours in the
+// future.
+func (e *RejectedLogEventsInfoError) TooNewEvents() []*cloudwatchlogs.InputLogEvent {
+	if e.Info.TooNewLogEventStartIndex == nil {
+		return nil
+	}
+
+	start := int(*e.Info.TooNewLogEventStartIndex)
+	if start < 0 || start >= len(e.Events) {
+		return nil
+	}
+
+	return e.Events[start:]
+}
+
+// ExpiredEvents returns the events rejected for being older than the
+// retention period of the log group.
+func (e *RejectedLogEventsInfoError) ExpiredEvents() []*cloudwatchlogs.InputLogEvent {
+	return e.eventsBefore(e.Info.ExpiredLogEventEndIndex)
+}
+
+// eventsBefore returns the events before the exclusive end index, as events
+// are sorted chronologically and rejected old events come first.
+func (e *RejectedLogEventsInfoError) eventsBefore(end *int64) []*cloudwatchlogs.InputLogEvent {
+	if end == nil || *end <= 0 {
+		return nil
+	}
+
+	if int(*end) > len(e.Events) {
+		return e.Events
+	}
+
+	return e.Events[:*end]
+}
+
+// WriteFlushCloser is an io.WriteCloser whose buffered log events can also be
+// sent on demand.
+type WriteFlushCloser interface {
+	io.WriteCloser
+
+	// Flush blocks until all currently buffered log events have been sent to
+	// AWS CloudWatch Logs, or an error occurs. Unlike Close, it leaves the
+	// writer open.
+	Flush() error
+}
+
+// Reader is returned by Group.Open. Read returns the messages of the events
+// read, concatenated, while NextEvent returns the events themselves. Both
+// consume the same events, and can be mixed: NextEvent skips the rest of an
+// event partially consumed by Read.
+type Reader interface {
+	io.ReadCloser
+
+	// NextEvent returns the next event read from the stream, with its
+	// original timestamp and ingestion time. Like Read, it doesn't block: if
+	// no event is available yet, it returns nil and a nil error, and once the
+	// reader stopped, the error that stopped it, which is io.EOF at the end
+	// of a tailed stream.
+	NextEvent() (*cloudwatchlogs.OutputLogEvent, error)
+
+	// ReadN returns the next n events, waiting for the reader to fetch them.
+	// If the reader stops first, or the end of the stream is reached when not
+	// tailing, it returns the events read so far along with the error, which
+	// is io.EOF at the end of the stream.
+	ReadN(n int) ([]*cloudwatchlogs.OutputLogEvent, error)
+
+	// Seek makes the reader continue from token, a forward token such as
+	// those saved using WithReaderTokenStore, or a FilterLogEvents token for
+	// readers using WithFilterPattern. Events read but not consumed yet are
+	// discarded. It waits for a request in progress to complete, and returns
+	// the error that stopped the reader, if any.
+	Seek(token string) error
+}
+
+// GroupOption allows setting various options on the resulting group.
+type GroupOption func(*groupImpl)
+
 // CreateOption allows setting various options on the resulting writer.
 type CreateOption func(*writerImpl)
 
+// ReadOption allows setting various options on the resulting reader.
+type ReadOption func(*readerImpl)
+
+// StreamIterator lazily pages through the log streams of a group.
+type StreamIterator interface {
+	// Next returns the name of the next log stream, and false once there are
+	// no more streams or an error occurred.
+	Next() (name string, ok bool)
+
+	// Err returns the error that stopped the iteration, if any.
+	Err() error
+}
+
+// FilterOptions selects the log events returned by Group.Filter. Fields left
+// to their zero value do not restrict the events.
+type FilterOptions struct {
+	// StartTime and EndTime restrict events to those at or after StartTime,
+	// and before EndTime.
+	StartTime, EndTime time.Time
+
+	// FilterPattern uses the CloudWatch Logs filter and pattern syntax.
+	FilterPattern string
+
+	// StreamNames restricts events to those in the given log streams.
+	StreamNames []string
+
+	// Limit is the maximum number of events returned.
+	Limit int
+}
+
+// FilterIterator lazily pages through the log events matching a filter.
+type FilterIterator interface {
+	// Next returns the next event, and false once there are no more events
+	// or an error occurred.
+	Next() (event *cloudwatchlogs.FilteredLogEvent, ok bool)
+
+	// Err returns the error that stopped the iteration, if any. It's the
+	// context's error if it was done.
+	Err() error
+}
+
 // Group is an abstraction over AWS CloudWatch Logs Group, allowing one to treat
 // it like a remote io.ReadWriter.
 type Group interface {
 	cloudwatchlogsiface.CloudWatchLogsAPI
 
-	// Create creates a log stream in the managed group and returns an
-	// implementation of io.Writer to write to it.
-	Create(ctx context.Context, streamName string, opts ...CreateOption) (io.WriteCloser, error)
+	// Create creates a log stream in the managed group and returns a Writer
+	// to write to it.
+	Create(ctx context.Context, streamName string, opts ...CreateOption) (Writer, error)
+
+	// CreateFromContext is like Create, writing to the stream named
+	// streamPrefix followed by a dash and the string value of ctx for key,
+	// such as a request ID. If a writer for that stream is already open, it's
+	// returned instead, even if it was created with other options, unless
+	// its context is done or it failed. The writer stops when ctx is done, so
+	// it should be closed before.
+	CreateFromContext(ctx context.Context, key interface{}, streamPrefix string, opts ...CreateOption) (Writer, error)
+
+	// CreateEphemeral is like Create, writing to a new stream named prefix
+	// followed by a dash and a random UUID, whose name is returned along with
+	// the writer.
+	CreateEphemeral(ctx context.Context, prefix string, opts ...CreateOption) (Writer, string, error)
 
 	// Name of the CloudWatch Logs group owned by this proxy.
 	Name() string
 
-	// Open returns an io.Readcloser to read from the log stream.
-	Open(ctx context.Context, streamName string) io.ReadCloser
+	// Writers returns the names of the streams written to by writers created
+	// using Create which haven't been closed yet, in alphabetical order.
+	Writers() []string
+
+	// CloseAll closes all writers created using Create which haven't been
+	// closed yet, concurrently, including several writers of the same
+	// stream. If any of them fails to close, a MultiError is returned.
+	CloseAll() error
+
+	// Open returns a Reader to read from the log stream.
+	Open(ctx context.Context, streamName string, opts ...ReadOption) Reader
+
+	// OpenMany returns an io.ReadCloser reading from several log streams at
+	// once, with their events merged in timestamp order. Unless tailing, Read
+	// returns io.EOF once every stream has been read to the end.
+	OpenMany(ctx context.Context, streamNames []string, opts ...ReadOption) io.ReadCloser
+
+	// SetRetention sets the number of days log events are kept in the group.
+	// Only the values accepted by CloudWatch Logs are allowed: 1, 3, 5, 7, 14,
+	// 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1827 and 3653.
+	SetRetention(ctx context.Context, days int) error
+
+	// DeleteRetention removes the group's retention policy, so that log events
+	// never expire.
+	DeleteRetention(ctx context.Context) error
+
+	// GetRecord returns all fields of a single log event, given the
+	// pointer returned for it by a CloudWatch Logs Insights query, as its
+	// @ptr field. Pointers expire after 24 hours, according to the AWS
+	// documentation.
+	GetRecord(ctx context.Context, logRecordPointer string) (map[string]string, error)
+
+	// AssociateKMSKey encrypts the log events ingested into the group from
+	// now on with the KMS key keyID, given as an ARN.
+	AssociateKMSKey(ctx context.Context, keyID string) error
+
+	// DisassociateKMSKey stops encrypting the log events ingested into the
+	// group. Events encrypted before remain readable.
+	DisassociateKMSKey(ctx context.Context) error
+
+	// DeleteStream deletes a log stream from the group. It returns ErrNotFound
+	// if the stream doesn't exist.
+	DeleteStream(ctx context.Context, streamName string) error
+
+	// Delete deletes the log group and all of its streams. Its writers are
+	// closed and its readers stopped first. It returns ErrNotFound if the
+	// group doesn't exist.
+	Delete(ctx context.Context) error
+
+	// Tag adds or updates tags on the log group. Along with Untag and
+	// ListTags, this uses the group's ARN, which is looked up using
+	// DescribeLogGroups. It requires the logs:TagResource and
+	// logs:DescribeLogGroups IAM permissions.
+	Tag(ctx context.Context, tags map[string]string) error
+
+	// Untag removes the tags with the given keys from the log group. It
+	// requires the logs:UntagResource IAM permission.
+	Untag(ctx context.Context, keys []string) error
+
+	// ListTags returns the tags of the log group. It requires the
+	// logs:ListTagsForResource IAM permission.
+	ListTags(ctx context.Context) (map[string]string, error)
+
+	// DescribeStream returns the metadata of a log stream of the group, such
+	// as the time of its last event and its stored bytes. It returns
+	// ErrNotFound if the stream doesn't exist.
+	DescribeStream(ctx context.Context, streamName string) (*cloudwatchlogs.LogStream, error)
+
+	// Describe returns the metadata of the log group, such as its ARN and
+	// stored bytes, using DescribeLogGroups. The result is cached for the
+	// duration set using WithDescribeCacheTTL. It returns ErrNotFound if the
+	// group doesn't exist.
+	Describe(ctx context.Context) (*cloudwatchlogs.LogGroup, error)
+
+	// PutSubscription creates or replaces a subscription filter on the log
+	// group, forwarding matching events to a destination. It's named so as not
+	// to clash with the raw PutSubscriptionFilter API method.
+	PutSubscription(ctx context.Context, opts SubscriptionFilterOptions) error
+
+	// DeleteSubscription deletes a subscription filter from the log group. It
+	// returns ErrNotFound if the filter doesn't exist.
+	DeleteSubscription(ctx context.Context, filterName string) error
+
+	// ListSubscriptions returns the subscription filters of the log group.
+	ListSubscriptions(ctx context.Context) ([]cloudwatchlogs.SubscriptionFilter, error)
+
+	// SetMetricFilter creates or replaces a metric filter on the log group,
+	// publishing CloudWatch metrics from matching events. The metric filter
+	// methods are named so as not to clash with the raw API methods.
+	SetMetricFilter(ctx context.Context, opts MetricFilterOptions) error
+
+	// RemoveMetricFilter deletes a metric filter from the log group. It
+	// returns ErrNotFound if the filter doesn't exist.
+	RemoveMetricFilter(ctx context.Context, filterName string) error
+
+	// ListMetricFilters returns the metric filters of the log group.
+	ListMetricFilters(ctx context.Context) ([]cloudwatchlogs.MetricFilter, error)
+
+	// MatchMetricFilter tests filterPattern against sampleLines, and returns
+	// the lines it matches along with the values it extracts. Lines which
+	// don't match are left out.
+	MatchMetricFilter(ctx context.Context, filterPattern string, sampleLines []string) ([]cloudwatchlogs.MetricFilterMatchRecord, error)
+
+	// SetDataProtectionPolicy creates or replaces the data protection policy
+	// of the log group, which audits and masks sensitive data in its events.
+	// policyDocument is a JSON document following the CloudWatch Logs data
+	// protection policy schema; an error is returned without calling the API
+	// if it isn't valid JSON. The data protection policy methods are named so
+	// as not to clash with the raw API methods.
+	SetDataProtectionPolicy(ctx context.Context, policyDocument string) error
+
+	// DataProtectionPolicy returns the data protection policy document of the
+	// log group, which is empty if it has none. It returns ErrNotFound if the
+	// group doesn't exist.
+	DataProtectionPolicy(ctx context.Context) (string, error)
+
+	// RemoveDataProtectionPolicy deletes the data protection policy of the log
+	// group. It returns ErrNotFound if the group or its policy doesn't exist.
+	RemoveDataProtectionPolicy(ctx context.Context) error
+
+	// ListStreams returns the names of all log streams in the group starting
+	// with prefix.
+	ListStreams(ctx context.Context, prefix string) ([]string, error)
+
+	// StreamIterator returns a StreamIterator over the log streams in the
+	// group starting with prefix, fetching pages as they are needed.
+	StreamIterator(ctx context.Context, prefix string) StreamIterator
+
+	// StreamExists reports whether the log stream exists in the group.
+	StreamExists(ctx context.Context, streamName string) (bool, error)
+
+	// WaitForStream blocks until the log stream exists, checking every
+	// pollInterval. It returns the context's error if it's done first, or
+	// the error from checking whether the stream exists.
+	WaitForStream(ctx context.Context, streamName string, pollInterval time.Duration) error
+
+	// Filter returns a FilterIterator over the events across the group's log
+	// streams selected by opts, fetching pages as they are needed. Pages
+	// after the first are throttled like reads.
+	Filter(ctx context.Context, opts FilterOptions) FilterIterator
+
+	// Events reads from the log stream like Open, but sends each event on the
+	// returned channel instead of serializing its message. Reading stops when
+	// ctx is done, after which both channels are closed. A non-nil error is
+	// sent on the error channel if reading fails.
+	Events(ctx context.Context, streamName string, opts ...ReadOption) (<-chan *cloudwatchlogs.OutputLogEvent, <-chan error)
+
+	// ExportToS3 exports the group's events selected by opts to an S3
+	// bucket, and waits for the export task to finish. If the task fails or
+	// is cancelled, it's returned along with an error. Only one export task
+	// per account can run at a time.
+	ExportToS3(ctx context.Context, opts ExportOptions) (*cloudwatchlogs.ExportTask, error)
+
+	// Copy writes the events of srcStream, up to its end at the time they're
+	// read, to dstStream with their original timestamps, and returns the
+	// number of events copied. dstStream is created if it doesn't exist, and
+	// appended to otherwise. Events older than CloudWatch Logs accepts are
+	// dropped like when writing.
+	Copy(ctx context.Context, srcStream, dstStream string, opts ...CopyOption) (int64, error)
+
+	// LiveTail streams the group's events in real time using a Live Tail
+	// session, limited to the given log streams and filter pattern if they
+	// are not empty. Unlike WithTailMode, events are pushed by CloudWatch
+	// rather than polled for. The session is reestablished after transient
+	// errors, such as the session timing out, up to WithMaxReconnects times
+	// in a row. Tailing stops when ctx is done, after which both channels are
+	// closed. Otherwise, the error which ended the session is sent on the
+	// error channel. It requires the logs:StartLiveTail and
+	// logs:DescribeLogGroups IAM permissions.
+	LiveTail(ctx context.Context, streamNames []string, filterPattern string, opts ...LiveTailOption) (<-chan *cloudwatchlogs.LiveTailSessionLogEvent, <-chan error)
 }
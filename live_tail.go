@@ -0,0 +1,165 @@
+package cloudwatch
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+)
+
+const defaultMaxReconnects = 3
+
+// errLiveTailClosed is returned when a Live Tail session ends without an
+// error.
+var errLiveTailClosed = errors.New("live tail session closed")
+
+// liveTailStream returns the event stream of a Live Tail session. It's
+// replaced in tests, as the stream of a StartLiveTailOutput can't be set.
+var liveTailStream = func(resp *cloudwatchlogs.StartLiveTailOutput) cloudwatchlogs.StartLiveTailResponseStreamReader {
+	return resp.GetStream()
+}
+
+// LiveTailOption allows setting various options on a Live Tail session.
+type LiveTailOption func(*liveTail)
+
+// WithMaxReconnects sets how many times in a row a Live Tail session is
+// reestablished after a transient error, such as the session timing out. The
+// default is 3.
+func WithMaxReconnects(n int) LiveTailOption {
+	return func(t *liveTail) {
+		t.maxReconnects = n
+	}
+}
+
+type liveTail struct {
+	client        *groupImpl
+	ctx           context.Context
+	events        chan<- *cloudwatchlogs.LiveTailSessionLogEvent
+	maxReconnects int
+	retry         backoff
+}
+
+func (g *groupImpl) LiveTail(ctx context.Context, streamNames []string, filterPattern string, opts ...LiveTailOption) (<-chan *cloudwatchlogs.LiveTailSessionLogEvent, <-chan error) {
+	events := make(chan *cloudwatchlogs.LiveTailSessionLogEvent)
+	errs := make(chan error, 1)
+
+	t := &liveTail{
+		client:        g,
+		ctx:           ctx,
+		events:        events,
+		maxReconnects: defaultMaxReconnects,
+		retry:         newBackoff(),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	go func() {
+		defer close(errs)
+		defer close(events)
+
+		if err := t.run(streamNames, filterPattern); err != nil && err != ctx.Err() {
+			errs <- err
+		}
+	}()
+
+	return events, errs
+}
+
+// run tails the log group until ctx is done, a non-transient error occurs, or
+// reconnecting failed too many times in a row.
+func (t *liveTail) run(streamNames []string, filterPattern string) error {
+	// Live Tail requires log groups to be identified by their ARN.
+	arn, err := t.client.arn(t.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not start the live tail session")
+	}
+
+	input := &cloudwatchlogs.StartLiveTailInput{
+		LogGroupIdentifiers: []*string{arn},
+	}
+	if len(streamNames) > 0 {
+		input.LogStreamNames = aws.StringSlice(streamNames)
+	}
+	if filterPattern != "" {
+		input.LogEventFilterPattern = aws.String(filterPattern)
+	}
+
+	for attempt := 0; ; attempt++ {
+		started, err := t.session(input)
+		if t.ctx.Err() != nil {
+			return t.ctx.Err()
+		}
+
+		// Only consecutive failures count towards the limit.
+		if started {
+			attempt = 0
+		}
+
+		if !transientLiveTailError(err) || attempt >= t.maxReconnects {
+			return err
+		}
+
+		if err := aws.SleepWithContext(t.ctx, t.retry.delay(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// session runs a single Live Tail session, forwarding its events. It returns
+// whether the session started, and the error which ended it.
+func (t *liveTail) session(input *cloudwatchlogs.StartLiveTailInput) (bool, error) {
+	resp, err := t.client.StartLiveTailWithContext(t.ctx, input)
+	if err != nil {
+		return false, err
+	}
+
+	stream := liveTailStream(resp)
+	defer stream.Close()
+
+	var started bool
+	for {
+		var event cloudwatchlogs.StartLiveTailResponseStreamEvent
+		var ok bool
+
+		select {
+		case <-t.ctx.Done():
+			return started, t.ctx.Err()
+		case event, ok = <-stream.Events():
+		}
+
+		if !ok {
+			if err := stream.Err(); err != nil {
+				return started, err
+			}
+			return started, errLiveTailClosed
+		}
+
+		switch event := event.(type) {
+		case *cloudwatchlogs.LiveTailSessionStart:
+			started = true
+		case *cloudwatchlogs.LiveTailSessionUpdate:
+			for _, result := range event.SessionResults {
+				select {
+				case t.events <- result:
+				case <-t.ctx.Done():
+					return started, t.ctx.Err()
+				}
+			}
+		}
+	}
+}
+
+func transientLiveTailError(err error) bool {
+	switch err.(type) {
+	case *cloudwatchlogs.SessionTimeoutException,
+		*cloudwatchlogs.SessionStreamingException,
+		*cloudwatchlogs.ServiceUnavailableException:
+		return true
+	}
+
+	return err == errLiveTailClosed || request.IsErrorThrottle(err)
+}
@@ -1,6 +1,9 @@
 package cloudwatch
 
 import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 )
 
@@ -10,26 +13,61 @@ const (
 	maxBatchSizeBytes  = 1048576
 	maxBatchSizeEvents = 10000
 	paddingSize        = 26
+
+	// The events of a batch can't span more than 24 hours.
+	maxBatchSpan = 24 * time.Hour
+
+	// maxEventSizeBytes is the maximum size of a single log event, including
+	// the padding.
+	maxEventSizeBytes    = 262144
+	maxEventMessageBytes = maxEventSizeBytes - paddingSize
+
+	// Events can't be older than 14 days, or more than 2 hours in the
+	// future.
+	maxEventAge    = 14 * 24 * time.Hour
+	maxEventFuture = 2 * time.Hour
 )
 
 type logBatch struct {
 	count, size int
 	events      []*cloudwatchlogs.InputLogEvent
 	next        *logBatch
+
+	// Oldest and newest timestamps of the events, in milliseconds.
+	minTimestamp, maxTimestamp int64
 }
 
-func (l *logBatch) add(event *cloudwatchlogs.InputLogEvent) *logBatch {
+// add appends the event to the batch, or to a new batch chained after it if
+// adding the event would exceed maxBytes or maxEvents, or make the batch span
+// more than maxBatchSpan. The batch the event ended up in is returned.
+func (l *logBatch) add(event *cloudwatchlogs.InputLogEvent, maxBytes, maxEvents int) *logBatch {
 	if event.Message == nil {
 		return l
 	}
-	l.count++
 	nextSize := l.size + len(*event.Message) + paddingSize
-	if nextSize > maxBatchSizeBytes || l.count > maxBatchSizeEvents {
+	if l.count > 0 && (nextSize > maxBytes || l.count+1 > maxEvents || l.exceedsSpan(event)) {
 		l.next = new(logBatch)
-		return l.next.add(event)
+		return l.next.add(event, maxBytes, maxEvents)
+	}
 
+	ts := aws.Int64Value(event.Timestamp)
+	if l.count == 0 || ts < l.minTimestamp {
+		l.minTimestamp = ts
+	}
+	if l.count == 0 || ts > l.maxTimestamp {
+		l.maxTimestamp = ts
 	}
+
 	l.events = append(l.events, event)
+	l.count++
 	l.size = nextSize
 	return l
 }
+
+// exceedsSpan reports whether adding the event would make the non-empty batch
+// span more than maxBatchSpan.
+func (l *logBatch) exceedsSpan(event *cloudwatchlogs.InputLogEvent) bool {
+	ts := aws.Int64Value(event.Timestamp)
+	span := maxBatchSpan.Milliseconds()
+	return ts-l.minTimestamp > span || l.maxTimestamp-ts > span
+}
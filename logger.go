@@ -0,0 +1,79 @@
+package cloudwatch
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// GroupLogger logs the internal operations of a group and its writers and
+// readers, such as stream creation, flushes, retries and dropped events. The
+// fields are alternating keys and values.
+type GroupLogger interface {
+	Info(msg string, fields ...interface{})
+	Warn(msg string, fields ...interface{})
+	Error(msg string, fields ...interface{})
+}
+
+// NoopGroupLogger discards everything.
+type NoopGroupLogger struct{}
+
+func (NoopGroupLogger) Info(string, ...interface{})  {}
+func (NoopGroupLogger) Warn(string, ...interface{})  {}
+func (NoopGroupLogger) Error(string, ...interface{}) {}
+
+// StdGroupLogger logs to the standard logger, one line per message with the
+// fields formatted as key=value.
+type StdGroupLogger struct{}
+
+func (StdGroupLogger) Info(msg string, fields ...interface{}) {
+	log.Print(formatLog("info", msg, fields))
+}
+
+func (StdGroupLogger) Warn(msg string, fields ...interface{}) {
+	log.Print(formatLog("warn", msg, fields))
+}
+
+func (StdGroupLogger) Error(msg string, fields ...interface{}) {
+	log.Print(formatLog("error", msg, fields))
+}
+
+// defaultGroupLogger is the default logger of groups, which only logs warnings
+// and errors, such as dropped events and invalid options, to the standard
+// logger.
+type defaultGroupLogger struct {
+	StdGroupLogger
+}
+
+func (defaultGroupLogger) Info(string, ...interface{}) {}
+
+func formatLog(level, msg string, fields []interface{}) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "cloudwatch: %s: %s", level, msg)
+	for i := 0; i < len(fields); i += 2 {
+		if i+1 < len(fields) {
+			fmt.Fprintf(&b, " %v=%v", fields[i], fields[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", fields[i])
+		}
+	}
+	return b.String()
+}
+
+// log returns the writer's logger, which may not be set on writers that
+// weren't created by a group.
+func (w *writerImpl) log() GroupLogger {
+	if w.logger == nil {
+		return defaultGroupLogger{}
+	}
+	return w.logger
+}
+
+// log returns the reader's logger, which may not be set on readers that
+// weren't opened by a group.
+func (r *readerImpl) log() GroupLogger {
+	if r.logger == nil {
+		return defaultGroupLogger{}
+	}
+	return r.logger
+}
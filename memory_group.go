@@ -0,0 +1,268 @@
+package cloudwatch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+)
+
+// getLogEventsLimit is the default and maximum number of events returned by a
+// GetLogEvents request.
+const getLogEventsLimit = 10000
+
+// MemoryStore is an in-memory implementation of the parts of the AWS
+// CloudWatch Logs API used by writers and readers, holding the events of a
+// single log group. Like CloudWatch Logs, it enforces sequence tokens and
+// rejects batches of events which are not in chronological order. Calling any
+// other method panics.
+//
+// It's safe for concurrent use.
+type MemoryStore struct {
+	iface.CloudWatchLogsAPI
+
+	groupName string
+
+	mu     sync.RWMutex
+	events map[string][]*cloudwatchlogs.InputLogEvent
+	// The number of batches put to each stream, from which its sequence
+	// token is derived.
+	batches map[string]int
+}
+
+// NewMemoryGroup returns a Group backed by a new MemoryStore instead of AWS
+// CloudWatch Logs, for use in tests. Writers and readers are throttled like
+// with a real Group.
+func NewMemoryGroup(groupName string) (Group, *MemoryStore) {
+	store := &MemoryStore{
+		groupName: groupName,
+		events:    make(map[string][]*cloudwatchlogs.InputLogEvent),
+		batches:   make(map[string]int),
+	}
+
+	return NewGroup(store, groupName), store
+}
+
+// Events returns the events written to the stream so far, in order.
+func (s *MemoryStore) Events(streamName string) []*cloudwatchlogs.InputLogEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]*cloudwatchlogs.InputLogEvent(nil), s.events[streamName]...)
+}
+
+func (s *MemoryStore) CreateLogGroupWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogGroupInput, opts ...request.Option) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	if err := s.checkGroup(input.LogGroupName); err != nil {
+		return nil, err
+	}
+
+	return nil, &cloudwatchlogs.ResourceAlreadyExistsException{
+		Message_: aws.String("The specified log group already exists"),
+	}
+}
+
+func (s *MemoryStore) CreateLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogStreamInput, opts ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	if err := s.checkGroup(input.LogGroupName); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := aws.StringValue(input.LogStreamName)
+	if _, ok := s.events[name]; ok {
+		return nil, &cloudwatchlogs.ResourceAlreadyExistsException{
+			Message_: aws.String("The specified log stream already exists"),
+		}
+	}
+
+	s.events[name] = []*cloudwatchlogs.InputLogEvent{}
+	return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+}
+
+func (s *MemoryStore) DeleteLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteLogStreamInput, opts ...request.Option) (*cloudwatchlogs.DeleteLogStreamOutput, error) {
+	if err := s.checkGroup(input.LogGroupName); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := aws.StringValue(input.LogStreamName)
+	if _, ok := s.events[name]; !ok {
+		return nil, streamNotFound()
+	}
+
+	delete(s.events, name)
+	delete(s.batches, name)
+	return &cloudwatchlogs.DeleteLogStreamOutput{}, nil
+}
+
+func (s *MemoryStore) DescribeLogStreamsWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeLogStreamsInput, opts ...request.Option) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	if err := s.checkGroup(input.LogGroupName); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var names []string
+	for name := range s.events {
+		if strings.HasPrefix(name, aws.StringValue(input.LogStreamNamePrefix)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	ret := &cloudwatchlogs.DescribeLogStreamsOutput{}
+	for _, name := range names {
+		stream := &cloudwatchlogs.LogStream{
+			LogStreamName:       aws.String(name),
+			UploadSequenceToken: sequenceToken(s.batches[name]),
+		}
+		for _, event := range s.events[name] {
+			if stream.FirstEventTimestamp == nil || *event.Timestamp < *stream.FirstEventTimestamp {
+				stream.FirstEventTimestamp = aws.Int64(*event.Timestamp)
+			}
+			if stream.LastEventTimestamp == nil || *event.Timestamp > *stream.LastEventTimestamp {
+				stream.LastEventTimestamp = aws.Int64(*event.Timestamp)
+			}
+		}
+		ret.LogStreams = append(ret.LogStreams, stream)
+	}
+
+	return ret, nil
+}
+
+func (s *MemoryStore) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.PutLogEventsInput, opts ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	if err := s.checkGroup(input.LogGroupName); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := aws.StringValue(input.LogStreamName)
+	events, ok := s.events[name]
+	if !ok {
+		return nil, streamNotFound()
+	}
+
+	expected := sequenceToken(s.batches[name])
+	if aws.StringValue(input.SequenceToken) != aws.StringValue(expected) {
+		return nil, &cloudwatchlogs.InvalidSequenceTokenException{
+			ExpectedSequenceToken: expected,
+			Message_:              aws.String("The given sequenceToken is invalid"),
+		}
+	}
+
+	for i, event := range input.LogEvents {
+		if i > 0 && aws.Int64Value(event.Timestamp) < aws.Int64Value(input.LogEvents[i-1].Timestamp) {
+			return nil, &cloudwatchlogs.InvalidParameterException{
+				Message_: aws.String("Log events in a single PutLogEvents request must be in chronological order"),
+			}
+		}
+
+		events = append(events, &cloudwatchlogs.InputLogEvent{
+			Message:   aws.String(aws.StringValue(event.Message)),
+			Timestamp: aws.Int64(aws.Int64Value(event.Timestamp)),
+		})
+	}
+
+	s.events[name] = events
+	s.batches[name]++
+
+	return &cloudwatchlogs.PutLogEventsOutput{
+		NextSequenceToken: sequenceToken(s.batches[name]),
+	}, nil
+}
+
+// GetLogEventsWithContext returns the stream's events, starting after the
+// position encoded in the forward token. Without a token, it starts from the
+// head of the stream, or its end if StartFromHead is false.
+func (s *MemoryStore) GetLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.GetLogEventsInput, opts ...request.Option) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	if err := s.checkGroup(input.LogGroupName); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events, ok := s.events[aws.StringValue(input.LogStreamName)]
+	if !ok {
+		return nil, streamNotFound()
+	}
+
+	var start int
+	if input.NextToken != nil {
+		var err error
+		if start, err = strconv.Atoi(strings.TrimPrefix(*input.NextToken, "f/")); err != nil || start > len(events) {
+			return nil, &cloudwatchlogs.InvalidParameterException{
+				Message_: aws.String("The specified nextToken is invalid"),
+			}
+		}
+	} else if !aws.BoolValue(input.StartFromHead) {
+		start = len(events)
+	}
+
+	limit := getLogEventsLimit
+	if input.Limit != nil && int(*input.Limit) < limit {
+		limit = int(*input.Limit)
+	}
+
+	ret := &cloudwatchlogs.GetLogEventsOutput{
+		NextBackwardToken: aws.String("b/" + strconv.Itoa(start)),
+	}
+
+	end := start
+	for ; end < len(events) && len(ret.Events) < limit; end++ {
+		event := events[end]
+		if input.StartTime != nil && *event.Timestamp < *input.StartTime {
+			continue
+		}
+		if input.EndTime != nil && *event.Timestamp >= *input.EndTime {
+			continue
+		}
+
+		ret.Events = append(ret.Events, &cloudwatchlogs.OutputLogEvent{
+			Message:   aws.String(*event.Message),
+			Timestamp: aws.Int64(*event.Timestamp),
+		})
+	}
+
+	// Like CloudWatch Logs, the token given is returned once the end of the
+	// stream has been reached.
+	ret.NextForwardToken = aws.String("f/" + strconv.Itoa(end))
+
+	return ret, nil
+}
+
+func (s *MemoryStore) checkGroup(groupName *string) error {
+	if aws.StringValue(groupName) == s.groupName {
+		return nil
+	}
+
+	return &cloudwatchlogs.ResourceNotFoundException{
+		Message_: aws.String("The specified log group does not exist"),
+	}
+}
+
+func streamNotFound() error {
+	return &cloudwatchlogs.ResourceNotFoundException{
+		Message_: aws.String("The specified log stream does not exist"),
+	}
+}
+
+// sequenceToken returns the token expected after the given number of batches.
+// New streams do not have a sequence token.
+func sequenceToken(batches int) *string {
+	if batches == 0 {
+		return nil
+	}
+	return aws.String(strconv.Itoa(batches))
+}
@@ -0,0 +1,432 @@
+package cloudwatch
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/suite"
+)
+
+type memoryGroupTestSuite struct {
+	suite.Suite
+	ctx   context.Context
+	sut   Group
+	store *MemoryStore
+}
+
+func (ms *memoryGroupTestSuite) SetupTest() {
+	ms.ctx = context.Background()
+	ms.sut, ms.store = NewMemoryGroup("groupName")
+}
+
+func (ms *memoryGroupTestSuite) TestWriteThenRead() {
+	writer, err := ms.sut.Create(ms.ctx, "streamName")
+	ms.Require().NoError(err)
+
+	_, err = io.WriteString(writer, "Hello\nWorld\n")
+	ms.Require().NoError(err)
+	ms.Require().NoError(writer.Flush())
+
+	events := ms.store.Events("streamName")
+	ms.Require().Len(events, 2)
+	ms.Equal("Hello\n", *events[0].Message)
+	ms.Equal("World\n", *events[1].Message)
+
+	ctx, cancel := context.WithCancel(ms.ctx)
+	defer cancel()
+
+	reader := ms.sut.Open(ctx, "streamName")
+	ms.Eventually(func() bool {
+		b := make([]byte, 64)
+		n, _ := reader.Read(b)
+		return string(b[:n]) == "Hello\nWorld\n"
+	}, time.Second, readThrottle)
+
+	ms.NoError(reader.Close())
+	ms.NoError(writer.Close())
+}
+
+func (ms *memoryGroupTestSuite) TestTail() {
+	writer, err := ms.sut.Create(ms.ctx, "streamName")
+	ms.Require().NoError(err)
+
+	_, err = io.WriteString(writer, "old\n")
+	ms.Require().NoError(err)
+	ms.Require().NoError(writer.Flush())
+
+	ctx, cancel := context.WithCancel(ms.ctx)
+	defer cancel()
+
+	reader := ms.sut.Open(ctx, "streamName", WithTailMode())
+	time.Sleep(2 * readThrottle)
+
+	_, err = io.WriteString(writer, "new\n")
+	ms.Require().NoError(err)
+	ms.Require().NoError(writer.Flush())
+
+	ms.Eventually(func() bool {
+		b := make([]byte, 64)
+		n, _ := reader.Read(b)
+		return string(b[:n]) == "new\n"
+	}, time.Second, readThrottle)
+
+	ms.NoError(reader.Close())
+	ms.NoError(writer.Close())
+}
+
+func (ms *memoryGroupTestSuite) TestSequenceTokens() {
+	first, err := ms.sut.Create(ms.ctx, "streamName")
+	ms.Require().NoError(err)
+
+	_, err = io.WriteString(first, "first\n")
+	ms.Require().NoError(err)
+	ms.Require().NoError(first.Flush())
+
+	// A second writer picks up the stream's sequence token.
+	second, err := ms.sut.Create(ms.ctx, "streamName")
+	ms.Require().NoError(err)
+	ms.Equal("1", aws.StringValue(second.(*writerImpl).sequenceToken))
+
+	_, err = io.WriteString(second, "second\n")
+	ms.Require().NoError(err)
+	ms.Require().NoError(second.Flush())
+
+	// The first writer's token is now stale, and is recovered from the
+	// error.
+	_, err = io.WriteString(first, "third\n")
+	ms.Require().NoError(err)
+	ms.Require().NoError(first.Flush())
+
+	ms.Len(ms.store.Events("streamName"), 3)
+
+	_, err = ms.store.PutLogEventsWithContext(ms.ctx, &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String("groupName"),
+		LogStreamName: aws.String("streamName"),
+		SequenceToken: aws.String("1"),
+	})
+	ms.Require().IsType(new(cloudwatchlogs.InvalidSequenceTokenException), err)
+	ms.Equal("3", aws.StringValue(err.(*cloudwatchlogs.InvalidSequenceTokenException).ExpectedSequenceToken))
+}
+
+func (ms *memoryGroupTestSuite) TestChronologicalOrder() {
+	_, err := ms.sut.Create(ms.ctx, "streamName")
+	ms.Require().NoError(err)
+
+	_, err = ms.store.PutLogEventsWithContext(ms.ctx, &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String("groupName"),
+		LogStreamName: aws.String("streamName"),
+		LogEvents: []*cloudwatchlogs.InputLogEvent{
+			{Message: aws.String("later"), Timestamp: aws.Int64(2)},
+			{Message: aws.String("earlier"), Timestamp: aws.Int64(1)},
+		},
+	})
+
+	ms.IsType(new(cloudwatchlogs.InvalidParameterException), err)
+	ms.Empty(ms.store.Events("streamName"))
+}
+
+func (ms *memoryGroupTestSuite) TestConcurrentWriters() {
+	var wg sync.WaitGroup
+	for _, name := range []string{"a", "b", "c"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+
+			writer, err := ms.sut.Create(ms.ctx, name)
+			ms.Require().NoError(err)
+
+			for i := 0; i < 10; i++ {
+				_, err = io.WriteString(writer, name+"\n")
+				ms.Require().NoError(err)
+			}
+			ms.Require().NoError(writer.Flush())
+		}(name)
+	}
+	wg.Wait()
+
+	streams, err := ms.sut.ListStreams(ms.ctx, "")
+	ms.Require().NoError(err)
+	ms.Equal([]string{"a", "b", "c"}, streams)
+
+	for _, name := range streams {
+		ms.Len(ms.store.Events(name), 10)
+	}
+}
+
+func (ms *memoryGroupTestSuite) TestMissingStream() {
+	ms.Equal(ErrNotFound, ms.sut.DeleteStream(ms.ctx, "streamName"))
+	ms.Nil(ms.store.Events("streamName"))
+}
+
+// putEvents writes events with the given messages to the stream, one minute
+// apart starting at start.
+func (ms *memoryGroupTestSuite) putEvents(streamName string, start time.Time, messages ...string) {
+	writer, err := ms.sut.Create(ms.ctx, streamName)
+	ms.Require().NoError(err)
+
+	var events []*cloudwatchlogs.InputLogEvent
+	for i, message := range messages {
+		events = append(events, &cloudwatchlogs.InputLogEvent{
+			Message:   aws.String(message),
+			Timestamp: aws.Int64(toMillis(start.Add(time.Duration(i) * time.Minute))),
+		})
+	}
+
+	_, err = ms.store.PutLogEventsWithContext(ms.ctx, &cloudwatchlogs.PutLogEventsInput{
+		LogEvents:     events,
+		LogGroupName:  aws.String("groupName"),
+		LogStreamName: aws.String(streamName),
+		SequenceToken: writer.(*writerImpl).sequenceToken,
+	})
+	ms.Require().NoError(err)
+	ms.Require().NoError(writer.Close())
+}
+
+func (ms *memoryGroupTestSuite) TestCopy() {
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	ms.putEvents("src", start, "a", "b", "c")
+	ms.putEvents("dst", start.Add(-time.Minute), "existing")
+
+	copied, err := ms.sut.Copy(ms.ctx, "src", "dst")
+	ms.Require().NoError(err)
+	ms.Equal(int64(3), copied)
+
+	// Events are appended with their original timestamps.
+	events := ms.store.Events("dst")
+	ms.Require().Len(events, 4)
+	for i, message := range []string{"existing", "a", "b", "c"} {
+		ms.Equal(message, *events[i].Message)
+		ms.Equal(toMillis(start.Add(time.Duration(i-1)*time.Minute)), *events[i].Timestamp)
+	}
+}
+
+func (ms *memoryGroupTestSuite) TestCopyParallel() {
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	ms.putEvents("src", start, "a", "b", "c", "d", "e", "f")
+
+	copied, err := ms.sut.Copy(ms.ctx, "src", "dst",
+		WithCopyTimeRange(start.Add(time.Minute), start.Add(5*time.Minute)),
+		WithCopyParallel(3),
+	)
+	ms.Require().NoError(err)
+	ms.Equal(int64(4), copied)
+
+	var messages []string
+	for _, event := range ms.store.Events("dst") {
+		messages = append(messages, *event.Message)
+	}
+	ms.Equal([]string{"b", "c", "d", "e"}, messages)
+}
+
+func (ms *memoryGroupTestSuite) TestFork() {
+	app, err := ms.sut.Create(ms.ctx, "app")
+	ms.Require().NoError(err)
+	audit, err := ms.sut.Create(ms.ctx, "audit")
+	ms.Require().NoError(err)
+
+	writer := Fork(app, audit)
+	_, err = io.WriteString(writer, "Hello\n")
+	ms.Require().NoError(err)
+	ms.Require().NoError(writer.Close())
+
+	for _, name := range []string{"app", "audit"} {
+		events := ms.store.Events(name)
+		ms.Require().Len(events, 1, name)
+		ms.Equal("Hello\n", *events[0].Message)
+	}
+}
+
+func (ms *memoryGroupTestSuite) TestPendingAckStore() {
+	dir, err := ioutil.TempDir("", "cloudwatch")
+	ms.Require().NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "pending.json")
+
+	// A batch was being sent when the process stopped.
+	store, err := FilePendingAckStore(path)
+	ms.Require().NoError(err)
+	ms.Require().NoError(store.Put("streamName", PendingBatch{
+		ID: "id",
+		Events: []*cloudwatchlogs.InputLogEvent{
+			{Message: aws.String("in flight\n"), Timestamp: aws.Int64(toMillis(time.Now()))},
+		},
+	}))
+
+	store, err = FilePendingAckStore(path)
+	ms.Require().NoError(err)
+	writer, err := ms.sut.Create(ms.ctx, "streamName", WithPendingAckStore(store))
+	ms.Require().NoError(err)
+
+	_, err = io.WriteString(writer, "Hello\n")
+	ms.Require().NoError(err)
+	ms.Require().NoError(writer.Close())
+
+	var messages []string
+	for _, event := range ms.store.Events("streamName") {
+		messages = append(messages, *event.Message)
+	}
+	ms.Equal([]string{"in flight\n", "Hello\n"}, messages)
+
+	pending, err := store.Pending("streamName")
+	ms.NoError(err)
+	ms.Empty(pending)
+}
+
+func (ms *memoryGroupTestSuite) TestCreateFromContext() {
+	ctx := context.WithValue(ms.ctx, requestIDKey{}, "abc")
+
+	writer, err := ms.sut.CreateFromContext(ctx, requestIDKey{}, "requests")
+	ms.Require().NoError(err)
+	ms.Equal([]string{"requests-abc"}, ms.sut.Writers())
+
+	again, err := ms.sut.CreateFromContext(ctx, requestIDKey{}, "requests")
+	ms.Require().NoError(err)
+	ms.Same(writer, again)
+
+	other, err := ms.sut.CreateFromContext(context.WithValue(ms.ctx, requestIDKey{}, "def"), requestIDKey{}, "requests")
+	ms.Require().NoError(err)
+	ms.NotEqual(writer, other)
+	ms.Equal([]string{"requests-abc", "requests-def"}, ms.sut.Writers())
+
+	ms.NoError(ms.sut.CloseAll())
+
+	_, err = ms.sut.CreateFromContext(ms.ctx, requestIDKey{}, "requests")
+	ms.Error(err)
+}
+
+func (ms *memoryGroupTestSuite) TestCreateFromContext_Stopped() {
+	ctx, cancel := context.WithCancel(context.WithValue(ms.ctx, requestIDKey{}, "abc"))
+
+	// A writer whose context is done is forgotten.
+	writer, err := ms.sut.CreateFromContext(ctx, requestIDKey{}, "requests")
+	ms.Require().NoError(err)
+	cancel()
+	ms.Eventually(func() bool { return len(ms.sut.Writers()) == 0 }, time.Second, time.Millisecond)
+
+	ctx = context.WithValue(ms.ctx, requestIDKey{}, "abc")
+	again, err := ms.sut.CreateFromContext(ctx, requestIDKey{}, "requests")
+	ms.Require().NoError(err)
+	ms.NotSame(writer, again)
+
+	// So is one which failed.
+	again.(*writerImpl).setErr(io.ErrUnexpectedEOF)
+	other, err := ms.sut.CreateFromContext(ctx, requestIDKey{}, "requests")
+	ms.Require().NoError(err)
+	ms.NotSame(again, other)
+	ms.Equal([]string{"requests-abc"}, ms.sut.Writers())
+
+	ms.NoError(ms.sut.CloseAll())
+}
+
+// blockingStore blocks the creation of one log stream until unblock is closed.
+type blockingStore struct {
+	*MemoryStore
+	streamName string
+	blocked    chan struct{}
+	unblock    chan struct{}
+}
+
+func (s *blockingStore) CreateLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogStreamInput, opts ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	if aws.StringValue(input.LogStreamName) == s.streamName {
+		close(s.blocked)
+		<-s.unblock
+	}
+	return s.MemoryStore.CreateLogStreamWithContext(ctx, input, opts...)
+}
+
+func (ms *memoryGroupTestSuite) TestCreateFromContext_Concurrent() {
+	store := &blockingStore{
+		MemoryStore: ms.store,
+		streamName:  "requests-slow",
+		blocked:     make(chan struct{}),
+		unblock:     make(chan struct{}),
+	}
+	group := NewGroup(store, "groupName")
+
+	slow := make(chan error, 1)
+	go func() {
+		_, err := group.CreateFromContext(context.WithValue(ms.ctx, requestIDKey{}, "slow"), requestIDKey{}, "requests")
+		slow <- err
+	}()
+	<-store.blocked
+
+	// Creating the writer of another value doesn't wait for the slow one.
+	_, err := group.CreateFromContext(context.WithValue(ms.ctx, requestIDKey{}, "fast"), requestIDKey{}, "requests")
+	ms.Require().NoError(err)
+	ms.Equal([]string{"requests-fast"}, group.Writers())
+
+	close(store.unblock)
+	ms.Require().NoError(<-slow)
+	ms.Equal([]string{"requests-fast", "requests-slow"}, group.Writers())
+	ms.NoError(group.CloseAll())
+}
+
+func (ms *memoryGroupTestSuite) TestResetDuringClose() {
+	store := &blockingStore{
+		MemoryStore: ms.store,
+		streamName:  "new",
+		blocked:     make(chan struct{}),
+		unblock:     make(chan struct{}),
+	}
+	group := NewGroup(store, "groupName")
+
+	writer, err := group.Create(ms.ctx, "old")
+	ms.Require().NoError(err)
+
+	reset := make(chan error, 1)
+	go func() {
+		reset <- writer.Reset(ms.ctx, "new")
+	}()
+	<-store.blocked
+
+	closed := make(chan error, 1)
+	go func() {
+		closed <- writer.Close()
+	}()
+
+	// Close waits for Reset, rather than unregistering the writer before
+	// Reset registers it under the new stream.
+	select {
+	case err := <-closed:
+		closed <- err
+		ms.Fail("Close didn't wait for Reset")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(store.unblock)
+	ms.Require().NoError(<-reset)
+	ms.Require().NoError(<-closed)
+	ms.Empty(group.Writers())
+}
+
+func (ms *memoryGroupTestSuite) TestCreateEphemeral() {
+	names := make(map[string]bool)
+	for i := 0; i < 10; i++ {
+		writer, name, err := ms.sut.CreateEphemeral(ms.ctx, "worker")
+		ms.Require().NoError(err)
+		ms.Regexp(`^worker-[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, name)
+		ms.False(names[name], "duplicate stream name %s", name)
+		names[name] = true
+		ms.NoError(writer.Close())
+	}
+
+	streams, err := ms.store.DescribeLogStreamsWithContext(ms.ctx, &cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName:        aws.String("groupName"),
+		LogStreamNamePrefix: aws.String("worker-"),
+	})
+	ms.Require().NoError(err)
+	ms.Len(streams.LogStreams, 10)
+}
+
+func TestMemoryGroup(t *testing.T) {
+	suite.Run(t, new(memoryGroupTestSuite))
+}
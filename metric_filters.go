@@ -0,0 +1,90 @@
+package cloudwatch
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+)
+
+// MetricFilterOptions describes a metric filter, publishing CloudWatch metrics
+// from the group's events matching a pattern. Its fields are those of
+// cloudwatchlogs.PutMetricFilterInput, except for the log group name.
+type MetricFilterOptions struct {
+	// FilterName identifies the filter. Putting a filter with the name of an
+	// existing one replaces it.
+	FilterName string
+
+	// FilterPattern selects the events to extract metrics from. An empty
+	// pattern matches all events.
+	FilterPattern string
+
+	// MetricTransformations describes the metrics published for matching
+	// events. The API only allows one.
+	MetricTransformations []*cloudwatchlogs.MetricTransformation
+}
+
+func (g *groupImpl) SetMetricFilter(ctx context.Context, opts MetricFilterOptions) error {
+	_, err := g.PutMetricFilterWithContext(ctx, &cloudwatchlogs.PutMetricFilterInput{
+		FilterName:            aws.String(opts.FilterName),
+		FilterPattern:         aws.String(opts.FilterPattern),
+		LogGroupName:          aws.String(g.groupName),
+		MetricTransformations: opts.MetricTransformations,
+	})
+
+	return errors.Wrap(err, "could not put the metric filter")
+}
+
+func (g *groupImpl) RemoveMetricFilter(ctx context.Context, filterName string) error {
+	_, err := g.DeleteMetricFilterWithContext(ctx, &cloudwatchlogs.DeleteMetricFilterInput{
+		FilterName:   aws.String(filterName),
+		LogGroupName: aws.String(g.groupName),
+	})
+
+	if _, ok := err.(*cloudwatchlogs.ResourceNotFoundException); ok {
+		return ErrNotFound
+	}
+
+	return errors.Wrap(err, "could not delete the metric filter")
+}
+
+func (g *groupImpl) ListMetricFilters(ctx context.Context) ([]cloudwatchlogs.MetricFilter, error) {
+	var ret []cloudwatchlogs.MetricFilter
+
+	input := &cloudwatchlogs.DescribeMetricFiltersInput{
+		LogGroupName: aws.String(g.groupName),
+	}
+
+	for {
+		resp, err := g.DescribeMetricFiltersWithContext(ctx, input)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list the metric filters")
+		}
+
+		for _, filter := range resp.MetricFilters {
+			ret = append(ret, *filter)
+		}
+
+		if resp.NextToken == nil {
+			return ret, nil
+		}
+		input.NextToken = resp.NextToken
+	}
+}
+
+func (g *groupImpl) MatchMetricFilter(ctx context.Context, filterPattern string, sampleLines []string) ([]cloudwatchlogs.MetricFilterMatchRecord, error) {
+	resp, err := g.TestMetricFilterWithContext(ctx, &cloudwatchlogs.TestMetricFilterInput{
+		FilterPattern:    aws.String(filterPattern),
+		LogEventMessages: aws.StringSlice(sampleLines),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not test the metric filter")
+	}
+
+	ret := make([]cloudwatchlogs.MetricFilterMatchRecord, 0, len(resp.Matches))
+	for _, match := range resp.Matches {
+		ret = append(ret, *match)
+	}
+	return ret, nil
+}
@@ -0,0 +1,44 @@
+package cloudwatch
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// Metrics receives measurements of a writer's activity, for example to export
+// them to a monitoring system. Implementations must be safe for concurrent
+// use, and may be shared by several writers.
+type Metrics interface {
+	// ObserveFlush is called after each batch of events is sent, or fails to
+	// be sent, to AWS CloudWatch Logs. The duration includes retries.
+	ObserveFlush(duration time.Duration, events, bytes int, err error)
+
+	// SetBufferSize is called with the number of buffered events whenever it
+	// changes significantly, that is after writes and flushes.
+	SetBufferSize(events int)
+}
+
+// WithMetrics reports measurements of the writer's activity to m.
+func WithMetrics(m Metrics) CreateOption {
+	return func(w *writerImpl) {
+		w.metrics = m
+	}
+}
+
+// reportBufferSize reports the number of buffered events, if the writer has
+// metrics.
+func (w *writerImpl) reportBufferSize() {
+	if w.metrics != nil {
+		w.metrics.SetBufferSize(w.buffered().len())
+	}
+}
+
+// batchBytes returns the size of the batch as counted towards the AWS limits.
+func batchBytes(batch []*cloudwatchlogs.InputLogEvent) int {
+	var ret int
+	for _, event := range batch {
+		ret += len(*event.Message) + paddingSize
+	}
+	return ret
+}
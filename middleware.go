@@ -0,0 +1,124 @@
+package cloudwatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// EventMiddleware transforms a log event before it's buffered. It may modify
+// the event in place or return a different one, and returning nil drops the
+// event.
+type EventMiddleware func(*cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent
+
+// EventEnricher modifies a log event in place using values from the writer's
+// context.
+type EventEnricher func(context.Context, *cloudwatchlogs.InputLogEvent)
+
+// RequestIDEnricher returns an enricher appending "fieldName=value" to each
+// message, where value is the string stored in the context under contextKey.
+// Messages are left unchanged if there's no such value.
+func RequestIDEnricher(contextKey interface{}, fieldName string) EventEnricher {
+	return func(ctx context.Context, event *cloudwatchlogs.InputLogEvent) {
+		value, ok := ctx.Value(contextKey).(string)
+		if !ok || value == "" {
+			return
+		}
+
+		// The field goes before the trailing newline, if any.
+		message := aws.StringValue(event.Message)
+		trimmed := strings.TrimSuffix(message, "\n")
+		field := fieldName + "=" + value
+		if trimmed != "" {
+			field = " " + field
+		}
+		event.Message = aws.String(trimmed + field + message[len(trimmed):])
+	}
+}
+
+// RedactRegexp returns middleware replacing matches of pattern in each message
+// with replacement, which may refer to submatches as in
+// regexp.Regexp.ReplaceAllString. It's meant for scrubbing personal data.
+func RedactRegexp(pattern *regexp.Regexp, replacement string) EventMiddleware {
+	return func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+		event.Message = aws.String(pattern.ReplaceAllString(aws.StringValue(event.Message), replacement))
+		return event
+	}
+}
+
+// AddField returns middleware setting the key field of messages which are JSON
+// objects to value. Other messages are left unchanged.
+func AddField(key, value string) EventMiddleware {
+	return func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+		fields, ok := jsonObject(aws.StringValue(event.Message))
+		if !ok {
+			return event
+		}
+
+		fields[key] = value
+		if b, err := json.Marshal(fields); err == nil {
+			event.Message = aws.String(string(b))
+		}
+		return event
+	}
+}
+
+// InjectJSONFields returns middleware adding fields to messages which are JSON
+// objects, before their closing brace, without decoding them. It's cheaper
+// than AddField, but fields already present in a message aren't replaced, so
+// it may end up with duplicate keys. Other messages are left unchanged.
+func InjectJSONFields(fields map[string]string) EventMiddleware {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// The encoded fields are the same for every message.
+	var injected []byte
+	for _, key := range keys {
+		k, _ := json.Marshal(key)
+		v, _ := json.Marshal(fields[key])
+		injected = append(append(append(append(injected, ','), k...), ':'), v...)
+	}
+
+	return func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+		message := aws.StringValue(event.Message)
+		object := strings.TrimSpace(message)
+		if len(injected) == 0 || !strings.HasPrefix(object, "{") || !strings.HasSuffix(object, "}") || !json.Valid([]byte(object)) {
+			return event
+		}
+
+		// Keep the whitespace around the object, such as a trailing newline.
+		end := strings.LastIndexByte(message, '}')
+		fields := injected
+		if strings.TrimSpace(object[1:len(object)-1]) == "" {
+			fields = fields[1:]
+		}
+		event.Message = aws.String(message[:end] + string(fields) + message[end:])
+		return event
+	}
+}
+
+// jsonObject decodes message if it's a JSON object, keeping numbers as they
+// were written.
+func jsonObject(message string) (map[string]interface{}, bool) {
+	trimmed := bytes.TrimSpace([]byte(message))
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, false
+	}
+
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, false
+	}
+	return fields, true
+}
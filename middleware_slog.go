@@ -0,0 +1,51 @@
+//go:build go1.21
+// +build go1.21
+
+package cloudwatch
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// FilterLevel returns middleware dropping JSON messages whose "level" field is
+// below minLevel, such as those written by NewSlogHandler. Messages without a
+// recognizable level are kept.
+func FilterLevel(minLevel slog.Level) EventMiddleware {
+	return func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+		fields, ok := jsonObject(aws.StringValue(event.Message))
+		if !ok {
+			return event
+		}
+
+		name, ok := fields[slog.LevelKey].(string)
+		if !ok {
+			return event
+		}
+
+		if level, ok := parseLevel(name); ok && level < minLevel {
+			return nil
+		}
+		return event
+	}
+}
+
+// parseLevel parses the level names used by slog, as well as the "warning",
+// "fatal" and "panic" names used by other logging libraries.
+func parseLevel(name string) (slog.Level, bool) {
+	switch strings.ToLower(name) {
+	case "warning":
+		return slog.LevelWarn, true
+	case "fatal", "panic":
+		return slog.LevelError, true
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, false
+	}
+	return level, true
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -13,16 +15,61 @@ type mockAPI struct {
 	iface.CloudWatchLogsAPI
 }
 
+func (m *mockAPI) CreateLogGroupWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogGroupInput, opts ...request.Option) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.CreateLogGroupOutput), args.Error(1)
+}
+
 func (m *mockAPI) CreateLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogStreamInput, opts ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
 	args := m.Called(ctx, input, opts)
 	return args.Get(0).(*cloudwatchlogs.CreateLogStreamOutput), args.Error(1)
 }
 
+func (m *mockAPI) CreateExportTaskWithContext(ctx aws.Context, input *cloudwatchlogs.CreateExportTaskInput, opts ...request.Option) (*cloudwatchlogs.CreateExportTaskOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.CreateExportTaskOutput), args.Error(1)
+}
+
+func (m *mockAPI) DeleteLogGroupWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteLogGroupInput, opts ...request.Option) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DeleteLogGroupOutput), args.Error(1)
+}
+
+func (m *mockAPI) DeleteLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteLogStreamInput, opts ...request.Option) (*cloudwatchlogs.DeleteLogStreamOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DeleteLogStreamOutput), args.Error(1)
+}
+
+func (m *mockAPI) DeleteSubscriptionFilterWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteSubscriptionFilterInput, opts ...request.Option) (*cloudwatchlogs.DeleteSubscriptionFilterOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DeleteSubscriptionFilterOutput), args.Error(1)
+}
+
+func (m *mockAPI) DeleteRetentionPolicyWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteRetentionPolicyInput, opts ...request.Option) (*cloudwatchlogs.DeleteRetentionPolicyOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DeleteRetentionPolicyOutput), args.Error(1)
+}
+
+func (m *mockAPI) DescribeExportTasksWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeExportTasksInput, opts ...request.Option) (*cloudwatchlogs.DescribeExportTasksOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DescribeExportTasksOutput), args.Error(1)
+}
+
 func (m *mockAPI) DescribeLogStreamsWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeLogStreamsInput, opts ...request.Option) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
 	args := m.Called(ctx, input, opts)
 	return args.Get(0).(*cloudwatchlogs.DescribeLogStreamsOutput), args.Error(1)
 }
 
+func (m *mockAPI) DescribeSubscriptionFiltersWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeSubscriptionFiltersInput, opts ...request.Option) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DescribeSubscriptionFiltersOutput), args.Error(1)
+}
+
+func (m *mockAPI) FilterLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.FilterLogEventsInput, opts ...request.Option) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.FilterLogEventsOutput), args.Error(1)
+}
+
 func (m *mockAPI) GetLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.GetLogEventsInput, opts ...request.Option) (*cloudwatchlogs.GetLogEventsOutput, error) {
 	args := m.Called(ctx, input, opts)
 	return args.Get(0).(*cloudwatchlogs.GetLogEventsOutput), args.Error(1)
@@ -32,3 +79,98 @@ func (m *mockAPI) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs
 	args := m.Called(ctx, input, opts)
 	return args.Get(0).(*cloudwatchlogs.PutLogEventsOutput), args.Error(1)
 }
+
+func (m *mockAPI) PutSubscriptionFilterWithContext(ctx aws.Context, input *cloudwatchlogs.PutSubscriptionFilterInput, opts ...request.Option) (*cloudwatchlogs.PutSubscriptionFilterOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.PutSubscriptionFilterOutput), args.Error(1)
+}
+
+func (m *mockAPI) PutMetricFilterWithContext(ctx aws.Context, input *cloudwatchlogs.PutMetricFilterInput, opts ...request.Option) (*cloudwatchlogs.PutMetricFilterOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.PutMetricFilterOutput), args.Error(1)
+}
+
+func (m *mockAPI) DeleteMetricFilterWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteMetricFilterInput, opts ...request.Option) (*cloudwatchlogs.DeleteMetricFilterOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DeleteMetricFilterOutput), args.Error(1)
+}
+
+func (m *mockAPI) DescribeMetricFiltersWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeMetricFiltersInput, opts ...request.Option) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DescribeMetricFiltersOutput), args.Error(1)
+}
+
+func (m *mockAPI) TestMetricFilterWithContext(ctx aws.Context, input *cloudwatchlogs.TestMetricFilterInput, opts ...request.Option) (*cloudwatchlogs.TestMetricFilterOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.TestMetricFilterOutput), args.Error(1)
+}
+
+func (m *mockAPI) PutDataProtectionPolicyWithContext(ctx aws.Context, input *cloudwatchlogs.PutDataProtectionPolicyInput, opts ...request.Option) (*cloudwatchlogs.PutDataProtectionPolicyOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.PutDataProtectionPolicyOutput), args.Error(1)
+}
+
+func (m *mockAPI) GetDataProtectionPolicyWithContext(ctx aws.Context, input *cloudwatchlogs.GetDataProtectionPolicyInput, opts ...request.Option) (*cloudwatchlogs.GetDataProtectionPolicyOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.GetDataProtectionPolicyOutput), args.Error(1)
+}
+
+func (m *mockAPI) DeleteDataProtectionPolicyWithContext(ctx aws.Context, input *cloudwatchlogs.DeleteDataProtectionPolicyInput, opts ...request.Option) (*cloudwatchlogs.DeleteDataProtectionPolicyOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DeleteDataProtectionPolicyOutput), args.Error(1)
+}
+
+func (m *mockAPI) PutRetentionPolicyWithContext(ctx aws.Context, input *cloudwatchlogs.PutRetentionPolicyInput, opts ...request.Option) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.PutRetentionPolicyOutput), args.Error(1)
+}
+
+func (m *mockAPI) DescribeLogGroupsWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeLogGroupsInput, opts ...request.Option) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DescribeLogGroupsOutput), args.Error(1)
+}
+
+func (m *mockAPI) StartLiveTailWithContext(ctx aws.Context, input *cloudwatchlogs.StartLiveTailInput, opts ...request.Option) (*cloudwatchlogs.StartLiveTailOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.StartLiveTailOutput), args.Error(1)
+}
+
+func (m *mockAPI) TagResourceWithContext(ctx aws.Context, input *cloudwatchlogs.TagResourceInput, opts ...request.Option) (*cloudwatchlogs.TagResourceOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.TagResourceOutput), args.Error(1)
+}
+
+func (m *mockAPI) UntagResourceWithContext(ctx aws.Context, input *cloudwatchlogs.UntagResourceInput, opts ...request.Option) (*cloudwatchlogs.UntagResourceOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.UntagResourceOutput), args.Error(1)
+}
+
+func (m *mockAPI) ListTagsForResourceWithContext(ctx aws.Context, input *cloudwatchlogs.ListTagsForResourceInput, opts ...request.Option) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.ListTagsForResourceOutput), args.Error(1)
+}
+
+func (m *mockAPI) AssociateKmsKeyWithContext(ctx aws.Context, input *cloudwatchlogs.AssociateKmsKeyInput, opts ...request.Option) (*cloudwatchlogs.AssociateKmsKeyOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.AssociateKmsKeyOutput), args.Error(1)
+}
+
+func (m *mockAPI) DisassociateKmsKeyWithContext(ctx aws.Context, input *cloudwatchlogs.DisassociateKmsKeyInput, opts ...request.Option) (*cloudwatchlogs.DisassociateKmsKeyOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.DisassociateKmsKeyOutput), args.Error(1)
+}
+
+func (m *mockAPI) GetLogRecordWithContext(ctx aws.Context, input *cloudwatchlogs.GetLogRecordInput, opts ...request.Option) (*cloudwatchlogs.GetLogRecordOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*cloudwatchlogs.GetLogRecordOutput), args.Error(1)
+}
+
+type mockSTS struct {
+	mock.Mock
+	stsiface.STSAPI
+}
+
+func (m *mockSTS) AssumeRoleWithContext(ctx aws.Context, input *sts.AssumeRoleInput, opts ...request.Option) (*sts.AssumeRoleOutput, error) {
+	args := m.Called(ctx, input, opts)
+	return args.Get(0).(*sts.AssumeRoleOutput), args.Error(1)
+}
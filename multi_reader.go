@@ -0,0 +1,185 @@
+package cloudwatch
+
+import (
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// maxConcurrentReads caps the number of GetLogEvents requests a multiReader
+// has in flight, so that reading many streams doesn't use up the account's
+// request quota.
+const maxConcurrentReads = 4
+
+// multiReader reads from several log streams and merges their events by
+// timestamp. The embedded readerImpl holds the merged buffer, the throttle and
+// the error state; streams are only used to fetch events.
+type multiReader struct {
+	*readerImpl
+
+	streams []*mergedStream
+}
+
+// mergedStream is a stream read by a multiReader, along with the events
+// fetched from it which weren't merged yet.
+type mergedStream struct {
+	*readerImpl
+
+	pending []*cloudwatchlogs.OutputLogEvent
+
+	// lastSeen is the timestamp of the last event fetched, if seen is true.
+	lastSeen int64
+	seen     bool
+
+	// caughtUp is true once the last read reached the end of the stream.
+	caughtUp bool
+}
+
+func (m *multiReader) start() {
+	m.poll(m.read)
+}
+
+// read fetches the next page of events from every stream whose fetched events
+// were all merged, then merges the pending events of all streams in timestamp
+// order. Since a stream's next page may hold events as old as the last one
+// fetched from it, events are only merged up to the smallest last seen
+// timestamp among streams which weren't read to the end; the others are kept
+// for the next round. When tailing, a stream which was read to the end doesn't
+// hold back the others, so older events written to it afterwards may be out
+// of order.
+//
+// Unless tailing, streams that have been read to the end are dropped, and
+// io.EOF is returned once there are none left.
+func (m *multiReader) read() error {
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, maxConcurrentReads)
+		errs = make([]error, len(m.streams))
+	)
+
+	for i, stream := range m.streams {
+		if len(stream.pending) > 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, stream *mergedStream) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			errs[i] = stream.fetch()
+		}(i, stream)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := m.merge(); err != nil {
+		return err
+	}
+
+	live := m.streams[:0]
+	for _, stream := range m.streams {
+		if m.tail || !stream.caughtUp || len(stream.pending) > 0 {
+			live = append(live, stream)
+		}
+	}
+	m.streams = live
+
+	if len(m.streams) == 0 {
+		return io.EOF
+	}
+
+	return nil
+}
+
+// fetch reads the next page of events of the stream into its pending events.
+func (s *mergedStream) fetch() error {
+	var events []*cloudwatchlogs.OutputLogEvent
+	s.onEvent = func(event *cloudwatchlogs.OutputLogEvent) error {
+		events = append(events, event)
+		return nil
+	}
+
+	token := s.nextToken
+	if err := s.read(); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if !s.seen || timestamp(event) > s.lastSeen {
+			s.lastSeen, s.seen = timestamp(event), true
+		}
+	}
+	s.pending = append(s.pending, events...)
+	s.caughtUp = len(events) == 0 && s.atEnd(token)
+
+	return nil
+}
+
+// merge emits the pending events of all streams in timestamp order, up to the
+// smallest last seen timestamp among streams which weren't read to the end.
+func (m *multiReader) merge() error {
+	watermark, bounded := int64(0), false
+	for _, stream := range m.streams {
+		if stream.caughtUp {
+			continue
+		}
+		if !stream.seen {
+			// Nothing is known yet about the events of the stream.
+			return nil
+		}
+		if !bounded || stream.lastSeen < watermark {
+			watermark, bounded = stream.lastSeen, true
+		}
+	}
+
+	for {
+		var next *mergedStream
+		for _, stream := range m.streams {
+			if len(stream.pending) == 0 {
+				continue
+			}
+			if next == nil || timestamp(stream.pending[0]) < timestamp(next.pending[0]) {
+				next = stream
+			}
+		}
+
+		if next == nil || (bounded && timestamp(next.pending[0]) > watermark) {
+			return nil
+		}
+
+		event := next.pending[0]
+		next.pending = next.pending[1:]
+		if err := m.emit(event); err != nil {
+			return err
+		}
+	}
+}
+
+// atEnd reports whether the last read, which was made with token, reached
+// the end of the stream.
+func (r *readerImpl) atEnd(token *string) bool {
+	if r.filterPattern != nil {
+		return r.nextToken == nil
+	}
+
+	// GetLogEvents returns the token it was given once there are no more
+	// events to read.
+	return token != nil && r.nextToken != nil && *token == *r.nextToken
+}
+
+func timestamp(event *cloudwatchlogs.OutputLogEvent) int64 {
+	if event.Timestamp == nil {
+		return 0
+	}
+	return *event.Timestamp
+}
@@ -0,0 +1,63 @@
+package cloudwatch
+
+import (
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// truncatedMarker is appended to messages cut down to fit the per-event size
+// limit, so that operators know data was lost.
+const truncatedMarker = "[TRUNCATED]"
+
+// ErrEventTooLarge is returned from Write when a log event exceeds the
+// CloudWatch Logs per-event size limit and OversizePolicyError is in effect.
+var ErrEventTooLarge = errors.New("log event exceeds the maximum event size")
+
+// OversizePolicy determines what happens to log events whose message exceeds
+// the CloudWatch Logs per-event size limit of 256 KB.
+type OversizePolicy int
+
+const (
+	// OversizePolicyTruncate truncates the message to fit the limit and marks
+	// it with a "[TRUNCATED]" suffix. This is the default.
+	OversizePolicyTruncate OversizePolicy = iota
+
+	// OversizePolicyDrop silently discards the event.
+	OversizePolicyDrop
+
+	// OversizePolicyError discards the event and returns ErrEventTooLarge
+	// from Write.
+	OversizePolicyError
+)
+
+// apply enforces the policy on a single message. It returns the message to be
+// sent, which is nil if the event should be dropped.
+func (p OversizePolicy) apply(message []byte) ([]byte, error) {
+	return p.applyLimit(message, maxEventMessageBytes)
+}
+
+// applyLimit is like apply, with a limit lower than the per-event one, to
+// leave room for data added afterwards.
+func (p OversizePolicy) applyLimit(message []byte, limit int) ([]byte, error) {
+	if len(message) <= limit {
+		return message, nil
+	}
+
+	switch p {
+	case OversizePolicyDrop:
+		return nil, nil
+	case OversizePolicyError:
+		return nil, ErrEventTooLarge
+	}
+
+	// Make sure not to cut a multi-byte character in half.
+	cut := limit - len(truncatedMarker)
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+
+	ret := make([]byte, 0, cut+len(truncatedMarker))
+	ret = append(ret, message[:cut]...)
+	return append(ret, truncatedMarker...), nil
+}
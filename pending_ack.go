@@ -0,0 +1,177 @@
+package cloudwatch
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+)
+
+// PendingBatch is a batch of events sent to a log stream, which wasn't
+// acknowledged yet.
+type PendingBatch struct {
+	ID     string
+	Events []*cloudwatchlogs.InputLogEvent
+}
+
+// PendingAckStore keeps the batches of events being sent, so that batches
+// whose requests were interrupted, for example by the process crashing, are
+// sent again by the next writer for the stream.
+type PendingAckStore interface {
+	// Put records a batch about to be sent to the stream.
+	Put(streamName string, batch PendingBatch) error
+
+	// Ack removes a batch once a response was received for it.
+	Ack(streamName string, id string) error
+
+	// Pending returns the batches of the stream which weren't acknowledged,
+	// in the order they were recorded.
+	Pending(streamName string) ([]PendingBatch, error)
+}
+
+// WithPendingAckStore makes the writer record each batch in store before
+// sending it, and remove it once a response is received. When creating a
+// writer, batches left in store for the stream are sent first, and Create
+// fails if they can't be.
+//
+// This provides at-least-once delivery of the batches being sent when the
+// process stops: a batch accepted just before is sent again, and CloudWatch
+// Logs only detects duplicates, with a DataAlreadyAcceptedException, when
+// they're sent using the same sequence token. Events still buffered are not
+// covered, unless the writer uses a DiskEventBuffer. Batches being retried
+// after a transient error are recorded again when they're sent.
+func WithPendingAckStore(store PendingAckStore) CreateOption {
+	return func(w *writerImpl) {
+		w.ackStore = store
+	}
+}
+
+// recordPending records the batch in the pending-ack store, if there's one,
+// and returns its ID.
+func (w *writerImpl) recordPending(events []*cloudwatchlogs.InputLogEvent) string {
+	if w.ackStore == nil {
+		return ""
+	}
+
+	id := newUUID()
+	if err := w.ackStore.Put(aws.StringValue(w.streamName), PendingBatch{ID: id, Events: events}); err != nil {
+		w.log().Error("could not record pending batch", "stream", aws.StringValue(w.streamName), "error", err)
+	}
+	return id
+}
+
+// ack removes the batch from the pending-ack store, if there's one.
+func (w *writerImpl) ack(id string) {
+	if w.ackStore == nil {
+		return
+	}
+
+	if err := w.ackStore.Ack(aws.StringValue(w.streamName), id); err != nil {
+		w.log().Error("could not acknowledge batch", "stream", aws.StringValue(w.streamName), "error", err)
+	}
+}
+
+// replayPending sends the batches left unacknowledged by a previous writer for
+// the stream. It's called before the writer is started.
+func (w *writerImpl) replayPending() error {
+	if w.ackStore == nil {
+		return nil
+	}
+
+	batches, err := w.ackStore.Pending(aws.StringValue(w.streamName))
+	if err != nil {
+		return errors.Wrap(err, "could not load pending batches")
+	}
+
+	for _, batch := range batches {
+		if events := w.dropOutOfRange(batch.Events); len(events) > 0 {
+			err := w.flush(events)
+			if _, rejected := err.(*RejectedLogEventsInfoError); err != nil && !rejected {
+				return errors.Wrap(err, "could not send pending batch")
+			}
+		}
+		w.log().Info("replayed pending batch", "stream", aws.StringValue(w.streamName), "id", batch.ID, "events", len(batch.Events))
+		w.ack(batch.ID)
+	}
+
+	return nil
+}
+
+// FilePendingAckStore returns a PendingAckStore backed by a JSON file, which is
+// loaded if it exists and rewritten every time a batch is recorded or
+// acknowledged. It's safe for concurrent use.
+func FilePendingAckStore(path string) (PendingAckStore, error) {
+	ret := &filePendingAckStore{path: path, batches: make(map[string][]PendingBatch)}
+
+	b, err := ioutil.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(b, &ret.batches)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "could not load pending batches")
+	}
+	if ret.batches == nil {
+		ret.batches = make(map[string][]PendingBatch)
+	}
+
+	return ret, nil
+}
+
+type filePendingAckStore struct {
+	path string
+
+	mu      sync.Mutex // This protects batches.
+	batches map[string][]PendingBatch
+}
+
+func (s *filePendingAckStore) Put(streamName string, batch PendingBatch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches[streamName] = append(s.batches[streamName], batch)
+	return s.save()
+}
+
+func (s *filePendingAckStore) Ack(streamName string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batches := s.batches[streamName]
+	for i, batch := range batches {
+		if batch.ID == id {
+			s.batches[streamName] = append(batches[:i:i], batches[i+1:]...)
+			break
+		}
+	}
+	if len(s.batches[streamName]) == 0 {
+		delete(s.batches, streamName)
+	}
+	return s.save()
+}
+
+func (s *filePendingAckStore) Pending(streamName string) ([]PendingBatch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]PendingBatch(nil), s.batches[streamName]...), nil
+}
+
+// save rewrites the file. The caller must hold the lock.
+func (s *filePendingAckStore) save() error {
+	b, err := json.Marshal(s.batches)
+	if err != nil {
+		return errors.Wrap(err, "could not encode pending batches")
+	}
+
+	// Write to a new file first, so that the store isn't lost if the process
+	// stops halfway.
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+		return errors.Wrap(err, "could not save pending batches")
+	}
+	return errors.Wrap(os.Rename(tmp, s.path), "could not save pending batches")
+}
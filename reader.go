@@ -1,14 +1,16 @@
 package cloudwatch
 
 import (
-	"bytes"
 	"context"
+	"io"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 )
 
 type readerImpl struct {
@@ -17,60 +19,350 @@ type readerImpl struct {
 	client iface.CloudWatchLogsAPI
 	ctx    context.Context
 
+	closeChan chan struct{}
+	closeOnce sync.Once
+
+	// This is held by the polling goroutine while requesting events, so that
+	// Seek doesn't change the token in the middle of a request.
+	pollMu sync.Mutex
+
+	// In tail mode the reader starts at the end of the stream, unless
+	// fromHead is set, and follows new events until it's closed.
+	tail, fromHead bool
+
+	// If set, the reader starts at the end of the stream and pages backwards
+	// using backward tokens, returning the newest events first.
+	reverse bool
+
+	// If set, the maximum number of events returned by each request.
+	limit *int64
+
+	// If set, forward tokens are saved to and resumed from this store.
+	tokenStore ReaderTokenStore
+
+	// In tail mode, the interval between polls doubles from throttleInterval
+	// up to maxPollInterval while no events are read. emitted counts the
+	// events read so far.
+	throttleInterval, maxPollInterval, pollInterval time.Duration
+	emitted                                         int
+
+	// Time range of events to read, in milliseconds since the epoch.
+	startTime, endTime *int64
+
+	// If set, events are read using FilterLogEvents and only those matching
+	// the pattern are returned. Polling resumes from the timestamp of the last
+	// event read, skipping the events with that timestamp which were already
+	// read, identified by their ID.
+	filterPattern *string
+	lastTimestamp *int64
+	lastEventIDs  map[string]struct{}
+
 	throttle *time.Ticker
-	buffer   lockingBuffer
+	limiter  *rate.Limiter // Shared with other readers, see WithReadRateLimit.
+	buffer   eventQueue
+
+	logger  GroupLogger
+	metrics GroupMetricsCollector
+
+	// If set, events are handed to this function instead of being written to
+	// the buffer.
+	onEvent func(*cloudwatchlogs.OutputLogEvent) error
+
+	// Error of an invalid option of the group, returned by the first request.
+	optionErr error
 
 	// If an error occurs when getting events from the stream, this will be
 	// populated and subsequent calls to Read will return the error.
-	err error
+	err   error
+	errMu sync.Mutex
+
+	// The last cacheSize bytes of messages read, starting at offset
+	// cacheStart, from which ReadAt is served. polled is closed after each
+	// request for events, and stopped is set once the reader stops.
+	cacheSize  int
+	cache      []byte
+	cacheStart int64
+	polled     chan struct{}
+	stopped    bool
+	cacheMu    sync.Mutex // This protects the fields above.
 }
 
-func (r *readerImpl) Read(b []byte) (int, error) {
-	// Return the AWS error if there is one.
-	if r.err != nil {
-		return 0, r.err
+// WithTailMode makes the reader skip to the end of the stream and follow new
+// events as they arrive, like tail -f. Once the reader is closed or its
+// context is done, Read returns io.EOF.
+func WithTailMode() ReadOption {
+	return func(r *readerImpl) {
+		r.tail = true
+	}
+}
+
+// WithReadFromHead makes a reader in tail mode start from the beginning of the
+// stream, rather than its end, before following new events. It has no effect
+// without WithTailMode, since readers start from the beginning by default.
+func WithReadFromHead() ReadOption {
+	return func(r *readerImpl) {
+		r.fromHead = true
+	}
+}
+
+// WithReadLimit sets the maximum number of events returned by each request to
+// AWS CloudWatch Logs, which is also the maximum, of 10,000, by default.
+// Smaller pages keep less data in memory at a time, at the cost of more
+// requests.
+func WithReadLimit(n int) ReadOption {
+	return func(r *readerImpl) {
+		if n > 0 && n <= getLogEventsLimit {
+			r.limit = aws.Int64(int64(n))
+		}
 	}
-	// If there is not data right now, return. Reading from the buffer would
-	// result in io.EOF being returned, which is not what we want.
-	if r.buffer.Len() == 0 {
-		return 0, nil
+}
+
+// WithReadThrottleInterval sets how often the reader requests events from AWS
+// CloudWatch Logs. Defaults to 100ms. This only paces the reader itself:
+// regardless of the interval, its requests also wait for the rate limit it
+// shares with the other readers of its group, set using WithReadRateLimit or
+// WithAccountReadLimit, so a short interval can't exceed that limit.
+func WithReadThrottleInterval(d time.Duration) ReadOption {
+	return func(r *readerImpl) {
+		if d <= 0 {
+			return
+		}
+		r.throttle.Stop()
+		r.throttle = time.NewTicker(d)
+		r.throttleInterval = d
+	}
+}
+
+// WithMaxPollInterval caps the interval between requests of a reader in tail
+// mode. Every request returning no events doubles the interval, starting from
+// the throttle interval, and any event resets it. Defaults to 30s.
+func WithMaxPollInterval(d time.Duration) ReadOption {
+	return func(r *readerImpl) {
+		r.maxPollInterval = d
+	}
+}
+
+// WithStartTime makes the reader skip events older than t.
+func WithStartTime(t time.Time) ReadOption {
+	return func(r *readerImpl) {
+		r.startTime = aws.Int64(toMillis(t))
+	}
+}
+
+// WithEndTime makes the reader skip events at or after t. It is ignored in
+// tail mode.
+func WithEndTime(t time.Time) ReadOption {
+	return func(r *readerImpl) {
+		r.endTime = aws.Int64(toMillis(t))
+	}
+}
+
+// WithFilterPattern makes the reader only return events matching pattern, which
+// uses the CloudWatch Logs filter and pattern syntax described in
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/logs/FilterAndPatternSyntax.html.
+//
+// Filtering happens server-side, which is particularly useful for tailing
+// high-volume streams. Since GetLogEvents does not support filter patterns,
+// the reader uses FilterLogEvents instead, limited to the reader's stream.
+func WithFilterPattern(pattern string) ReadOption {
+	return func(r *readerImpl) {
+		r.filterPattern = aws.String(pattern)
+	}
+}
+
+// WithReverseOrder makes the reader return events newest first, starting from
+// the end of the stream, for example to show the most recent errors first.
+// It's ignored in tail mode and with WithFilterPattern, since FilterLogEvents
+// only returns events oldest first, and tokens aren't saved to the store set
+// using WithReaderTokenStore.
+func WithReverseOrder() ReadOption {
+	return func(r *readerImpl) {
+		r.reverse = true
+	}
+}
+
+// WithReadAPIWrapper replaces the client used by the reader with the one
+// returned by fn, which is passed the group's client. It's the reading
+// counterpart of WithAPIWrapper.
+func WithReadAPIWrapper(fn func(iface.CloudWatchLogsAPI) iface.CloudWatchLogsAPI) ReadOption {
+	return func(r *readerImpl) {
+		r.client = fn(r.client)
+	}
+}
+
+func (r *readerImpl) Read(b []byte) (int, error) {
+	// If there is no data right now, return. The error is only returned once
+	// the buffer is drained.
+	if r.buffer.empty() {
+		// Return the AWS error if there is one.
+		return 0, r.getErr()
 	}
 	return r.buffer.Read(b)
 }
 
+func (r *readerImpl) NextEvent() (*cloudwatchlogs.OutputLogEvent, error) {
+	if event, ok := r.buffer.next(); ok {
+		return event, nil
+	}
+	return nil, r.getErr()
+}
+
+func (r *readerImpl) ReadN(n int) ([]*cloudwatchlogs.OutputLogEvent, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	events := make([]*cloudwatchlogs.OutputLogEvent, 0, n)
+	for len(events) < n {
+		event, err := r.NextEvent()
+		if err != nil {
+			return events, err
+		}
+		if event != nil {
+			events = append(events, event)
+			continue
+		}
+
+		// Wait for the next request for events. If the reader stopped in
+		// the meantime, NextEvent returns its error.
+		r.cacheMu.Lock()
+		if r.stopped {
+			r.cacheMu.Unlock()
+			continue
+		}
+		polled := r.pollChan()
+		r.cacheMu.Unlock()
+		<-polled
+
+		// Like ReadAt, the end of the stream is reached once a request
+		// returned no new events, unless tailing.
+		if !r.tail && r.buffer.empty() && r.getErr() == nil {
+			return events, io.EOF
+		}
+	}
+
+	return events, nil
+}
+
+func (r *readerImpl) Seek(token string) error {
+	if token == "" {
+		return errors.New("empty token")
+	}
+
+	r.pollMu.Lock()
+	defer r.pollMu.Unlock()
+
+	if err := r.getErr(); err != nil {
+		return err
+	}
+
+	r.nextToken = aws.String(token)
+	r.buffer.clear()
+	return nil
+}
+
 func (r *readerImpl) Close() error {
-	r.throttle.Stop()
+	r.closeOnce.Do(func() {
+		r.throttle.Stop()
+		close(r.closeChan)
+	})
 	return nil
 }
 
 func (r *readerImpl) start() {
+	r.poll(r.read)
+}
+
+// poll calls read on every tick until the reader is closed, its context is
+// done or read fails.
+func (r *readerImpl) poll(read func() error) {
 	for {
-		<-r.throttle.C
-		if r.err = r.read(); r.err != nil {
+		select {
+		case <-r.closeChan:
+			r.stop(nil)
+			return
+		case <-r.ctx.Done():
+			r.stop(r.ctx.Err())
+			return
+		case <-r.throttle.C:
+		}
+
+		r.pollMu.Lock()
+		emitted := r.emitted
+		err := read()
+		r.pollMu.Unlock()
+		if err != nil {
+			if r.ctx.Err() != nil {
+				err = r.ctx.Err()
+			}
+			r.stop(err)
 			return
 		}
+		r.notifyPolled(false)
+		r.adaptPollInterval(r.emitted > emitted)
+	}
+}
+
+// stop records the error to be returned once the buffer is drained. When
+// tailing, the reader being closed or its context being done marks the end of
+// the stream.
+func (r *readerImpl) stop(err error) {
+	if r.tail && (err == nil || err == r.ctx.Err()) {
+		err = io.EOF
 	}
+
+	r.errMu.Lock()
+	r.err = err
+	r.errMu.Unlock()
+
+	r.notifyPolled(true)
 }
 
 func (r *readerImpl) read() error {
+	if r.optionErr != nil {
+		return r.optionErr
+	}
+	if err := r.wait(); err != nil {
+		return err
+	}
+	if r.filterPattern != nil {
+		return r.readFiltered()
+	}
+
+	// When tailing, the first request starts from the end of the stream,
+	// unless reading from the head. Requests using a forward token must start
+	// from the head, and those using a backward token from the end.
 	input := &cloudwatchlogs.GetLogEventsInput{
 		LogGroupName:  r.groupName,
 		LogStreamName: r.streamName,
-		StartFromHead: aws.Bool(true),
+		StartFromHead: aws.Bool(!r.reverse && (!r.tail || r.fromHead || r.nextToken != nil)),
 		NextToken:     r.nextToken,
+		StartTime:     r.startTime,
+		EndTime:       r.endTime,
+		Limit:         r.limit,
 	}
 
+	start := time.Now()
 	resp, err := r.client.GetLogEventsWithContext(r.ctx, input)
 
 	if err != nil {
+		r.observeRead(start, 0, err)
 		return err
 	}
+	r.observeRead(start, len(resp.Events), nil)
 
-	// We want to re-use the existing token in the event that
-	// NextForwardToken is nil, which means there's no new messages to
-	// consume.
-	if resp.NextForwardToken != nil {
+	if r.reverse {
+		if resp.NextBackwardToken != nil {
+			r.nextToken = resp.NextBackwardToken
+		}
+	} else if resp.NextForwardToken != nil {
+		// We want to re-use the existing token in the event that
+		// NextForwardToken is nil, which means there's no new messages to
+		// consume.
 		r.nextToken = resp.NextForwardToken
+		if r.tokenStore != nil {
+			r.tokenStore.Save(aws.StringValue(r.streamName), *r.nextToken)
+		}
 	}
 
 	// If there are no messages, return so that the consumer can read again.
@@ -78,29 +370,297 @@ func (r *readerImpl) read() error {
 		return nil
 	}
 
+	// Each page is in ascending order, even when paging backwards.
+	events := resp.Events
+	if r.reverse {
+		events = make([]*cloudwatchlogs.OutputLogEvent, len(resp.Events))
+		for i, event := range resp.Events {
+			events[len(events)-1-i] = event
+		}
+	}
+
+	for _, event := range events {
+		if !r.inRange(event) {
+			continue
+		}
+		if err := r.emit(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readAll reads until the end of the stream, as of the last request, is
+// reached.
+func (r *readerImpl) readAll() error {
+	for {
+		token := r.nextToken
+		if err := r.read(); err != nil {
+			return err
+		}
+
+		// The filtered pages end without a token, while GetLogEvents returns
+		// the token it was given at the end of the stream.
+		if r.nextToken == nil || (r.filterPattern == nil && token != nil && *token == *r.nextToken) {
+			return nil
+		}
+	}
+}
+
+// wait blocks until the group's limiter allows another request.
+func (r *readerImpl) wait() error {
+	if r.limiter == nil {
+		return nil
+	}
+	return r.limiter.Wait(r.ctx)
+}
+
+// observeRead reports a request for events, if the group has metrics.
+func (r *readerImpl) observeRead(start time.Time, events int, err error) {
+	if r.metrics != nil {
+		r.metrics.ObserveRead(time.Since(start), events, err)
+	}
+}
+
+// readFiltered reads the next page of events matching the filter pattern. Once
+// all pages have been read, subsequent requests start from the timestamp of
+// the last event seen, since other events with the same timestamp may still be
+// ingested.
+func (r *readerImpl) readFiltered() error {
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName:   r.groupName,
+		LogStreamNames: []*string{r.streamName},
+		FilterPattern:  r.filterPattern,
+		NextToken:      r.nextToken,
+		StartTime:      r.startTime,
+		EndTime:        r.endTime,
+		Limit:          r.limit,
+	}
+
+	start := time.Now()
+	resp, err := r.client.FilterLogEventsWithContext(r.ctx, input)
+
+	if err != nil {
+		r.observeRead(start, 0, err)
+		return err
+	}
+	r.observeRead(start, len(resp.Events), nil)
+
 	for _, event := range resp.Events {
-		r.buffer.WriteString(*event.Message)
+		if r.seenFiltered(event) {
+			continue
+		}
+		err := r.emit(&cloudwatchlogs.OutputLogEvent{
+			IngestionTime: event.IngestionTime,
+			Message:       event.Message,
+			Timestamp:     event.Timestamp,
+		})
+		if err != nil {
+			return err
+		}
+		r.markFiltered(event)
+	}
+
+	r.nextToken = resp.NextToken
+	if r.nextToken == nil && r.lastTimestamp != nil {
+		r.startTime = aws.Int64(*r.lastTimestamp)
+	}
+
+	return nil
+}
+
+// seenFiltered returns whether event was already read, which happens when
+// polling resumes from the timestamp of the last event read.
+func (r *readerImpl) seenFiltered(event *cloudwatchlogs.FilteredLogEvent) bool {
+	if event.EventId == nil || r.lastTimestamp == nil || aws.Int64Value(event.Timestamp) != *r.lastTimestamp {
+		return false
+	}
+	_, ok := r.lastEventIDs[*event.EventId]
+	return ok
+}
+
+// markFiltered records event as the last event read.
+func (r *readerImpl) markFiltered(event *cloudwatchlogs.FilteredLogEvent) {
+	if event.Timestamp == nil {
+		return
+	}
+	if r.lastTimestamp == nil || *event.Timestamp != *r.lastTimestamp {
+		r.lastTimestamp = event.Timestamp
+		r.lastEventIDs = make(map[string]struct{})
+	}
+	if event.EventId != nil {
+		r.lastEventIDs[*event.EventId] = struct{}{}
+	}
+}
+
+// adaptPollInterval backs off polling while tailing a quiet stream, and resets
+// the interval as soon as events are read.
+func (r *readerImpl) adaptPollInterval(read bool) {
+	if !r.tail {
+		return
+	}
+
+	base := r.throttleInterval
+	if base <= 0 {
+		base = readThrottle
 	}
+	max := r.maxPollInterval
+	if max <= 0 {
+		max = defaultMaxPollInterval
+	}
+
+	current := r.pollInterval
+	if current <= 0 {
+		current = base
+	}
+
+	next := base
+	if !read {
+		if next = 2 * current; next > max {
+			next = max
+		}
+		if next < base {
+			next = base
+		}
+	}
+
+	if next != current {
+		r.throttle.Reset(next)
+	}
+	r.pollInterval = next
+}
 
+// emit hands the event to the consumer, writing its message to the buffer
+// unless onEvent is set.
+func (r *readerImpl) emit(event *cloudwatchlogs.OutputLogEvent) error {
+	r.emitted++
+	if r.onEvent != nil {
+		return r.onEvent(event)
+	}
+	r.buffer.push(event)
+	r.cacheMessage(*event.Message)
 	return nil
 }
 
-// lockingBuffer is a bytes.Buffer that locks Reads and Writes.
-type lockingBuffer struct {
+func (r *readerImpl) getErr() error {
+	r.errMu.Lock()
+	defer r.errMu.Unlock()
+	return r.err
+}
+
+// inRange reports whether the event falls within the requested time range.
+// CloudWatch filters events server-side already, this is just a safeguard.
+func (r *readerImpl) inRange(event *cloudwatchlogs.OutputLogEvent) bool {
+	if event.Timestamp == nil {
+		return true
+	}
+	if r.startTime != nil && *event.Timestamp < *r.startTime {
+		return false
+	}
+	return r.endTime == nil || *event.Timestamp < *r.endTime
+}
+
+// validate resolves conflicting options, and resumes from the saved token if
+// there is one.
+func (r *readerImpl) validate() {
+	if r.tail && r.endTime != nil {
+		r.log().Warn("WithEndTime is ignored in tail mode", "stream", aws.StringValue(r.streamName))
+		r.endTime = nil
+	}
+
+	if r.reverse && (r.tail || r.filterPattern != nil) {
+		r.log().Warn("WithReverseOrder is ignored in tail mode and with WithFilterPattern", "stream", aws.StringValue(r.streamName))
+		r.reverse = false
+	}
+
+	if r.tokenStore != nil && r.filterPattern == nil && !r.reverse && r.nextToken == nil {
+		if token, ok := r.tokenStore.Load(aws.StringValue(r.streamName)); ok {
+			r.nextToken = aws.String(token)
+		}
+	}
+
+	// FilterLogEvents cannot start from the end of the stream, so tailing
+	// starts from the current time instead.
+	if r.tail && !r.fromHead && r.filterPattern != nil && r.startTime == nil {
+		r.startTime = aws.Int64(toMillis(time.Now()))
+	}
+}
+
+// eventQueue holds the events read and not yet consumed, either as bytes by
+// Read or as events by NextEvent.
+type eventQueue struct {
 	sync.Mutex
-	bytes.Buffer
+	events []*cloudwatchlogs.OutputLogEvent
+
+	// The number of bytes of the first event's message already read.
+	offset int
+}
+
+func (q *eventQueue) push(event *cloudwatchlogs.OutputLogEvent) {
+	q.Lock()
+	defer q.Unlock()
+
+	q.events = append(q.events, event)
+}
+
+// Read reads the messages of the queued events, removing the events which were
+// read entirely.
+func (q *eventQueue) Read(b []byte) (int, error) {
+	q.Lock()
+	defer q.Unlock()
+
+	var n int
+	for n < len(b) && len(q.events) > 0 {
+		message := aws.StringValue(q.events[0].Message)
+		copied := copy(b[n:], message[q.offset:])
+		n += copied
+
+		if q.offset += copied; q.offset == len(message) {
+			q.pop()
+		}
+	}
+
+	return n, nil
+}
+
+// next removes the first event and returns it, skipping the rest of an event
+// partially consumed by Read.
+func (q *eventQueue) next() (*cloudwatchlogs.OutputLogEvent, bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	if len(q.events) > 0 && q.offset > 0 {
+		q.pop()
+	}
+	if len(q.events) == 0 {
+		return nil, false
+	}
+
+	event := q.events[0]
+	q.pop()
+	return event, true
+}
+
+// pop removes the first event. The caller must hold the lock.
+func (q *eventQueue) pop() {
+	q.events[0] = nil
+	q.events = q.events[1:]
+	q.offset = 0
 }
 
-func (r *lockingBuffer) Read(b []byte) (int, error) {
-	r.Lock()
-	defer r.Unlock()
+// clear removes all events.
+func (q *eventQueue) clear() {
+	q.Lock()
+	defer q.Unlock()
 
-	return r.Buffer.Read(b)
+	q.events = nil
+	q.offset = 0
 }
 
-func (r *lockingBuffer) Write(b []byte) (int, error) {
-	r.Lock()
-	defer r.Unlock()
+func (q *eventQueue) empty() bool {
+	q.Lock()
+	defer q.Unlock()
 
-	return r.Buffer.Write(b)
+	return len(q.events) == 0
 }
@@ -0,0 +1,117 @@
+package cloudwatch
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// defaultReadAtCacheBytes is the default size of the cache ReadAt is served
+// from.
+const defaultReadAtCacheBytes = 1 << 20
+
+// ErrOffsetEvicted is returned from ReadAt when the requested offset is no
+// longer in the reader's cache.
+var ErrOffsetEvicted = errors.New("offset is no longer cached")
+
+// WithReadAtCacheSize sets the number of bytes of the most recently read
+// messages kept to serve ReadAt. Defaults to 1 MiB, and 0 disables the cache.
+func WithReadAtCacheSize(n int) ReadOption {
+	return func(r *readerImpl) {
+		if n >= 0 {
+			r.cacheSize = n
+		}
+	}
+}
+
+// ReadAt implements io.ReaderAt, off being an offset in the concatenation of
+// the messages of all events read, regardless of what was consumed by Read.
+//
+// CloudWatch Logs can't seek to a byte offset, so ReadAt is served from a
+// cache of the last messages read, whose size is set using
+// WithReadAtCacheSize. Reading past the end of the cache waits for the reader
+// to fetch more events, while reading before its start fails with
+// ErrOffsetEvicted. Unless tailing, io.EOF is returned once a request
+// returned no new events.
+func (r *readerImpl) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+
+	grew := true
+	for {
+		r.cacheMu.Lock()
+		if off < r.cacheStart {
+			r.cacheMu.Unlock()
+			return 0, ErrOffsetEvicted
+		}
+
+		end := r.cacheStart + int64(len(r.cache))
+		if off+int64(len(p)) > end && grew && !r.stopped {
+			polled := r.pollChan()
+			r.cacheMu.Unlock()
+
+			<-polled
+
+			r.cacheMu.Lock()
+			grew = r.tail || r.cacheStart+int64(len(r.cache)) > end
+			r.cacheMu.Unlock()
+			continue
+		}
+
+		var n int
+		if off < end {
+			n = copy(p, r.cache[off-r.cacheStart:])
+		}
+		r.cacheMu.Unlock()
+
+		if n == len(p) {
+			return n, nil
+		}
+		if err := r.getErr(); err != nil {
+			return n, err
+		}
+		return n, io.EOF
+	}
+}
+
+// cacheMessage adds the message of an event read to the cache, evicting the
+// oldest bytes beyond the cache size.
+func (r *readerImpl) cacheMessage(message string) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	if r.cacheSize == 0 {
+		r.cacheStart += int64(len(message))
+		return
+	}
+
+	// Evicted bytes are released once appending reallocates the cache.
+	r.cache = append(r.cache, message...)
+	if excess := len(r.cache) - r.cacheSize; excess > 0 {
+		r.cache = r.cache[excess:]
+		r.cacheStart += int64(excess)
+	}
+}
+
+// pollChan returns a channel closed once the next request for events has been
+// made. The caller must hold the cache lock.
+func (r *readerImpl) pollChan() chan struct{} {
+	if r.polled == nil {
+		r.polled = make(chan struct{})
+	}
+	return r.polled
+}
+
+// notifyPolled wakes up calls to ReadAt waiting for a request for events,
+// after it was made or once the reader stopped.
+func (r *readerImpl) notifyPolled(stopped bool) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	r.stopped = r.stopped || stopped
+	if r.polled != nil {
+		close(r.polled)
+		r.polled = nil
+	}
+}
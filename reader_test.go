@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -20,7 +21,7 @@ type readerTestSuite struct {
 	api                   *mockAPI
 	ctx                   context.Context
 	groupName, streamName string
-	sut                   io.ReadCloser
+	sut                   Reader
 }
 
 func (r *readerTestSuite) SetupTest() {
@@ -31,6 +32,7 @@ func (r *readerTestSuite) SetupTest() {
 
 	r.sut = &readerImpl{
 		client:     r.api,
+		closeChan:  make(chan struct{}),
 		ctx:        r.ctx,
 		groupName:  aws.String(r.groupName),
 		streamName: aws.String(r.streamName),
@@ -98,6 +100,336 @@ func (r *readerTestSuite) TestBuffering() {
 	r.Equal("lo", string(buffer[:n]))
 }
 
+func (r *readerTestSuite) TestNextEvent() {
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("Hello"), Timestamp: aws.Int64(1000), IngestionTime: aws.Int64(1001)},
+			{Message: aws.String("World"), Timestamp: aws.Int64(2000), IngestionTime: aws.Int64(2001)},
+			{Message: aws.String("!"), Timestamp: aws.Int64(3000), IngestionTime: aws.Int64(3001)},
+		},
+	}, nil)
+
+	reader := r.sut.(*readerImpl)
+	r.NoError(reader.read())
+
+	event, err := reader.NextEvent()
+	r.NoError(err)
+	r.Equal(&cloudwatchlogs.OutputLogEvent{
+		Message:       aws.String("Hello"),
+		Timestamp:     aws.Int64(1000),
+		IngestionTime: aws.Int64(1001),
+	}, event)
+
+	// The rest of an event partially read is skipped.
+	buffer := make([]byte, 3)
+	n, err := reader.Read(buffer)
+	r.NoError(err)
+	r.Equal("Wor", string(buffer[:n]))
+
+	event, err = reader.NextEvent()
+	r.NoError(err)
+	r.Equal("!", *event.Message)
+
+	event, err = reader.NextEvent()
+	r.NoError(err)
+	r.Nil(event)
+}
+
+func (r *readerTestSuite) getLogEventsReturns(messages ...string) {
+	var events []*cloudwatchlogs.OutputLogEvent
+	for i, message := range messages {
+		events = append(events, &cloudwatchlogs.OutputLogEvent{Message: aws.String(message), Timestamp: aws.Int64(int64(1000 * (i + 1)))})
+	}
+
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{Events: events}, nil)
+}
+
+func (r *readerTestSuite) TestReadN() {
+	r.getLogEventsReturns("Hello", "World")
+	r.api.On("GetLogEventsWithContext", r.ctx, mock.Anything, []request.Option(nil)).Return(&cloudwatchlogs.GetLogEventsOutput{}, nil)
+	go r.sut.(*readerImpl).start()
+
+	events, err := r.sut.ReadN(2)
+	r.NoError(err)
+	r.Require().Len(events, 2)
+	r.Equal("Hello", *events[0].Message)
+	r.Equal("World", *events[1].Message)
+}
+
+func (r *readerTestSuite) TestReadN_Zero() {
+	events, err := r.sut.ReadN(0)
+	r.NoError(err)
+	r.Empty(events)
+	r.api.AssertNotCalled(r.T(), "GetLogEventsWithContext")
+}
+
+func (r *readerTestSuite) TestReadN_EndOfStream() {
+	r.getLogEventsReturns("Hello")
+	r.getLogEventsReturns("World")
+	r.api.On("GetLogEventsWithContext", r.ctx, mock.Anything, []request.Option(nil)).Return(&cloudwatchlogs.GetLogEventsOutput{}, nil)
+	go r.sut.(*readerImpl).start()
+
+	events, err := r.sut.ReadN(3)
+	r.Equal(io.EOF, err)
+	r.Require().Len(events, 2)
+	r.Equal("Hello", *events[0].Message)
+	r.Equal("World", *events[1].Message)
+}
+
+func (r *readerTestSuite) TestReaderTokenStore() {
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+		},
+		NextForwardToken: aws.String("next"),
+	}, nil)
+
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+			NextToken:     aws.String("next"),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("World"), Timestamp: aws.Int64(2000)},
+		},
+		NextForwardToken: aws.String("after"),
+	}, nil)
+
+	store := MemoryReaderTokenStore()
+	group := NewGroup(r.api, r.groupName).(*groupImpl)
+
+	first := group.newReader(r.ctx, r.streamName, WithReaderTokenStore(store))
+	defer first.Close()
+	r.NoError(first.read())
+
+	// A reader opened again resumes after "Hello".
+	second := group.newReader(r.ctx, r.streamName, WithReaderTokenStore(store))
+	defer second.Close()
+	r.NoError(second.read())
+
+	buffer := make([]byte, 10)
+	n, err := second.Read(buffer)
+	r.NoError(err)
+	r.Equal("World", string(buffer[:n]))
+
+	token, ok := store.Load(r.streamName)
+	r.True(ok)
+	r.Equal("after", token)
+}
+
+func (r *readerTestSuite) TestSeek() {
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("one"), Timestamp: aws.Int64(1000)},
+			{Message: aws.String("two"), Timestamp: aws.Int64(2000)},
+		},
+		NextForwardToken: aws.String("after-two"),
+	}, nil)
+
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+			NextToken:     aws.String("after-two"),
+		},
+		[]request.Option(nil),
+	).Twice().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("three"), Timestamp: aws.Int64(3000)},
+		},
+		NextForwardToken: aws.String("after-three"),
+	}, nil)
+
+	store := MemoryReaderTokenStore()
+	group := NewGroup(r.api, r.groupName).(*groupImpl)
+	reader := group.newReader(r.ctx, r.streamName, WithReaderTokenStore(store))
+	defer reader.Close()
+
+	// The token is saved after the first two events.
+	r.Require().NoError(reader.read())
+	saved, ok := store.Load(r.streamName)
+	r.Require().True(ok)
+
+	r.Require().NoError(reader.read())
+	event, err := reader.NextEvent()
+	r.Require().NoError(err)
+	r.Equal("one", aws.StringValue(event.Message))
+
+	// Seeking discards the buffered events, and the next read starts from
+	// the third event again.
+	r.Require().NoError(reader.Seek(saved))
+	event, err = reader.NextEvent()
+	r.NoError(err)
+	r.Nil(event)
+
+	r.Require().NoError(reader.read())
+	event, err = reader.NextEvent()
+	r.Require().NoError(err)
+	r.Equal("three", aws.StringValue(event.Message))
+
+	r.EqualError(reader.Seek(""), "empty token")
+	r.api.AssertExpectations(r.T())
+}
+
+func (r *readerTestSuite) TestReverseOrder() {
+	getLogEventsReturns := func(token, backwardToken *string, messages ...string) {
+		var events []*cloudwatchlogs.OutputLogEvent
+		for i, message := range messages {
+			events = append(events, &cloudwatchlogs.OutputLogEvent{Message: aws.String(message + "\n"), Timestamp: aws.Int64(int64(1000 * (i + 1)))})
+		}
+
+		r.api.On(
+			"GetLogEventsWithContext",
+			r.ctx,
+			&cloudwatchlogs.GetLogEventsInput{
+				LogGroupName:  aws.String(r.groupName),
+				LogStreamName: aws.String(r.streamName),
+				StartFromHead: aws.Bool(false),
+				NextToken:     token,
+			},
+			[]request.Option(nil),
+		).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+			Events:            events,
+			NextBackwardToken: backwardToken,
+			NextForwardToken:  aws.String("forward"),
+		}, nil)
+	}
+
+	// Pages are returned newest first, each in ascending order, until the
+	// backward token stops changing.
+	getLogEventsReturns(nil, aws.String("b1"), "three", "four")
+	getLogEventsReturns(aws.String("b1"), aws.String("b2"), "one", "two")
+	getLogEventsReturns(aws.String("b2"), aws.String("b2"))
+
+	reader := NewGroup(r.api, r.groupName).(*groupImpl).newReader(r.ctx, r.streamName, WithReverseOrder())
+	defer reader.Close()
+	r.Require().NoError(reader.readAll())
+
+	buffer := make([]byte, 100)
+	n, err := reader.Read(buffer)
+	r.NoError(err)
+	r.Equal("four\nthree\ntwo\none\n", string(buffer[:n]))
+	r.api.AssertExpectations(r.T())
+}
+
+func (r *readerTestSuite) TestPollBackoff() {
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		NextForwardToken: aws.String("next"),
+	}, nil)
+
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+			NextToken:     aws.String("next"),
+		},
+		[]request.Option(nil),
+	).Times(3).Return(&cloudwatchlogs.GetLogEventsOutput{
+		NextForwardToken: aws.String("next"),
+	}, nil)
+
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+			NextToken:     aws.String("next"),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+		},
+		NextForwardToken: aws.String("after"),
+	}, nil)
+
+	reader := r.sut.(*readerImpl)
+	for _, opt := range []ReadOption{
+		WithTailMode(),
+		WithReadFromHead(),
+		WithReadThrottleInterval(100 * time.Millisecond),
+		WithMaxPollInterval(500 * time.Millisecond),
+	} {
+		opt(reader)
+	}
+
+	// The interval doubles on each empty response up to the maximum, and is
+	// reset by the first event.
+	for _, expected := range []time.Duration{
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		500 * time.Millisecond,
+		500 * time.Millisecond,
+		100 * time.Millisecond,
+	} {
+		emitted := reader.emitted
+		r.NoError(reader.read())
+		reader.adaptPollInterval(reader.emitted > emitted)
+		r.Equal(expected, reader.pollInterval)
+	}
+
+	r.api.AssertExpectations(r.T())
+}
+
 func (r *readerTestSuite) TestEndOfFile() {
 	r.api.On(
 		"GetLogEventsWithContext",
@@ -188,6 +520,265 @@ func (r *readerTestSuite) TestReadError() {
 	r.EqualError(err, errorMessage)
 }
 
+func (r *readerTestSuite) TestTimeRange() {
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+			StartTime:     aws.Int64(2000),
+			EndTime:       aws.Int64(3000),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("Before"), Timestamp: aws.Int64(1999)},
+			{Message: aws.String("Start"), Timestamp: aws.Int64(2000)},
+			{Message: aws.String("End"), Timestamp: aws.Int64(2999)},
+			{Message: aws.String("After"), Timestamp: aws.Int64(3000)},
+		},
+	}, nil)
+
+	reader := r.sut.(*readerImpl)
+	WithStartTime(time.Unix(2, 0))(reader)
+	WithEndTime(time.Unix(3, 0))(reader)
+	reader.validate()
+
+	r.NoError(reader.read())
+
+	buffer := make([]byte, 100)
+	n, err := r.sut.Read(buffer)
+	r.NoError(err)
+	r.Equal("StartEnd", string(buffer[:n]))
+}
+
+func (r *readerTestSuite) TestEndTimeIgnoredInTailMode() {
+	reader := r.sut.(*readerImpl)
+	WithTailMode()(reader)
+	WithEndTime(time.Unix(3, 0))(reader)
+	reader.validate()
+
+	r.Nil(reader.endTime)
+}
+
+func (r *readerTestSuite) TestFilterPattern() {
+	const pattern = "ERROR"
+
+	r.api.On(
+		"FilterLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName:   aws.String(r.groupName),
+			LogStreamNames: []*string{aws.String(r.streamName)},
+			FilterPattern:  aws.String(pattern),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.FilterLogEventsOutput{
+		Events: []*cloudwatchlogs.FilteredLogEvent{
+			{EventId: aws.String("1"), Message: aws.String("ERROR one"), Timestamp: aws.Int64(1000)},
+		},
+		NextToken: aws.String("next"),
+	}, nil)
+
+	r.api.On(
+		"FilterLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName:   aws.String(r.groupName),
+			LogStreamNames: []*string{aws.String(r.streamName)},
+			FilterPattern:  aws.String(pattern),
+			NextToken:      aws.String("next"),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.FilterLogEventsOutput{
+		Events: []*cloudwatchlogs.FilteredLogEvent{
+			{EventId: aws.String("2"), Message: aws.String("ERROR two"), Timestamp: aws.Int64(2000)},
+		},
+	}, nil)
+
+	// Once all pages are read, polling resumes from the timestamp of the last
+	// event, skipping the events already read.
+	r.api.On(
+		"FilterLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName:   aws.String(r.groupName),
+			LogStreamNames: []*string{aws.String(r.streamName)},
+			FilterPattern:  aws.String(pattern),
+			StartTime:      aws.Int64(2000),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.FilterLogEventsOutput{
+		Events: []*cloudwatchlogs.FilteredLogEvent{
+			{EventId: aws.String("2"), Message: aws.String("ERROR two"), Timestamp: aws.Int64(2000)},
+			{EventId: aws.String("3"), Message: aws.String("ERROR three"), Timestamp: aws.Int64(2000)},
+		},
+	}, nil)
+
+	reader := r.sut.(*readerImpl)
+	WithFilterPattern(pattern)(reader)
+
+	r.NoError(reader.read())
+	r.NoError(reader.read())
+	r.NoError(reader.read())
+
+	buffer := make([]byte, 100)
+	n, err := r.sut.Read(buffer)
+	r.NoError(err)
+	r.Equal("ERROR oneERROR twoERROR three", string(buffer[:n]))
+	r.api.AssertExpectations(r.T())
+}
+
+func (r *readerTestSuite) TestTailMode() {
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(false),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+		},
+		NextForwardToken: aws.String("next"),
+	}, nil)
+
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+			NextToken:     aws.String("next"),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("World"), Timestamp: aws.Int64(1000)},
+		},
+		NextForwardToken: aws.String("next"),
+	}, nil)
+
+	// Events are read explicitly, so the reader must not poll on its own.
+	reader := r.sut.(*readerImpl)
+	reader.throttle = time.NewTicker(time.Hour)
+	WithTailMode()(reader)
+
+	r.NoError(reader.read())
+	r.NoError(reader.read())
+
+	buffer := make([]byte, 10)
+	n, err := r.sut.Read(buffer)
+	r.NoError(err)
+	r.Equal("HelloWorld", string(buffer[:n]))
+
+	r.Require().NoError(r.sut.Close())
+	reader.start()
+
+	_, err = r.sut.Read(buffer)
+	r.Equal(io.EOF, err)
+}
+
+func (r *readerTestSuite) TestTailModeFromHead() {
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+			Limit:         aws.Int64(10),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+		},
+		NextForwardToken: aws.String("next"),
+	}, nil)
+
+	reader := r.sut.(*readerImpl)
+	WithReadThrottleInterval(time.Hour)(reader)
+	WithTailMode()(reader)
+	WithReadFromHead()(reader)
+	WithReadLimit(10)(reader)
+
+	r.NoError(reader.read())
+
+	buffer := make([]byte, 10)
+	n, err := r.sut.Read(buffer)
+	r.NoError(err)
+	r.Equal("Hello", string(buffer[:n]))
+}
+
+func (r *readerTestSuite) TestTailModeContextCancelled() {
+	ctx, cancel := context.WithCancel(r.ctx)
+	cancel()
+
+	r.sut = NewGroup(r.api, r.groupName).Open(ctx, r.streamName, WithTailMode())
+
+	buffer := new(bytes.Buffer)
+	_, err := io.Copy(buffer, r.sut)
+	r.NoError(err)
+	r.Empty(buffer.String())
+}
+
+func (r *readerTestSuite) TestReadAt() {
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+			{Message: aws.String("World"), Timestamp: aws.Int64(1000)},
+		},
+		NextForwardToken: aws.String("next"),
+	}, nil)
+
+	r.api.On(
+		"GetLogEventsWithContext",
+		r.ctx,
+		&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(r.groupName),
+			LogStreamName: aws.String(r.streamName),
+			StartFromHead: aws.Bool(true),
+			NextToken:     aws.String("next"),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.GetLogEventsOutput{NextForwardToken: aws.String("next")}, nil)
+
+	reader := r.sut.(*readerImpl)
+	WithReadAtCacheSize(8)(reader)
+	go reader.start()
+
+	// The cache only holds "lloWorld".
+	buffer := make([]byte, 4)
+	n, err := reader.ReadAt(buffer, 3)
+	r.NoError(err)
+	r.Equal("loWo", string(buffer[:n]))
+
+	_, err = reader.ReadAt(buffer, 1)
+	r.Equal(ErrOffsetEvicted, err)
+
+	// The next request returns no events, which is the end of the stream.
+	buffer = make([]byte, 10)
+	n, err = reader.ReadAt(buffer, 6)
+	r.Equal(io.EOF, err)
+	r.Equal("orld", string(buffer[:n]))
+}
+
 func TestReader(t *testing.T) {
 	suite.Run(t, new(readerTestSuite))
 }
@@ -0,0 +1,294 @@
+package cloudwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/pkg/errors"
+)
+
+// ErrRecordingNotFound is returned by a RecordingStore when loading a key which
+// was never saved.
+var ErrRecordingNotFound = errors.New("recording not found")
+
+// RecordingStore persists the requests and responses captured by a recorder.
+// Values are JSON-serializable.
+type RecordingStore interface {
+	// Load decodes the value saved under key into v. It returns
+	// ErrRecordingNotFound if there is none.
+	Load(key string, v interface{}) error
+
+	// Save saves v under key, replacing any previous value.
+	Save(key string, v interface{}) error
+}
+
+// NewRecorder returns a client calling client and saving each request and its
+// response to store, to be served later by a client returned by NewReplayer.
+// The calls recorded are those made to create and write to log streams:
+// CreateLogStream, DescribeLogStreams, PutLogEvents and GetLogEvents. Other
+// calls are passed through without being recorded.
+//
+// Calls are keyed by operation and order, so that recordings can be replayed
+// even though the timestamps of the events differ between runs.
+func NewRecorder(client iface.CloudWatchLogsAPI, store RecordingStore) iface.CloudWatchLogsAPI {
+	return &recorder{CloudWatchLogsAPI: client, tape: newTape(store)}
+}
+
+// NewReplayer returns a client serving the responses recorded in store by a
+// client returned by NewRecorder, in the order they were recorded. Calls which
+// are not recorded panic.
+func NewReplayer(store RecordingStore) iface.CloudWatchLogsAPI {
+	return &replayer{tape: newTape(store)}
+}
+
+// recording is a request and its response, as saved to a RecordingStore.
+type recording struct {
+	Input  interface{}     `json:"input"`
+	Output json.RawMessage `json:"output,omitempty"`
+	Error  *recordedError  `json:"error,omitempty"`
+}
+
+// recordedError is an AWS error, including the fields needed to recover from
+// sequence token errors.
+type recordedError struct {
+	Code                  string  `json:"code"`
+	Message               string  `json:"message"`
+	ExpectedSequenceToken *string `json:"expectedSequenceToken,omitempty"`
+}
+
+func newRecordedError(err error) *recordedError {
+	ret := &recordedError{Code: "Unknown", Message: err.Error()}
+
+	if awsErr, ok := err.(awserr.Error); ok {
+		ret.Code = awsErr.Code()
+		ret.Message = awsErr.Message()
+	}
+
+	switch err := err.(type) {
+	case *cloudwatchlogs.InvalidSequenceTokenException:
+		ret.ExpectedSequenceToken = err.ExpectedSequenceToken
+	case *cloudwatchlogs.DataAlreadyAcceptedException:
+		ret.ExpectedSequenceToken = err.ExpectedSequenceToken
+	}
+
+	return ret
+}
+
+// err returns the error as the type returned by the SDK, since the writer
+// handles some of them specifically.
+func (e *recordedError) err() error {
+	message := aws.String(e.Message)
+
+	switch e.Code {
+	case cloudwatchlogs.ErrCodeInvalidSequenceTokenException:
+		return &cloudwatchlogs.InvalidSequenceTokenException{Message_: message, ExpectedSequenceToken: e.ExpectedSequenceToken}
+	case cloudwatchlogs.ErrCodeDataAlreadyAcceptedException:
+		return &cloudwatchlogs.DataAlreadyAcceptedException{Message_: message, ExpectedSequenceToken: e.ExpectedSequenceToken}
+	case cloudwatchlogs.ErrCodeResourceNotFoundException:
+		return &cloudwatchlogs.ResourceNotFoundException{Message_: message}
+	case cloudwatchlogs.ErrCodeResourceAlreadyExistsException:
+		return &cloudwatchlogs.ResourceAlreadyExistsException{Message_: message}
+	case cloudwatchlogs.ErrCodeInvalidParameterException:
+		return &cloudwatchlogs.InvalidParameterException{Message_: message}
+	}
+
+	return awserr.New(e.Code, e.Message, nil)
+}
+
+// tape numbers the calls made for each operation.
+type tape struct {
+	store RecordingStore
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newTape(store RecordingStore) *tape {
+	return &tape{store: store, calls: make(map[string]int)}
+}
+
+// next returns the key of the next call to operation.
+func (t *tape) next(operation string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ret := fmt.Sprintf("%s/%d", operation, t.calls[operation])
+	t.calls[operation]++
+	return ret
+}
+
+func (t *tape) record(operation string, input, output interface{}, err error) error {
+	rec := recording{Input: input}
+
+	if err != nil {
+		rec.Error = newRecordedError(err)
+	} else {
+		raw, marshalErr := json.Marshal(output)
+		if marshalErr != nil {
+			return errors.Wrap(marshalErr, "could not encode the response")
+		}
+		rec.Output = raw
+	}
+
+	return errors.Wrap(t.store.Save(t.next(operation), rec), "could not save the recording")
+}
+
+// replay decodes the next recorded response to operation into output, and
+// returns the recorded error if there is one.
+func (t *tape) replay(operation string, output interface{}) error {
+	key := t.next(operation)
+
+	var rec recording
+	if err := t.store.Load(key, &rec); err != nil {
+		return errors.Wrapf(err, "could not load the recording of %s", key)
+	}
+
+	if rec.Error != nil {
+		return rec.Error.err()
+	}
+
+	return errors.Wrapf(json.Unmarshal(rec.Output, output), "could not decode the recording of %s", key)
+}
+
+type recorder struct {
+	iface.CloudWatchLogsAPI
+	tape *tape
+}
+
+func (r *recorder) CreateLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogStreamInput, opts ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	resp, err := r.CloudWatchLogsAPI.CreateLogStreamWithContext(ctx, input, opts...)
+	if recordErr := r.tape.record("CreateLogStream", input, resp, err); recordErr != nil {
+		return nil, recordErr
+	}
+	return resp, err
+}
+
+func (r *recorder) DescribeLogStreamsWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeLogStreamsInput, opts ...request.Option) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	resp, err := r.CloudWatchLogsAPI.DescribeLogStreamsWithContext(ctx, input, opts...)
+	if recordErr := r.tape.record("DescribeLogStreams", input, resp, err); recordErr != nil {
+		return nil, recordErr
+	}
+	return resp, err
+}
+
+func (r *recorder) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.PutLogEventsInput, opts ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	resp, err := r.CloudWatchLogsAPI.PutLogEventsWithContext(ctx, input, opts...)
+	if recordErr := r.tape.record("PutLogEvents", input, resp, err); recordErr != nil {
+		return nil, recordErr
+	}
+	return resp, err
+}
+
+func (r *recorder) GetLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.GetLogEventsInput, opts ...request.Option) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	resp, err := r.CloudWatchLogsAPI.GetLogEventsWithContext(ctx, input, opts...)
+	if recordErr := r.tape.record("GetLogEvents", input, resp, err); recordErr != nil {
+		return nil, recordErr
+	}
+	return resp, err
+}
+
+type replayer struct {
+	iface.CloudWatchLogsAPI
+	tape *tape
+}
+
+func (r *replayer) CreateLogStreamWithContext(ctx aws.Context, input *cloudwatchlogs.CreateLogStreamInput, opts ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	ret := new(cloudwatchlogs.CreateLogStreamOutput)
+	if err := r.tape.replay("CreateLogStream", ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *replayer) DescribeLogStreamsWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeLogStreamsInput, opts ...request.Option) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	ret := new(cloudwatchlogs.DescribeLogStreamsOutput)
+	if err := r.tape.replay("DescribeLogStreams", ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *replayer) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.PutLogEventsInput, opts ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	ret := new(cloudwatchlogs.PutLogEventsOutput)
+	if err := r.tape.replay("PutLogEvents", ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *replayer) GetLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.GetLogEventsInput, opts ...request.Option) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	ret := new(cloudwatchlogs.GetLogEventsOutput)
+	if err := r.tape.replay("GetLogEvents", ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// FileRecordingStore is a RecordingStore backed by a JSON file, which is
+// rewritten on each call to Save. It's safe for concurrent use.
+type FileRecordingStore struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]json.RawMessage
+}
+
+// NewFileRecordingStore returns a FileRecordingStore backed by the file at
+// path, loading the recordings it contains if it exists.
+func NewFileRecordingStore(path string) (*FileRecordingStore, error) {
+	ret := &FileRecordingStore{path: path, entries: make(map[string]json.RawMessage)}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ret, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not read the recordings")
+	}
+
+	if err := json.Unmarshal(b, &ret.entries); err != nil {
+		return nil, errors.Wrap(err, "could not decode the recordings")
+	}
+
+	return ret, nil
+}
+
+// Load implements RecordingStore.
+func (s *FileRecordingStore) Load(key string, v interface{}) error {
+	s.mu.Lock()
+	raw, ok := s.entries[key]
+	s.mu.Unlock()
+
+	if !ok {
+		return ErrRecordingNotFound
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+// Save implements RecordingStore.
+func (s *FileRecordingStore) Save(key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = raw
+
+	b, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(s.path, b, 0644)
+}
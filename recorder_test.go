@@ -0,0 +1,102 @@
+package cloudwatch
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type recorderTestSuite struct {
+	suite.Suite
+	ctx  context.Context
+	dir  string
+	path string
+}
+
+func (rs *recorderTestSuite) SetupTest() {
+	var err error
+	rs.dir, err = ioutil.TempDir("", "cloudwatch")
+	rs.Require().NoError(err)
+
+	rs.ctx = context.Background()
+	rs.path = filepath.Join(rs.dir, "recordings.json")
+}
+
+func (rs *recorderTestSuite) TearDownTest() {
+	os.RemoveAll(rs.dir)
+}
+
+func (rs *recorderTestSuite) TestRecordAndReplay() {
+	getInput := &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String("groupName"),
+		LogStreamName: aws.String("streamName"),
+		StartFromHead: aws.Bool(true),
+	}
+
+	// Record against the in-memory store.
+	_, store := NewMemoryGroup("groupName")
+	recordings, err := NewFileRecordingStore(rs.path)
+	rs.Require().NoError(err)
+
+	client := NewRecorder(store, recordings)
+	rs.write(client)
+
+	recorded, err := client.GetLogEventsWithContext(rs.ctx, getInput)
+	rs.Require().NoError(err)
+	rs.Require().Len(recorded.Events, 1)
+
+	// Replay from the file.
+	recordings, err = NewFileRecordingStore(rs.path)
+	rs.Require().NoError(err)
+
+	client = NewReplayer(recordings)
+	rs.write(client)
+
+	replayed, err := client.GetLogEventsWithContext(rs.ctx, getInput)
+	rs.Require().NoError(err)
+	rs.Equal(recorded, replayed)
+
+	_, err = client.GetLogEventsWithContext(rs.ctx, getInput)
+	rs.Equal(ErrRecordingNotFound, errors.Cause(err))
+}
+
+func (rs *recorderTestSuite) TestReplayErrors() {
+	_, store := NewMemoryGroup("groupName")
+	recordings, err := NewFileRecordingStore(rs.path)
+	rs.Require().NoError(err)
+
+	putInput := &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String("groupName"),
+		LogStreamName: aws.String("streamName"),
+	}
+
+	_, err = NewRecorder(store, recordings).PutLogEventsWithContext(rs.ctx, putInput)
+	rs.IsType(new(cloudwatchlogs.ResourceNotFoundException), err)
+
+	_, err = NewReplayer(recordings).PutLogEventsWithContext(rs.ctx, putInput)
+	rs.IsType(new(cloudwatchlogs.ResourceNotFoundException), err)
+	rs.EqualError(err, "ResourceNotFoundException: The specified log stream does not exist")
+}
+
+func (rs *recorderTestSuite) write(client iface.CloudWatchLogsAPI) {
+	writer, err := NewGroup(client, "groupName").Create(rs.ctx, "streamName")
+	rs.Require().NoError(err)
+
+	_, err = io.WriteString(writer, "Hello\n")
+	rs.Require().NoError(err)
+	rs.Require().NoError(writer.Flush())
+	rs.Require().NoError(writer.Close())
+}
+
+func TestRecorder(t *testing.T) {
+	suite.Run(t, new(recorderTestSuite))
+}
@@ -0,0 +1,56 @@
+package cloudwatch
+
+import (
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// RedactionRule replaces the matches of Pattern in log event messages with
+// Replacement, which is used literally: "$1" isn't expanded, unlike with
+// RedactRegexp.
+type RedactionRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+var (
+	emailPattern      = regexp.MustCompile(`[\w.+-]+@[\w-]+(\.[\w-]+)+`)
+	creditCardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	ipAddressPattern  = regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b|\b(?:[0-9a-fA-F]{1,4}:){7}[0-9a-fA-F]{1,4}\b`)
+)
+
+// RedactEmail returns a rule replacing email addresses with "[EMAIL]".
+func RedactEmail() RedactionRule {
+	return RedactionRule{Pattern: emailPattern, Replacement: "[EMAIL]"}
+}
+
+// RedactCreditCard returns a rule replacing sequences of 13 to 19 digits,
+// optionally separated by spaces or dashes, with "[CREDIT_CARD]". It doesn't
+// check the Luhn digit, so other long numbers are redacted too.
+func RedactCreditCard() RedactionRule {
+	return RedactionRule{Pattern: creditCardPattern, Replacement: "[CREDIT_CARD]"}
+}
+
+// RedactIPAddress returns a rule replacing IPv4 addresses and uncompressed
+// IPv6 addresses with "[IP]".
+func RedactIPAddress() RedactionRule {
+	return RedactionRule{Pattern: ipAddressPattern, Replacement: "[IP]"}
+}
+
+// NewRedactionMiddleware returns middleware applying rules to each message, in
+// order, for example to keep personal data out of CloudWatch Logs. Patterns
+// are matched in linear time, as Go regular expressions don't backtrack.
+func NewRedactionMiddleware(rules []RedactionRule) EventMiddleware {
+	rules = append([]RedactionRule(nil), rules...)
+
+	return func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+		message := aws.StringValue(event.Message)
+		for _, rule := range rules {
+			message = rule.Pattern.ReplaceAllLiteralString(message, rule.Replacement)
+		}
+		event.Message = aws.String(message)
+		return event
+	}
+}
@@ -0,0 +1,133 @@
+package cloudwatch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// MultiError is returned by Group.CloseAll when closing several writers
+// failed, holding one error per writer.
+type MultiError []error
+
+func (e MultiError) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// register keeps track of the writer until it's closed.
+func (g *groupImpl) register(w *writerImpl, streamName string) {
+	g.writers.Store(w, streamName)
+
+	w.onClose = w.unregister
+}
+
+// unregister stops keeping track of the writer, if it was created by a group.
+func (w *writerImpl) unregister() {
+	if w.group != nil {
+		w.group.writers.Delete(w)
+	}
+}
+
+// openWriter returns an open writer for the stream which can still be written
+// to, if there's one. Writers whose context is done or which failed are
+// forgotten.
+func (g *groupImpl) openWriter(streamName string) *writerImpl {
+	var ret *writerImpl
+	g.writers.Range(func(key, value interface{}) bool {
+		w := key.(*writerImpl)
+		switch {
+		case value.(string) != streamName:
+			return true
+		case w.ctx.Err() != nil || w.getErr() != nil:
+			g.writers.Delete(w)
+			return true
+		}
+
+		ret = w
+		return false
+	})
+	return ret
+}
+
+// contextStreamKey locks the creation of a writer by CreateFromContext. It's
+// distinct from the stream name locked by openStream, which is taken while the
+// writer is created.
+type contextStreamKey string
+
+func (g *groupImpl) CreateFromContext(ctx context.Context, key interface{}, streamPrefix string, opts ...CreateOption) (Writer, error) {
+	value, ok := ctx.Value(key).(string)
+	if !ok || value == "" {
+		return nil, errors.Errorf("context has no string value for key %v", key)
+	}
+	streamName := streamPrefix + "-" + value
+
+	// Creations for the same value are serialized so that concurrent calls
+	// share a writer, without waiting for those for other values.
+	unlock := g.locker.Lock(contextStreamKey(streamName))
+	defer unlock()
+
+	if w := g.openWriter(streamName); w != nil {
+		return w, nil
+	}
+	return g.Create(ctx, streamName, opts...)
+}
+
+func (g *groupImpl) Writers() []string {
+	seen := make(map[string]bool)
+	var ret []string
+	g.writers.Range(func(_, value interface{}) bool {
+		if name := value.(string); !seen[name] {
+			seen[name] = true
+			ret = append(ret, name)
+		}
+		return true
+	})
+
+	sort.Strings(ret)
+	return ret
+}
+
+func (g *groupImpl) CloseAll() error {
+	var writers []*writerImpl
+	g.writers.Range(func(key, _ interface{}) bool {
+		writers = append(writers, key.(*writerImpl))
+		return true
+	})
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs MultiError
+	)
+
+	for _, w := range writers {
+		wg.Add(1)
+		go func(w *writerImpl) {
+			defer wg.Done()
+
+			if err := w.Close(); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,140 @@
+package cloudwatch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"regexp"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// defaultRouteStream is the log stream written to by a router for lines which
+// don't match any route, unless a catch-all route is given.
+const defaultRouteStream = "default"
+
+// Route sends the lines matching Pattern to the StreamName log stream. A nil
+// Pattern matches every line.
+type Route struct {
+	Pattern    *regexp.Regexp
+	StreamName string
+}
+
+type router struct {
+	group  Group
+	ctx    context.Context
+	routes []Route
+	opts   []CreateOption
+
+	writers map[string]WriteFlushCloser
+	closed  bool
+
+	sync.Mutex // This protects writers and closed.
+}
+
+// NewRouter returns an io.WriteCloser sending each line written to it to the
+// log stream of the first route whose pattern it matches. Lines matching no
+// route are sent to a stream named "default", so to change it, end routes with
+// a Route with a nil Pattern.
+//
+// Streams are created using opts when the first line is sent to them. Closing
+// the router closes all of them.
+func NewRouter(g Group, ctx context.Context, routes []Route, opts ...CreateOption) (io.WriteCloser, error) {
+	for _, route := range routes {
+		if route.StreamName == "" {
+			return nil, errors.New("routes must have a stream name")
+		}
+	}
+
+	return &router{
+		group:   g,
+		ctx:     ctx,
+		routes:  routes,
+		opts:    opts,
+		writers: make(map[string]WriteFlushCloser),
+	}, nil
+}
+
+func (r *router) Write(b []byte) (int, error) {
+	return forEachLine(b, func(line []byte) error {
+		writer, err := r.writer(r.route(line))
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(line)
+		return err
+	})
+}
+
+// forEachLine calls fn with each line of b, including its trailing newline,
+// and returns the number of bytes in the lines for which fn succeeded.
+func forEachLine(b []byte, fn func(line []byte) error) (int, error) {
+	reader := bufio.NewReader(bytes.NewReader(b))
+
+	var n int
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if err := fn(line); err != nil {
+				return n, err
+			}
+			n += len(line)
+		}
+		if err != nil {
+			return n, nil
+		}
+	}
+}
+
+// route returns the name of the log stream line should be sent to.
+func (r *router) route(line []byte) string {
+	for _, route := range r.routes {
+		if route.Pattern == nil || route.Pattern.Match(line) {
+			return route.StreamName
+		}
+	}
+	return defaultRouteStream
+}
+
+// writer returns the writer for streamName, creating it if needed.
+func (r *router) writer(streamName string) (WriteFlushCloser, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.closed {
+		return nil, io.ErrClosedPipe
+	}
+
+	if writer, ok := r.writers[streamName]; ok {
+		return writer, nil
+	}
+
+	writer, err := r.group.Create(r.ctx, streamName, r.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r.writers[streamName] = writer
+	return writer, nil
+}
+
+// Close closes all the streams written to, returning the first error.
+func (r *router) Close() error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	var ret error
+	for _, writer := range r.writers {
+		if err := writer.Close(); err != nil && ret == nil {
+			ret = err
+		}
+	}
+	return ret
+}
@@ -0,0 +1,174 @@
+package cloudwatch
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type routerTestSuite struct {
+	suite.Suite
+
+	api *mockAPI
+	ctx context.Context
+
+	mu       sync.Mutex
+	messages map[string][]string
+	created  []string
+}
+
+func (r *routerTestSuite) SetupTest() {
+	r.api = new(mockAPI)
+	r.ctx = context.Background()
+	r.messages = make(map[string][]string)
+	r.created = nil
+
+	r.api.On(
+		"CreateLogStreamWithContext",
+		r.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.CreateLogStreamInput"),
+		[]request.Option(nil),
+	).Run(func(args mock.Arguments) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.created = append(r.created, *args.Get(1).(*cloudwatchlogs.CreateLogStreamInput).LogStreamName)
+	}).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil)
+
+	r.api.On(
+		"PutLogEventsWithContext",
+		mock.Anything,
+		mock.AnythingOfType("*cloudwatchlogs.PutLogEventsInput"),
+		[]request.Option(nil),
+	).Run(func(args mock.Arguments) {
+		input := args.Get(1).(*cloudwatchlogs.PutLogEventsInput)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for _, event := range input.LogEvents {
+			r.messages[*input.LogStreamName] = append(r.messages[*input.LogStreamName], *event.Message)
+		}
+	}).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+}
+
+func (r *routerTestSuite) newRouter(routes ...Route) io.WriteCloser {
+	router, err := NewRouter(NewGroup(r.api, "groupName"), r.ctx, routes)
+	r.Require().NoError(err)
+	return router
+}
+
+func (r *routerTestSuite) createdStreams() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.created...)
+}
+
+func (r *routerTestSuite) TestRouting() {
+	router := r.newRouter(
+		Route{Pattern: regexp.MustCompile(`ERROR`), StreamName: "errors"},
+		Route{Pattern: regexp.MustCompile(`WARN|ERROR`), StreamName: "warnings"},
+	)
+
+	_, err := io.WriteString(router, "ERROR one\nWARN two\nINFO three\nERROR four\n")
+	r.Require().NoError(err)
+	r.Require().NoError(router.Close())
+
+	// The first matching route wins.
+	r.Equal(map[string][]string{
+		"errors":   {"ERROR one\n", "ERROR four\n"},
+		"warnings": {"WARN two\n"},
+		"default":  {"INFO three\n"},
+	}, r.messages)
+}
+
+func (r *routerTestSuite) TestCatchAllRoute() {
+	router := r.newRouter(
+		Route{Pattern: regexp.MustCompile(`ERROR`), StreamName: "errors"},
+		Route{StreamName: "everything"},
+	)
+
+	_, err := io.WriteString(router, "INFO one\n")
+	r.Require().NoError(err)
+	r.Require().NoError(router.Close())
+
+	r.Equal(map[string][]string{"everything": {"INFO one\n"}}, r.messages)
+}
+
+func (r *routerTestSuite) TestLazyCreation() {
+	router := r.newRouter(
+		Route{Pattern: regexp.MustCompile(`ERROR`), StreamName: "errors"},
+		Route{Pattern: regexp.MustCompile(`WARN`), StreamName: "warnings"},
+	)
+	r.Empty(r.createdStreams())
+
+	_, err := io.WriteString(router, "ERROR one\nERROR two\n")
+	r.Require().NoError(err)
+	r.Equal([]string{"errors"}, r.createdStreams())
+
+	r.Require().NoError(router.Close())
+
+	_, err = io.WriteString(router, "ERROR three\n")
+	r.Equal(io.ErrClosedPipe, err)
+}
+
+func (r *routerTestSuite) TestInvalidRoute() {
+	_, err := NewRouter(NewGroup(r.api, "groupName"), r.ctx, []Route{{Pattern: regexp.MustCompile(`.`)}})
+
+	r.EqualError(err, "routes must have a stream name")
+}
+
+func (r *routerTestSuite) TestShardedWriter() {
+	writer, err := NewShardedWriter(NewGroup(r.api, "groupName"), r.ctx, "shard", 3)
+	r.Require().NoError(err)
+	r.ElementsMatch([]string{"shard-0", "shard-1", "shard-2"}, r.createdStreams())
+
+	const writers, lines = 10, 100
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < lines; j++ {
+				_, err := io.WriteString(writer, "Hello\n")
+				r.NoError(err)
+			}
+		}()
+	}
+	wg.Wait()
+	r.Require().NoError(writer.Close())
+
+	// Round-robin distribution spreads lines evenly.
+	var total int
+	for _, messages := range r.messages {
+		r.InDelta(writers*lines/3, len(messages), 1)
+		total += len(messages)
+	}
+	r.Equal(writers*lines, total)
+}
+
+func (r *routerTestSuite) TestShardingKey() {
+	writer, err := NewShardedWriter(NewGroup(r.api, "groupName"), r.ctx, "shard", 2,
+		WithShardingKey(func(line []byte) int { return -len(line) }),
+	)
+	r.Require().NoError(err)
+
+	_, err = io.WriteString(writer, "a\nbb\nccc\n")
+	r.Require().NoError(err)
+	r.Require().NoError(writer.Close())
+
+	r.Equal(map[string][]string{
+		"shard-0": {"a\n", "ccc\n"},
+		"shard-1": {"bb\n"},
+	}, r.messages)
+}
+
+func TestRouter(t *testing.T) {
+	suite.Run(t, new(routerTestSuite))
+}
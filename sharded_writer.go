@@ -0,0 +1,99 @@
+package cloudwatch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// WithShardingKey makes a writer returned by NewShardedWriter send each line
+// to the shard whose index is fn(line) modulo the number of shards, rather
+// than distributing lines round-robin. It has no effect on other writers.
+func WithShardingKey(fn func([]byte) int) CreateOption {
+	return func(w *writerImpl) {
+		w.shardingKey = fn
+	}
+}
+
+type shardedWriter struct {
+	shards      []WriteFlushCloser
+	shardingKey func([]byte) int
+	next        uint64
+}
+
+// NewShardedWriter returns an io.WriteCloser spreading lines across shards log
+// streams named <streamBase>-0 to <streamBase>-<shards-1>, to work around the
+// rate limit of PutLogEvents requests per log stream. Lines are distributed
+// round-robin, unless WithShardingKey is set. All streams are created using
+// opts.
+func NewShardedWriter(g Group, ctx context.Context, streamBase string, shards int, opts ...CreateOption) (io.WriteCloser, error) {
+	if shards < 1 {
+		return nil, errors.Errorf("invalid number of shards: %d", shards)
+	}
+
+	// Apply the options to a scratch writer to find the sharding key.
+	scratch := &writerImpl{events: newEventsBuffer()}
+	for _, opt := range opts {
+		opt(scratch)
+	}
+
+	ret := &shardedWriter{shardingKey: scratch.shardingKey}
+	for i := 0; i < shards; i++ {
+		shard, err := g.Create(ctx, fmt.Sprintf("%s-%d", streamBase, i), opts...)
+		if err != nil {
+			ret.Close()
+			return nil, err
+		}
+		ret.shards = append(ret.shards, shard)
+	}
+
+	return ret, nil
+}
+
+func (s *shardedWriter) Write(b []byte) (int, error) {
+	return forEachLine(b, func(line []byte) error {
+		_, err := s.shards[s.shard(line)].Write(line)
+		return err
+	})
+}
+
+// shard returns the index of the shard line should be sent to.
+func (s *shardedWriter) shard(line []byte) int {
+	n := len(s.shards)
+	if s.shardingKey == nil {
+		return int((atomic.AddUint64(&s.next, 1) - 1) % uint64(n))
+	}
+
+	i := s.shardingKey(line) % n
+	if i < 0 {
+		i += n
+	}
+	return i
+}
+
+// Close closes all shards concurrently, waiting for them to be flushed, and
+// returns the first error.
+func (s *shardedWriter) Close() error {
+	errs := make([]error, len(s.shards))
+
+	var wg sync.WaitGroup
+	for i, shard := range s.shards {
+		wg.Add(1)
+		go func(i int, shard WriteFlushCloser) {
+			defer wg.Done()
+			errs[i] = shard.Close()
+		}(i, shard)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
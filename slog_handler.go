@@ -0,0 +1,177 @@
+//go:build go1.21
+// +build go1.21
+
+package cloudwatch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+type slogHandler struct {
+	mu   *sync.Mutex // This protects writes to w, shared with derived handlers.
+	w    io.WriteCloser
+	opts slog.HandlerOptions
+
+	// Attributes added using WithAttrs, already flattened, and the groups
+	// added using WithGroup.
+	fields map[string]interface{}
+	groups []string
+}
+
+// NewSlogHandler returns a slog.Handler writing each record to w as a JSON
+// object on a single line, so that it becomes a single CloudWatch Logs event
+// when w is a writer returned by Group.Create.
+//
+// The time, level and message of the record are written as the "time",
+// "level" and "msg" fields. Attributes are flattened into the top-level
+// object, with the keys of attributes in groups prefixed by the group names
+// and a dot. opts may be nil, in which case records at slog.LevelInfo and
+// above are written.
+func NewSlogHandler(w io.WriteCloser, opts *slog.HandlerOptions) slog.Handler {
+	h := &slogHandler{
+		mu:     new(sync.Mutex),
+		w:      w,
+		fields: make(map[string]interface{}),
+	}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	min := slog.LevelInfo
+	if h.opts.Level != nil {
+		min = h.opts.Level.Level()
+	}
+	return level >= min
+}
+
+func (h *slogHandler) Handle(_ context.Context, record slog.Record) error {
+	fields := make(map[string]interface{}, len(h.fields)+record.NumAttrs()+3)
+
+	var builtin []slog.Attr
+	if !record.Time.IsZero() {
+		builtin = append(builtin, slog.Time(slog.TimeKey, record.Time))
+	}
+	builtin = append(builtin,
+		slog.Any(slog.LevelKey, record.Level),
+		slog.String(slog.MessageKey, record.Message),
+	)
+	if h.opts.AddSource && record.PC != 0 {
+		builtin = append(builtin, slog.Any(slog.SourceKey, source(record.PC)))
+	}
+	for _, attr := range builtin {
+		h.addAttr(fields, nil, attr)
+	}
+
+	for key, value := range h.fields {
+		fields[key] = value
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		h.addAttr(fields, h.groups, attr)
+		return true
+	})
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err = h.w.Write(append(b, '\n'))
+	return err
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	ret := h.clone()
+	for _, attr := range attrs {
+		h.addAttr(ret.fields, h.groups, attr)
+	}
+	return ret
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	ret := h.clone()
+	ret.groups = append(ret.groups[:len(ret.groups):len(ret.groups)], name)
+	return ret
+}
+
+func (h *slogHandler) clone() *slogHandler {
+	fields := make(map[string]interface{}, len(h.fields))
+	for key, value := range h.fields {
+		fields[key] = value
+	}
+	return &slogHandler{
+		mu:     h.mu,
+		w:      h.w,
+		opts:   h.opts,
+		fields: fields,
+		groups: h.groups,
+	}
+}
+
+// addAttr flattens attr into fields, prefixing its key with groups.
+func (h *slogHandler) addAttr(fields map[string]interface{}, groups []string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if h.opts.ReplaceAttr != nil && attr.Value.Kind() != slog.KindGroup {
+		attr = h.opts.ReplaceAttr(groups, attr)
+		attr.Value = attr.Value.Resolve()
+	}
+
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		// Attributes of groups with an empty key are inlined.
+		if attr.Key != "" {
+			groups = append(groups[:len(groups):len(groups)], attr.Key)
+		}
+		for _, member := range attr.Value.Group() {
+			h.addAttr(fields, groups, member)
+		}
+		return
+	}
+
+	key := strings.Join(append(groups[:len(groups):len(groups)], attr.Key), ".")
+	fields[key] = jsonValue(attr.Value)
+}
+
+// jsonValue converts v to a value encoding/json represents sensibly.
+func jsonValue(v slog.Value) interface{} {
+	switch v.Kind() {
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindAny:
+		switch value := v.Any().(type) {
+		case slog.Level:
+			return value.String()
+		case error:
+			return value.Error()
+		}
+	}
+	return v.Any()
+}
+
+func source(pc uintptr) *slog.Source {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return &slog.Source{
+		Function: frame.Function,
+		File:     frame.File,
+		Line:     frame.Line,
+	}
+}
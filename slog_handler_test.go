@@ -0,0 +1,94 @@
+//go:build go1.21
+// +build go1.21
+
+package cloudwatch
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+func (w *writerTestSuite) TestSlogHandler() {
+	writer := w.newUnstartedWriter(w.ctx)
+
+	logger := slog.New(NewSlogHandler(writer, &slog.HandlerOptions{Level: slog.LevelDebug})).
+		With("service", "orders").
+		WithGroup("request")
+
+	logger.Debug("Hello", "id", 42, slog.Group("user", "name", "bacon"))
+	logger.Error("World", "err", errors.New("bacon"))
+
+	messages := drainMessages(writer)
+	w.Require().Len(messages, 2)
+
+	var first map[string]interface{}
+	w.Require().NoError(json.Unmarshal([]byte(messages[0]), &first))
+	w.Equal("DEBUG", first["level"])
+	w.Equal("Hello", first["msg"])
+	w.Equal("orders", first["service"])
+	w.Equal(float64(42), first["request.id"])
+	w.Equal("bacon", first["request.user.name"])
+	w.Contains(first, "time")
+
+	var second map[string]interface{}
+	w.Require().NoError(json.Unmarshal([]byte(messages[1]), &second))
+	w.Equal("ERROR", second["level"])
+	w.Equal("bacon", second["request.err"])
+}
+
+func (w *writerTestSuite) TestFilterLevel() {
+	writer := w.newUnstartedWriter(w.ctx, WithEventMiddleware(FilterLevel(slog.LevelWarn)))
+
+	_, err := io.WriteString(writer, strings.Join([]string{
+		`{"level":"INFO","msg":"dropped"}`,
+		`{"level":"warning","msg":"kept"}`,
+		`{"level":"ERROR+2","msg":"kept"}`,
+		`{"msg":"kept"}`,
+		`not JSON`,
+	}, "\n"))
+	w.Require().NoError(err)
+
+	w.Equal([]string{
+		"{\"level\":\"warning\",\"msg\":\"kept\"}\n",
+		"{\"level\":\"ERROR+2\",\"msg\":\"kept\"}\n",
+		"{\"msg\":\"kept\"}\n",
+		"not JSON",
+	}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestSlogHandlerLevel() {
+	writer := w.newUnstartedWriter(w.ctx)
+	logger := slog.New(NewSlogHandler(writer, nil))
+
+	logger.Debug("Hello")
+	logger.Info("World")
+
+	messages := drainMessages(writer)
+	w.Require().Len(messages, 1)
+	w.Contains(messages[0], `"msg":"World"`)
+}
+
+func (w *writerTestSuite) TestSlogHandlerConcurrent() {
+	writer := w.newUnstartedWriter(w.ctx)
+	logger := slog.New(NewSlogHandler(writer, nil))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			logger.With("worker", i).Info("Hello")
+		}(i)
+	}
+	wg.Wait()
+
+	messages := drainMessages(writer)
+	w.Len(messages, 10)
+	for _, message := range messages {
+		w.True(json.Valid([]byte(message)))
+	}
+}
@@ -0,0 +1,110 @@
+package cloudwatch
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// Writer is returned by Group.Create, CreateFromContext and CreateEphemeral.
+// Its writers keep statistics about the events they sent and adapt their flush
+// rate.
+type Writer interface {
+	WriteFlushCloser
+
+	// Stats returns the writer's statistics since it was created.
+	Stats() WriterStats
+
+	// CurrentFlushRate returns the number of flushes per second the writer
+	// is currently limited to, which is lowered while AWS CloudWatch Logs
+	// throttles requests.
+	CurrentFlushRate() float64
+
+	// Reset sends the buffered events to the current log stream, then makes
+	// the writer write to streamName, creating it if needed. Events written
+	// concurrently are sent to either stream. ctx only applies to the
+	// requests made by Reset.
+	Reset(ctx context.Context, streamName string) error
+
+	// State returns the current state of the writer's circuit breaker, which
+	// is always closed without WithCircuitBreaker.
+	State() CircuitState
+}
+
+// WriterStats are statistics about the events sent by a writer.
+type WriterStats struct {
+	// FlushCount is the number of batches sent, whether successfully or not.
+	FlushCount int64
+
+	// EventsSent is the number of events accepted by AWS CloudWatch Logs.
+	EventsSent int64
+
+	// BytesSent is the size of the events accepted by AWS CloudWatch Logs,
+	// including the 26 bytes CloudWatch Logs adds to each event.
+	BytesSent int64
+
+	// ErrorCount is the number of batches which failed to be sent, including
+	// batches with rejected events.
+	ErrorCount int64
+
+	// RejectedCount is the number of events rejected by AWS CloudWatch Logs.
+	RejectedCount int64
+
+	// LastFlushDuration is how long sending the last batch took.
+	LastFlushDuration time.Duration
+}
+
+// writerStats holds a writer's statistics, which are updated atomically.
+type writerStats struct {
+	flushCount, eventsSent, bytesSent, errorCount, rejectedCount int64
+	lastFlushDuration                                            int64
+}
+
+func (s *writerStats) get() WriterStats {
+	return WriterStats{
+		FlushCount:        atomic.LoadInt64(&s.flushCount),
+		EventsSent:        atomic.LoadInt64(&s.eventsSent),
+		BytesSent:         atomic.LoadInt64(&s.bytesSent),
+		ErrorCount:        atomic.LoadInt64(&s.errorCount),
+		RejectedCount:     atomic.LoadInt64(&s.rejectedCount),
+		LastFlushDuration: time.Duration(atomic.LoadInt64(&s.lastFlushDuration)),
+	}
+}
+
+// recordFlush records sending a batch, which took d.
+func (s *writerStats) recordFlush(d time.Duration, err error) {
+	atomic.AddInt64(&s.flushCount, 1)
+	atomic.StoreInt64(&s.lastFlushDuration, int64(d))
+	if err != nil {
+		atomic.AddInt64(&s.errorCount, 1)
+	}
+}
+
+// recordSent records the events accepted and rejected out of a batch.
+func (s *writerStats) recordSent(accepted []*cloudwatchlogs.InputLogEvent, rejected int) {
+	atomic.AddInt64(&s.eventsSent, int64(len(accepted)))
+	atomic.AddInt64(&s.bytesSent, int64(batchBytes(accepted)))
+	atomic.AddInt64(&s.rejectedCount, int64(rejected))
+}
+
+// acceptedEvents returns the events of the batch which were not rejected.
+func (e *RejectedLogEventsInfoError) acceptedEvents() []*cloudwatchlogs.InputLogEvent {
+	start := len(e.TooOldEvents())
+	if expired := len(e.ExpiredEvents()); expired > start {
+		start = expired
+	}
+
+	end := len(e.Events) - len(e.TooNewEvents())
+	if start >= end {
+		return nil
+	}
+
+	return e.Events[start:end]
+}
+
+// Stats returns the writer's statistics since it was created.
+func (w *writerImpl) Stats() WriterStats {
+	return w.stats.get()
+}
@@ -0,0 +1,60 @@
+package cloudwatch
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/pkg/errors"
+)
+
+type streamIterator struct {
+	client    iface.CloudWatchLogsAPI
+	ctx       context.Context
+	groupName *string
+	prefix    string
+
+	page      []*cloudwatchlogs.LogStream
+	nextToken *string
+	started   bool
+	err       error
+}
+
+func (it *streamIterator) Next() (string, bool) {
+	for len(it.page) == 0 {
+		if it.err != nil || (it.started && it.nextToken == nil) {
+			return "", false
+		}
+		it.fetch()
+	}
+
+	name := aws.StringValue(it.page[0].LogStreamName)
+	it.page = it.page[1:]
+	return name, true
+}
+
+func (it *streamIterator) Err() error {
+	return it.err
+}
+
+// fetch requests the next page of log streams.
+func (it *streamIterator) fetch() {
+	input := &cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName: it.groupName,
+		NextToken:    it.nextToken,
+	}
+	if it.prefix != "" {
+		input.LogStreamNamePrefix = aws.String(it.prefix)
+	}
+
+	resp, err := it.client.DescribeLogStreamsWithContext(it.ctx, input)
+	if err != nil {
+		it.err = errors.Wrap(err, "couldn't list log streams")
+		return
+	}
+
+	it.started = true
+	it.page = resp.LogStreams
+	it.nextToken = resp.NextToken
+}
@@ -0,0 +1,92 @@
+package cloudwatch
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+)
+
+// SubscriptionFilterOptions describes a subscription filter, forwarding the
+// group's events matching a pattern to a destination. Its fields are those of
+// cloudwatchlogs.PutSubscriptionFilterInput, except for the log group name.
+type SubscriptionFilterOptions struct {
+	// DestinationArn is the ARN of the Kinesis stream, Kinesis Data Firehose
+	// delivery stream, Lambda function or logical destination to forward
+	// events to.
+	DestinationArn string
+
+	// Distribution is either cloudwatchlogs.DistributionByLogStream, the
+	// default, or cloudwatchlogs.DistributionRandom. It only applies to
+	// Kinesis streams.
+	Distribution string
+
+	// FilterName identifies the filter. Putting a filter with the name of an
+	// existing one replaces it.
+	FilterName string
+
+	// FilterPattern selects the events to forward. An empty pattern matches
+	// all events.
+	FilterPattern string
+
+	// RoleArn is the ARN of the IAM role granting CloudWatch Logs permission
+	// to write to the destination. It's not used for Lambda functions.
+	RoleArn string
+}
+
+func (g *groupImpl) PutSubscription(ctx context.Context, opts SubscriptionFilterOptions) error {
+	input := &cloudwatchlogs.PutSubscriptionFilterInput{
+		DestinationArn: aws.String(opts.DestinationArn),
+		FilterName:     aws.String(opts.FilterName),
+		FilterPattern:  aws.String(opts.FilterPattern),
+		LogGroupName:   aws.String(g.groupName),
+	}
+	if opts.Distribution != "" {
+		input.Distribution = aws.String(opts.Distribution)
+	}
+	if opts.RoleArn != "" {
+		input.RoleArn = aws.String(opts.RoleArn)
+	}
+
+	_, err := g.PutSubscriptionFilterWithContext(ctx, input)
+
+	return errors.Wrap(err, "could not put the subscription filter")
+}
+
+func (g *groupImpl) DeleteSubscription(ctx context.Context, filterName string) error {
+	_, err := g.DeleteSubscriptionFilterWithContext(ctx, &cloudwatchlogs.DeleteSubscriptionFilterInput{
+		FilterName:   aws.String(filterName),
+		LogGroupName: aws.String(g.groupName),
+	})
+
+	if _, ok := err.(*cloudwatchlogs.ResourceNotFoundException); ok {
+		return ErrNotFound
+	}
+
+	return errors.Wrap(err, "could not delete the subscription filter")
+}
+
+func (g *groupImpl) ListSubscriptions(ctx context.Context) ([]cloudwatchlogs.SubscriptionFilter, error) {
+	var ret []cloudwatchlogs.SubscriptionFilter
+
+	input := &cloudwatchlogs.DescribeSubscriptionFiltersInput{
+		LogGroupName: aws.String(g.groupName),
+	}
+
+	for {
+		resp, err := g.DescribeSubscriptionFiltersWithContext(ctx, input)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list the subscription filters")
+		}
+
+		for _, filter := range resp.SubscriptionFilters {
+			ret = append(ret, *filter)
+		}
+
+		if resp.NextToken == nil {
+			return ret, nil
+		}
+		input.NextToken = resp.NextToken
+	}
+}
@@ -0,0 +1,80 @@
+package cloudwatch
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+)
+
+func (g *groupImpl) Tag(ctx context.Context, tags map[string]string) error {
+	arn, err := g.arn(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = g.TagResourceWithContext(ctx, &cloudwatchlogs.TagResourceInput{
+		ResourceArn: arn,
+		Tags:        aws.StringMap(tags),
+	})
+
+	return errors.Wrap(err, "could not tag the log group")
+}
+
+func (g *groupImpl) Untag(ctx context.Context, keys []string) error {
+	arn, err := g.arn(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = g.UntagResourceWithContext(ctx, &cloudwatchlogs.UntagResourceInput{
+		ResourceArn: arn,
+		TagKeys:     aws.StringSlice(keys),
+	})
+
+	return errors.Wrap(err, "could not untag the log group")
+}
+
+func (g *groupImpl) ListTags(ctx context.Context) (map[string]string, error) {
+	arn, err := g.arn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.ListTagsForResourceWithContext(ctx, &cloudwatchlogs.ListTagsForResourceInput{
+		ResourceArn: arn,
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list the log group tags")
+	}
+
+	return aws.StringValueMap(resp.Tags), nil
+}
+
+// arn returns the ARN of the log group, as expected by the tagging API. The
+// account ID isn't part of the SDK config, so the ARN is looked up once using
+// DescribeLogGroups and cached.
+func (g *groupImpl) arn(ctx context.Context) (*string, error) {
+	g.arnMu.Lock()
+	defer g.arnMu.Unlock()
+
+	if g.groupARN != nil {
+		return g.groupARN, nil
+	}
+
+	group, err := g.describeGroup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if group.Arn == nil {
+		return nil, ErrNotFound
+	}
+
+	// DescribeLogGroups returns the ARN of the group's streams, ending with
+	// ":*".
+	g.groupARN = aws.String(strings.TrimSuffix(*group.Arn, ":*"))
+	return g.groupARN, nil
+}
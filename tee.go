@@ -0,0 +1,37 @@
+package cloudwatch
+
+import "io"
+
+type tee struct {
+	cw    io.WriteCloser
+	local io.Writer
+}
+
+// Tee returns an io.WriteCloser writing to both cw, typically a writer
+// returned by Group.Create, and local, such as os.Stderr. Writes return the
+// first error from either side.
+//
+// Closing it closes cw, as well as local if it implements io.Closer.
+func Tee(cw io.WriteCloser, local io.Writer) io.WriteCloser {
+	return &tee{cw: cw, local: local}
+}
+
+func (t *tee) Write(b []byte) (int, error) {
+	// Writes to cw only buffer events, and don't hold any lock once they
+	// return, so a slow local writer doesn't hold up flushes.
+	n, err := t.cw.Write(b)
+	if _, lerr := t.local.Write(b); err == nil && lerr != nil {
+		return n, lerr
+	}
+	return n, err
+}
+
+func (t *tee) Close() error {
+	err := t.cw.Close()
+	if closer, ok := t.local.(io.Closer); ok {
+		if lerr := closer.Close(); err == nil {
+			err = lerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+package cloudwatch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// WithTokenBucket limits the number of events sent to rate per second, with
+// bursts of up to burst events. Before sending a batch, the writer waits for
+// as many tokens as the batch has events, and batches are capped at burst
+// events so that they can always be sent. This paces sending steadily, rather
+// than sending whatever accumulated on each tick of the flush interval, which
+// still applies.
+func WithTokenBucket(rate float64, burst int) CreateOption {
+	return func(w *writerImpl) {
+		if rate <= 0 || burst <= 0 {
+			return
+		}
+
+		w.bucket = newTokenBucket(rate, burst)
+		if burst < w.events.maxEvents {
+			w.events.maxEvents = burst
+		}
+	}
+}
+
+// tokenBucket is a token bucket rate limiter, which starts full.
+type tokenBucket struct {
+	rate, burst float64
+
+	mu     sync.Mutex // This protects the fields below.
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait blocks until n tokens are available and takes them, or until ctx is
+// done. Requests for more tokens than the burst size wait for a full bucket.
+func (b *tokenBucket) wait(ctx context.Context, n int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	want := float64(n)
+	if want > b.burst {
+		want = b.burst
+	}
+
+	b.refill(time.Now())
+	if deficit := want - b.tokens; deficit > 0 {
+		if err := aws.SleepWithContext(ctx, time.Duration(deficit/b.rate*float64(time.Second))); err != nil {
+			return err
+		}
+		b.refill(time.Now())
+	}
+
+	// The sleep may have been rounded down, which only slightly exceeds the
+	// rate.
+	if b.tokens -= want; b.tokens < 0 {
+		b.tokens = 0
+	}
+	return nil
+}
+
+// refill adds the tokens accumulated since the last refill. The caller must
+// hold the lock.
+func (b *tokenBucket) refill(now time.Time) {
+	if b.tokens += now.Sub(b.last).Seconds() * b.rate; b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
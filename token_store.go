@@ -0,0 +1,130 @@
+package cloudwatch
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+)
+
+// SequenceTokenStore keeps the sequence tokens of log streams, so that writers
+// created for existing streams, for example after a restart, don't need to
+// describe the stream to get its token.
+type SequenceTokenStore interface {
+	// Load returns the last sequence token saved for the stream, if any.
+	Load(streamName string) (token string, ok bool)
+
+	// Save saves the sequence token to use for the next write to the stream.
+	Save(streamName string, token string)
+}
+
+// WithSequenceTokenStore makes the writer save the sequence token returned by
+// each successful flush to store, and use the token found in store when
+// writing to an existing stream. Outdated tokens are recovered from on the
+// first flush. A token set using FromToken takes precedence.
+func WithSequenceTokenStore(store SequenceTokenStore) CreateOption {
+	return func(w *writerImpl) {
+		w.tokenStore = store
+	}
+}
+
+// ReaderTokenStore keeps the forward tokens of readers, so that a reader opened
+// again, for example after a restart, resumes where the last one stopped.
+type ReaderTokenStore interface {
+	// Load returns the last forward token saved for the stream, if any.
+	Load(streamName string) (token string, ok bool)
+
+	// Save saves the token to read the stream from next.
+	Save(streamName string, token string)
+}
+
+// WithReaderTokenStore makes the reader save the forward token returned by each
+// request to store, and resume from the token found in store when opened. As
+// tokens are saved once events are read rather than consumed, events which
+// were read but not consumed before a restart are skipped. It has no effect
+// with WithFilterPattern, since filtered reads use a different kind of token.
+func WithReaderTokenStore(store ReaderTokenStore) ReadOption {
+	return func(r *readerImpl) {
+		r.tokenStore = store
+	}
+}
+
+// MemoryReaderTokenStore returns a ReaderTokenStore keeping tokens in memory,
+// which is safe for concurrent use.
+func MemoryReaderTokenStore() ReaderTokenStore {
+	return &memoryTokenStore{tokens: make(map[string]string)}
+}
+
+// FileReaderTokenStore returns a ReaderTokenStore backed by a JSON file, like
+// FileTokenStore. It must not share its file with a SequenceTokenStore.
+func FileReaderTokenStore(path string) ReaderTokenStore {
+	return FileTokenStore(path)
+}
+
+// MemoryTokenStore returns a SequenceTokenStore keeping tokens in memory, which
+// is safe for concurrent use.
+func MemoryTokenStore() SequenceTokenStore {
+	return &memoryTokenStore{tokens: make(map[string]string)}
+}
+
+type memoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func (s *memoryTokenStore) Load(streamName string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[streamName]
+	return token, ok
+}
+
+func (s *memoryTokenStore) Save(streamName string, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[streamName] = token
+}
+
+// FileTokenStore returns a SequenceTokenStore backed by a JSON file, which is
+// loaded if it exists and rewritten every time a token is saved. Failures to
+// read or write the file are logged to the standard logger, in which case
+// writers fall back to describing streams. It's safe for concurrent use.
+func FileTokenStore(path string) SequenceTokenStore {
+	ret := &fileTokenStore{path: path, memoryTokenStore: memoryTokenStore{tokens: make(map[string]string)}}
+
+	b, err := ioutil.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(b, &ret.tokens)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		log.Printf("cloudwatch: could not load sequence tokens: %v", err)
+	}
+	if ret.tokens == nil {
+		ret.tokens = make(map[string]string)
+	}
+
+	return ret
+}
+
+type fileTokenStore struct {
+	path string
+	memoryTokenStore
+}
+
+func (s *fileTokenStore) Save(streamName string, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[streamName] = token
+
+	b, err := json.MarshalIndent(s.tokens, "", "  ")
+	if err == nil {
+		err = ioutil.WriteFile(s.path, b, 0644)
+	}
+	if err != nil {
+		log.Printf("cloudwatch: could not save sequence tokens: %v", err)
+	}
+}
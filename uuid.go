@@ -0,0 +1,17 @@
+package cloudwatch
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// newUUID returns a random version 4 UUID, as described in RFC 4122.
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40 // Version 4.
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant.
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
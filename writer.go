@@ -4,43 +4,274 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"os"
+	"regexp"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	iface "github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/pkg/errors"
 )
 
+// ErrWriteTimeout is returned from Flush when sending a batch of log events
+// takes longer than the timeout set using WithWriteTimeout. The events are
+// kept in the buffer and sent again with the next flush.
+var ErrWriteTimeout = errors.New("timed out sending log events")
+
+// ErrEventOutOfRange is passed to the dead-letter handler set using
+// WithDeadLetterHandler along with log events which are older than 14 days or
+// more than 2 hours in the future, which CloudWatch Logs would reject.
+var ErrEventOutOfRange = errors.New("log event timestamp out of range")
+
+// ErrEventTooOld is passed to the dead-letter handler set using
+// WithDeadLetterHandler along with log events older than the maximum age set
+// using WithMaxEventAge, which are dropped rather than buffered.
+var ErrEventTooOld = errors.New("log event is too old")
+
+// MaxEventAge is the default maximum age of log events, an hour short of the
+// 14 days after which CloudWatch Logs rejects them. See
+// https://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_PutLogEvents.html
+const MaxEventAge = 14*24*time.Hour - time.Hour
+
+// DrainTimeoutError is returned from Close when the buffered events could not
+// all be sent within the timeout set using WithCloseTimeout.
+type DrainTimeoutError struct {
+	// Remaining is the number of events which were not sent.
+	Remaining int
+}
+
+func (e *DrainTimeoutError) Error() string {
+	return fmt.Sprintf("timed out draining log events, %d not sent", e.Remaining)
+}
+
+// cancelFlushTimeout bounds the final flush made once the writer's context is
+// done.
+const cancelFlushTimeout = 5 * time.Second
+
 type writerImpl struct {
+	// This is first so that its fields are 64-bit aligned, as required for
+	// atomic operations on 32-bit platforms.
+	stats writerStats
+
 	client iface.CloudWatchLogsAPI
 
 	groupName, streamName, sequenceToken *string
 
 	ctx context.Context
 
+	// If set, used instead of ctx for requests once ctx is done, so that
+	// buffered events can still be sent.
+	drainCtx context.Context
+
 	closeChan chan (struct{})
-	closed    bool
+	flushChan chan (struct{})
+	closed    bool // Set holding the lock, so that Reset can check it.
+	closeOnce sync.Once
+	closeErr  error // The result of the first call to Close.
 	err       error
+	errMu     sync.RWMutex // This protects err.
+	errChan   chan<- error
+
+	events         *eventsBuffer
+	store          eventStore // If set, used instead of events.
+	flushInterval  time.Duration
+	metrics        Metrics
+	middleware     []EventMiddleware
+	enrichers      []EventEnricher
+	hostname       string
+	messagePrefix  []byte
+	stripNewlines  bool
+	hmacKey        []byte
+	newlineReplace *string
+	logger         GroupLogger
+	dedup          *deduplicator
+	deadLetter     func([]*cloudwatchlogs.InputLogEvent, error)
+	maxEventAge    time.Duration
+	nowFunc        func() time.Time
+	onEvent        func(*cloudwatchlogs.InputLogEvent)
+	onBatch        func([]*cloudwatchlogs.InputLogEvent)
+	onFlushed      func(*cloudwatchlogs.PutLogEventsOutput, error)
+	onEventSize    func(int)
+	onBatchSize    func(int, int)
+	oversizePolicy OversizePolicy
+	retry          backoff
+	tokenRetry     backoff
+	tokenStore     SequenceTokenStore
+	ackStore       PendingAckStore
+	validateJSON   bool
+	writeTimeout   time.Duration
+	closeTimeout   time.Duration
+
+	// Whether to recreate the log stream if it's deleted while writing.
+	autoRecreateStream bool
+
+	// If set, failed flushes open the circuit rather than failing the writer.
+	breaker *circuitBreaker
+
+	// Adjusts the interval between flushes when requests are throttled.
+	adaptive *adaptiveThrottle
+
+	// If set, limits the rate at which events are sent.
+	bucket *tokenBucket
 
-	events  *eventsBuffer
-	nowFunc func() time.Time
-	onEvent func(*cloudwatchlogs.InputLogEvent)
+	// If set, heartbeatMessage is sent once no events were sent for
+	// heartbeatInterval. lastSent is protected by the lock.
+	heartbeatInterval time.Duration
+	heartbeatMessage  string
+	lastSent          time.Time
+
+	// If set, lines not matching multiLineStart are appended to the pending
+	// event rather than starting a new one, up to multiLineMaxBytes.
+	multiLineStart    *regexp.Regexp
+	multiLineMaxBytes int
+	pending           []byte
+	pendingTime       time.Time
+	pendingMu         sync.Mutex // This protects pending and pendingTime.
+
+	// Whether to create the log group if it doesn't exist, and the retention
+	// policy to give it.
+	createGroup    bool
+	groupRetention *int64
+
+	// Thresholds after which writes go to a new log stream, named after the
+	// original stream and the time of rotation.
+	rotateBytes    int64
+	rotateAfter    time.Duration
+	onRotate       func(old, new string)
+	baseStreamName string
+	writtenBytes   int64
+	rotatedAt      time.Time
+	rotateMu       sync.Mutex // This protects writtenBytes and rotatedAt.
+
+	// Only used by NewShardedWriter.
+	shardingKey func([]byte) int
+
+	// The group which created the writer, if any, and a function called
+	// when the writer is closed, to remove it from the group.
+	group   *groupImpl
+	onClose func()
 
 	throttle *time.Ticker
 
-	sync.Mutex // This protects calls to flush.
+	sync.Mutex // This protects calls to flush, and streamName.
 }
 
+// osHostname is a variable so that tests can stub it.
+var osHostname = os.Hostname
+
+// rotatedStreamFormat is the time format appended to the names of rotated log
+// streams. Colons are not allowed in log stream names.
+const rotatedStreamFormat = "2006-01-02T15-04-05"
+
 // WithInputCallback allows setting a function introspecting each input log
-// event before it's sent to AWS CloudWatch Logs.
+// event before it's sent to AWS CloudWatch Logs. It's called after all event
+// middleware, so use WithEventMiddleware to modify events.
 func WithInputCallback(callback func(*cloudwatchlogs.InputLogEvent)) CreateOption {
 	return func(w *writerImpl) {
 		w.onEvent = callback
 	}
 }
 
+// WithEventMiddleware adds a function transforming each log event before it's
+// buffered. Middleware added by multiple options is applied in order, and an
+// event for which one returns nil is dropped. The oversize policy is applied
+// to the resulting event.
+func WithEventMiddleware(fn EventMiddleware) CreateOption {
+	return func(w *writerImpl) {
+		w.middleware = append(w.middleware, fn)
+	}
+}
+
+// WithJSONFieldInjector adds fields to messages which are JSON objects, for
+// example the service name and version, using InjectJSONFields as event
+// middleware.
+func WithJSONFieldInjector(fields map[string]string) CreateOption {
+	return WithEventMiddleware(InjectJSONFields(fields))
+}
+
+// WithEventEnricher adds a function adding values from the writer's context,
+// such as request or trace IDs, to each log event. Enrichers run after all
+// event middleware, in the order they were added, and the oversize policy is
+// applied to the resulting event.
+func WithEventEnricher(fn EventEnricher) CreateOption {
+	return func(w *writerImpl) {
+		w.enrichers = append(w.enrichers, fn)
+	}
+}
+
+// WithHostname prefixes each log event's message with "host=<hostname> ",
+// where hostname is the one reported by the kernel when the writer is created.
+// The prefix is added after event enrichers have run. It has no effect if the
+// hostname can't be determined.
+func WithHostname() CreateOption {
+	return func(w *writerImpl) {
+		if hostname, err := osHostname(); err == nil {
+			w.hostname = hostname
+		}
+	}
+}
+
+// WithCustomHostname is like WithHostname, but uses hostname rather than the
+// one reported by the kernel.
+func WithCustomHostname(hostname string) CreateOption {
+	return func(w *writerImpl) {
+		w.hostname = hostname
+	}
+}
+
+// WithMessagePrefix prefixes each log event's message with prefix followed by
+// separator, which defaults to a space if empty, for example to tag events
+// with the name of the service. The prefix is added to complete multi-line
+// events, before event middleware runs, and counts towards the per-event size
+// limit. It has no effect if prefix is empty.
+func WithMessagePrefix(prefix, separator string) CreateOption {
+	return func(w *writerImpl) {
+		if prefix == "" {
+			w.messagePrefix = nil
+			return
+		}
+		if separator == "" {
+			separator = " "
+		}
+		w.messagePrefix = []byte(prefix + separator)
+	}
+}
+
+// WithStripNewlines removes the line break at the end of each log event's
+// message, which the CloudWatch console shows as a blank line. Events left
+// empty are dropped.
+func WithStripNewlines() CreateOption {
+	return func(w *writerImpl) {
+		w.stripNewlines = true
+	}
+}
+
+// WithNewlineReplacement replaces the line breaks within each log event's
+// message, such as those of multi-line events, with r, for example `\n`, so
+// that the CloudWatch console shows the event on a single line. The line
+// break at the end of the message is kept, unless WithStripNewlines is used.
+func WithNewlineReplacement(r string) CreateOption {
+	return func(w *writerImpl) {
+		w.newlineReplace = aws.String(r)
+	}
+}
+
+// WithAPIWrapper replaces the client used by the writer with the one returned
+// by fn, which is passed the group's client. It allows instrumenting the calls
+// made to AWS CloudWatch Logs, for example for tracing.
+func WithAPIWrapper(fn func(iface.CloudWatchLogsAPI) iface.CloudWatchLogsAPI) CreateOption {
+	return func(w *writerImpl) {
+		w.client = fn(w.client)
+	}
+}
+
 // FromToken allows writing from an arbitrary sequence token.
 func FromToken(sequenceToken string) CreateOption {
 	return func(w *writerImpl) {
@@ -48,6 +279,344 @@ func FromToken(sequenceToken string) CreateOption {
 	}
 }
 
+// WithOversizePolicy sets the policy applied to log events exceeding the
+// CloudWatch Logs per-event size limit. Defaults to OversizePolicyTruncate.
+func WithOversizePolicy(policy OversizePolicy) CreateOption {
+	return func(w *writerImpl) {
+		w.oversizePolicy = policy
+	}
+}
+
+// WithJSONValidation makes the writer drop log events that aren't valid JSON,
+// logging them to the group's logger, to keep malformed messages out of
+// streams expected to contain structured logs.
+func WithJSONValidation() CreateOption {
+	return func(w *writerImpl) {
+		w.validateJSON = true
+	}
+}
+
+// WithMultiLineStart groups multi-line messages such as stack traces into a
+// single log event. Lines matching pattern start a new event, while other
+// lines are appended to the previous one. An event is only buffered once the
+// next one starts or the writer is flushed.
+func WithMultiLineStart(pattern *regexp.Regexp) CreateOption {
+	return func(w *writerImpl) {
+		w.multiLineStart = pattern
+	}
+}
+
+// WithMultiLineMaxBytes caps the size of events grouped by WithMultiLineStart.
+// A line which would take an event over n bytes starts a new event instead.
+// Defaults to, and can't be raised above, the CloudWatch Logs event size
+// limit.
+func WithMultiLineMaxBytes(n int) CreateOption {
+	return func(w *writerImpl) {
+		if n > 0 && n <= maxEventMessageBytes {
+			w.multiLineMaxBytes = n
+		}
+	}
+}
+
+// WithRotateAfterBytes makes the writer switch to a new log stream once n
+// bytes have been written to the current one. New streams are named after
+// the original one, followed by the time of rotation in UTC, for example
+// "app-2024-01-15T10-00-00". Events buffered before rotation are sent to the
+// previous stream.
+func WithRotateAfterBytes(n int64) CreateOption {
+	return func(w *writerImpl) {
+		w.rotateBytes = n
+	}
+}
+
+// WithRotateAfterDuration makes the writer switch to a new log stream once the
+// current one has been written to for d. Rotation happens on the first write
+// after d has elapsed. See WithRotateAfterBytes for how new streams are named.
+func WithRotateAfterDuration(d time.Duration) CreateOption {
+	return func(w *writerImpl) {
+		w.rotateAfter = d
+	}
+}
+
+// WithOnRotate sets a function called with the names of the previous and new
+// log streams every time the writer rotates streams.
+func WithOnRotate(callback func(old, new string)) CreateOption {
+	return func(w *writerImpl) {
+		w.onRotate = callback
+	}
+}
+
+// WithMaxBatchBytes lowers the maximum size in bytes of a single batch of log
+// events sent to AWS CloudWatch Logs, counting 26 bytes of overhead per event.
+// Values above the AWS limit of 1,048,576 bytes are ignored.
+func WithMaxBatchBytes(n int) CreateOption {
+	return func(w *writerImpl) {
+		if n > 0 && n <= maxBatchSizeBytes {
+			w.events.maxBytes = n
+		}
+	}
+}
+
+// WithMaxBatchEvents lowers the maximum number of log events in a single batch
+// sent to AWS CloudWatch Logs. Values above the AWS limit of 10,000 events are
+// ignored.
+func WithMaxBatchEvents(n int) CreateOption {
+	return func(w *writerImpl) {
+		if n > 0 && n <= maxBatchSizeEvents {
+			w.events.maxEvents = n
+		}
+	}
+}
+
+// WithMaxRetries sets the number of times a batch is retried when AWS
+// CloudWatch Logs throttles the request. Defaults to 5.
+func WithMaxRetries(n int) CreateOption {
+	return func(w *writerImpl) {
+		w.retry.maxRetries = n
+	}
+}
+
+// WithBaseDelay sets the upper bound of the delay before the first retry of a
+// throttled request. The bound doubles with each subsequent retry, and the
+// actual delay is picked at random below it. Defaults to 100ms.
+func WithBaseDelay(d time.Duration) CreateOption {
+	return func(w *writerImpl) {
+		w.retry.baseDelay = d
+	}
+}
+
+// WithMaxDelay caps the delay between retries of a throttled request.
+// Defaults to 30s.
+func WithMaxDelay(d time.Duration) CreateOption {
+	return func(w *writerImpl) {
+		w.retry.maxDelay = d
+	}
+}
+
+// WithSequenceTokenRetries sets the number of times getting the sequence token
+// of an existing log stream is retried when creating a writer. The delay
+// before each retry is picked at random below a bound starting at 100ms and
+// doubling with each retry, up to 30s. Defaults to 5.
+func WithSequenceTokenRetries(n int) CreateOption {
+	return func(w *writerImpl) {
+		w.tokenRetry.maxRetries = n
+	}
+}
+
+// WithAutoRecreateStream controls whether the log stream is recreated if it
+// gets deleted while the writer is running. Defaults to true. When disabled,
+// the ResourceNotFoundException is returned from subsequent writes instead.
+func WithAutoRecreateStream(recreate bool) CreateOption {
+	return func(w *writerImpl) {
+		w.autoRecreateStream = recreate
+	}
+}
+
+// WithCreateGroupIfMissing makes Create create the log group if it doesn't
+// exist yet, rather than failing with a ResourceNotFoundException. This
+// requires the logs:CreateLogGroup IAM permission.
+func WithCreateGroupIfMissing() CreateOption {
+	return func(w *writerImpl) {
+		w.createGroup = true
+	}
+}
+
+// WithLogGroupRetention sets the retention policy, in days, of a log group
+// created by WithCreateGroupIfMissing. It has no effect on existing groups or
+// without WithCreateGroupIfMissing. This requires the logs:PutRetentionPolicy
+// IAM permission.
+func WithLogGroupRetention(days int) CreateOption {
+	return func(w *writerImpl) {
+		w.groupRetention = aws.Int64(int64(days))
+	}
+}
+
+// WithFlushThresholdBytes triggers a flush as soon as the buffered events reach
+// n bytes, counting 26 bytes of overhead per event, rather than waiting for
+// the next tick. These flushes are serialized with the periodic ones, so a
+// flush triggered while another one is in progress sends whatever remains in
+// the buffer once the first one completes.
+func WithFlushThresholdBytes(n int) CreateOption {
+	return func(w *writerImpl) {
+		w.events.thresholdBytes = n
+		w.events.onThreshold = w.triggerFlush
+	}
+}
+
+// WithFlushThresholdEvents triggers a flush as soon as n events are buffered,
+// rather than waiting for the next tick. See WithFlushThresholdBytes for how
+// this interacts with periodic flushes.
+func WithFlushThresholdEvents(n int) CreateOption {
+	return func(w *writerImpl) {
+		w.events.thresholdEvents = n
+		w.events.onThreshold = w.triggerFlush
+	}
+}
+
+// WithBufferCapacity caps the number of events buffered between flushes. What
+// happens to further events is determined by WithBufferFullPolicy. The buffer
+// is unbounded by default.
+func WithBufferCapacity(maxEvents int) CreateOption {
+	return func(w *writerImpl) {
+		w.events.capacity = maxEvents
+	}
+}
+
+// WithBufferFullPolicy sets the policy applied when the buffer reaches the
+// capacity set by WithBufferCapacity. Defaults to BufferFullPolicyBlock.
+func WithBufferFullPolicy(policy BufferFullPolicy) CreateOption {
+	return func(w *writerImpl) {
+		w.events.fullPolicy = policy
+	}
+}
+
+// WithSortEvents keeps the events in each batch in ascending timestamp order,
+// as required by CloudWatch Logs, even when events are written concurrently or
+// middleware changes their timestamps.
+func WithSortEvents() CreateOption {
+	return func(w *writerImpl) {
+		w.events.sorted = true
+	}
+}
+
+// WithDeadLetterHandler sets a function called with log events which are
+// dropped rather than sent, and the reason why. Events with a timestamp
+// CloudWatch Logs would reject are passed along with ErrEventOutOfRange, and
+// the batches left to send when a flush fails the writer along with the error.
+// By default, dropped events are logged as warnings to the group's logger.
+func WithDeadLetterHandler(fn func(events []*cloudwatchlogs.InputLogEvent, err error)) CreateOption {
+	return func(w *writerImpl) {
+		w.deadLetter = fn
+	}
+}
+
+// WithMaxEventAge sets the age beyond which log events are dropped when
+// written, and passed to the dead-letter handler along with ErrEventTooOld,
+// rather than being rejected by CloudWatch Logs once sent. Events only get old
+// when middleware changes their timestamp. Defaults to MaxEventAge.
+func WithMaxEventAge(d time.Duration) CreateOption {
+	return func(w *writerImpl) {
+		w.maxEventAge = d
+	}
+}
+
+// WithHeartbeat makes the writer send an event with the given message, such as
+// "[heartbeat]", once no events were sent for interval, so that quiet streams
+// keep appearing in dashboards. Heartbeats bypass event middleware, so the
+// message is sent as is, and should be easy to tell apart from real events.
+// The interval is only checked on each flush, so heartbeats can be late by up
+// to the flush interval.
+func WithHeartbeat(interval time.Duration, message string) CreateOption {
+	return func(w *writerImpl) {
+		w.heartbeatInterval = interval
+		w.heartbeatMessage = message
+	}
+}
+
+// WithWriteTimeout bounds the time a single PutLogEvents call may take. Batches
+// which time out are put back in the buffer for the next flush, without
+// advancing the sequence token.
+func WithWriteTimeout(d time.Duration) CreateOption {
+	return func(w *writerImpl) {
+		w.writeTimeout = d
+	}
+}
+
+// WithCloseTimeout bounds the time Close spends sending the buffered events.
+// Once it expires, Close returns a *DrainTimeoutError with the number of events
+// which were not sent. By default, Close waits until all events are sent, or a
+// flush fails, in which case it returns the error of that flush.
+func WithCloseTimeout(d time.Duration) CreateOption {
+	return func(w *writerImpl) {
+		w.closeTimeout = d
+	}
+}
+
+// WithFlushInterval sets how often buffered events are sent to AWS CloudWatch
+// Logs. Defaults to 200ms.
+//
+// CloudWatch Logs accepts at most 5 PutLogEvents requests per second per log
+// stream, and returns a ThrottlingException beyond that, so intervals shorter
+// than 200ms are raised to 200ms. Use a longer interval to send fewer, larger
+// batches from quiet streams. See
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/logs/cloudwatch_limits_cwl.html
+// for the current limits.
+func WithFlushInterval(d time.Duration) CreateOption {
+	return func(w *writerImpl) {
+		if d < writeThrottle {
+			d = writeThrottle
+		}
+		w.flushInterval = d
+	}
+}
+
+// WithUnsafeFlushInterval sets how often buffered events are sent to AWS
+// CloudWatch Logs without enforcing the CloudWatch Logs rate limits described
+// in WithFlushInterval. It is mostly useful for tests. Intervals which aren't
+// positive are ignored.
+func WithUnsafeFlushInterval(d time.Duration) CreateOption {
+	return func(w *writerImpl) {
+		if d > 0 {
+			w.flushInterval = d
+		}
+	}
+}
+
+// WithBatchCallback allows setting a function introspecting each batch of log
+// events before it's sent to AWS CloudWatch Logs, for example to keep an audit
+// trail. It's called synchronously by the flush, every time the batch is sent,
+// so it should be quick and must not modify the events. A panic in callback
+// is recovered and sent to the error channel, without failing the writer.
+func WithBatchCallback(callback func([]*cloudwatchlogs.InputLogEvent)) CreateOption {
+	return func(w *writerImpl) {
+		w.onBatch = callback
+	}
+}
+
+// WithPostFlushCallback allows setting a function called with the response of
+// AWS CloudWatch Logs once a batch was sent, or the error sending it, after
+// throttled requests were retried. Panics are handled like in
+// WithBatchCallback.
+func WithPostFlushCallback(callback func(*cloudwatchlogs.PutLogEventsOutput, error)) CreateOption {
+	return func(w *writerImpl) {
+		w.onFlushed = callback
+	}
+}
+
+// WithSizeObserver allows setting a function called with the size in bytes of
+// the message of each log event once it's buffered, for example to feed a
+// histogram when tuning batches. It's called by Write, without holding the
+// lock serializing flushes, except for the last event of multi-line messages
+// which is buffered by the next flush. Panics are handled like in
+// WithBatchCallback.
+func WithSizeObserver(fn func(eventBytes int)) CreateOption {
+	return func(w *writerImpl) {
+		w.onEventSize = fn
+	}
+}
+
+// WithBatchSizeObserver allows setting a function called with the number of
+// events of each batch and its size in bytes, as counted by CloudWatch Logs,
+// before it's sent. Unlike WithSizeObserver, it's called by the flush, which
+// holds the writer's lock so that batches are sent in order, so it should be
+// quick. Panics are handled like in WithBatchCallback.
+func WithBatchSizeObserver(fn func(batchEvents int, batchBytes int)) CreateOption {
+	return func(w *writerImpl) {
+		w.onBatchSize = fn
+	}
+}
+
+// WithErrorChannel makes the writer send every error encountered while
+// flushing, including RejectedLogEventsInfoError and ErrWriteTimeout, to ch.
+// Sends never block: errors are discarded if ch is not ready to receive them,
+// so callers must keep draining ch, ideally giving it a buffer. Flush errors
+// are still returned from subsequent calls to Write as well.
+func WithErrorChannel(ch chan<- error) CreateOption {
+	return func(w *writerImpl) {
+		w.errChan = ch
+	}
+}
+
 func freezeTime(now time.Time) CreateOption {
 	return func(w *writerImpl) {
 		w.nowFunc = func() time.Time {
@@ -57,115 +626,690 @@ func freezeTime(now time.Time) CreateOption {
 }
 
 // Write takes the buffer, and creates a Cloudwatch Log event for each
-// individual line. If Flush returns an error, subsequent calls to Write will
-// fail.
+// individual line. If Flush returns an error, or the writer's context is done,
+// subsequent calls to Write will fail.
 func (w *writerImpl) Write(b []byte) (int, error) {
+	return w.write(bytes.NewReader(b), len(b))
+}
+
+// WriteString is like Write, but avoids copying s into a byte slice.
+func (w *writerImpl) WriteString(s string) (int, error) {
+	return w.write(strings.NewReader(s), len(s))
+}
+
+// write buffers the size bytes read from r.
+func (w *writerImpl) write(r io.Reader, size int) (int, error) {
 	if w.closed {
 		return 0, io.ErrClosedPipe
 	}
 
-	if w.err != nil {
-		return 0, w.err
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if err := w.getErr(); err != nil {
+		return 0, err
+	}
+
+	if w.dropping() {
+		return size, nil
+	}
+
+	n, err := w.buffer(r)
+	w.reportBufferSize()
+	if err != nil {
+		return n, err
+	}
+
+	if w.shouldRotate(n) {
+		if err := w.rotate(); err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+// shouldRotate records that n bytes were written, and reports whether a
+// rotation threshold has been reached.
+func (w *writerImpl) shouldRotate(n int) bool {
+	if w.rotateBytes <= 0 && w.rotateAfter <= 0 {
+		return false
+	}
+
+	w.rotateMu.Lock()
+	defer w.rotateMu.Unlock()
+
+	now := w.now()
+	if w.rotatedAt.IsZero() {
+		w.rotatedAt = now
+	}
+	w.writtenBytes += int64(n)
+
+	if w.rotateBytes > 0 && w.writtenBytes >= w.rotateBytes {
+		return true
+	}
+	return w.rotateAfter > 0 && now.Sub(w.rotatedAt) >= w.rotateAfter
+}
+
+// rotate sends the buffered events to the current log stream, then creates a
+// new one and makes it the target of subsequent flushes.
+func (w *writerImpl) rotate() error {
+	if err := w.flushBatch(); err != nil {
+		return err
+	}
+
+	now := w.now()
+
+	w.Lock()
+	defer w.Unlock()
+
+	if w.baseStreamName == "" {
+		w.baseStreamName = aws.StringValue(w.streamName)
+	}
+
+	old := aws.StringValue(w.streamName)
+	name := w.baseStreamName + "-" + now.UTC().Format(rotatedStreamFormat)
+
+	_, err := w.client.CreateLogStreamWithContext(w.requestContext(), &cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  w.groupName,
+		LogStreamName: aws.String(name),
+	})
+
+	// Rotating more than once a second reuses the same stream, in which case
+	// the sequence token will be recovered on the first flush.
+	if _, ok := err.(*cloudwatchlogs.ResourceAlreadyExistsException); err != nil && !ok {
+		return errors.Wrap(err, "could not create the rotated log stream")
+	}
+
+	w.streamName = aws.String(name)
+	w.sequenceToken = nil
+
+	w.rotateMu.Lock()
+	w.writtenBytes, w.rotatedAt = 0, now
+	w.rotateMu.Unlock()
+
+	if w.onRotate != nil {
+		w.onRotate(old, name)
+	}
+
+	return nil
+}
+
+// Reset sends the buffered events to the current log stream, then switches the
+// writer to streamName. It's safe to call concurrently with Write, Flush and
+// the background flushes: it holds the writer's lock while the new stream is
+// created and its sequence token looked up, so that no flush runs until the
+// writer uses the new stream, or keeps the old one if that fails. Events
+// written meanwhile are sent to either stream.
+func (w *writerImpl) Reset(ctx context.Context, streamName string) (err error) {
+	defer w.recoverPanic(&err)
+
+	if w.isClosed() {
+		return io.ErrClosedPipe
+	}
+	if w.group == nil {
+		return errors.New("writer wasn't created by a group")
+	}
+
+	w.flushPending()
+	if err := w.flushBatch(); err != nil {
+		return err
+	}
+
+	// Holding the lock keeps flushes from using the old stream's sequence
+	// token with the new stream, and Close from unregistering the writer
+	// before it's registered under the new stream.
+	w.Lock()
+	defer w.Unlock()
+
+	if w.closed {
+		return io.ErrClosedPipe
 	}
 
-	return w.buffer(b)
+	old := aws.StringValue(w.streamName)
+	token := w.sequenceToken
+	w.sequenceToken = nil
+	if err := w.group.openStream(ctx, w, streamName); err != nil {
+		w.sequenceToken = token
+		return err
+	}
+
+	w.streamName = aws.String(streamName)
+	w.baseStreamName = ""
+	w.rotateMu.Lock()
+	w.writtenBytes, w.rotatedAt = 0, time.Time{}
+	w.rotateMu.Unlock()
+
+	if w.onClose != nil {
+		w.onClose()
+		w.group.register(w, streamName)
+	}
+
+	w.log().Info("reset writer", "from", old, "to", streamName)
+	return nil
+}
+
+// isClosed reports whether Close was called.
+func (w *writerImpl) isClosed() bool {
+	w.Lock()
+	defer w.Unlock()
+	return w.closed
 }
 
-// Start continuously flushing the buffered events.
+// Start continuously flushing the buffered events, either periodically or
+// when triggered by a flush threshold, until the writer is closed or its
+// context is done.
 func (w *writerImpl) start() (err error) {
+	defer w.recoverPanic(&err)
+
 	for {
 		select {
 		case <-w.closeChan:
 			return
+		case <-w.ctx.Done():
+			w.unregister()
+			return w.drain()
 		case <-w.throttle.C:
-			if err = w.flushBatch(); err != nil {
-				return
-			}
+		case <-w.flushChan:
+		}
+
+		// Only errors failing the writer stop the loop, as opposed to
+		// timeouts and errors handled by the circuit breaker.
+		if err = w.flushBatch(); err != nil && w.getErr() != nil {
+			return
 		}
 	}
 }
 
+// drain makes a last attempt at sending the buffered events once the writer's
+// context is done, then fails the writer with the context's error.
+func (w *writerImpl) drain() error {
+	ctx, cancel := context.WithTimeout(context.Background(), cancelFlushTimeout)
+	defer cancel()
+
+	w.Lock()
+	w.drainCtx = ctx
+	w.Unlock()
+
+	if err := w.flushBatch(); err != nil {
+		w.reportError(err)
+	}
+
+	err := w.ctx.Err()
+	w.setErr(err)
+	return err
+}
+
+// drainExpired reports whether the time for a last flush is up. The caller must
+// hold the lock.
+func (w *writerImpl) drainExpired() bool {
+	return w.drainCtx != nil && w.drainCtx.Err() != nil
+}
+
+// requestContext returns the context for requests to AWS CloudWatch Logs. The
+// caller must hold the lock.
+func (w *writerImpl) requestContext() context.Context {
+	if w.drainCtx != nil {
+		return w.drainCtx
+	}
+	return w.ctx
+}
+
+// triggerFlush asks the background goroutine to flush without waiting for the
+// next tick. It does not block if a flush has already been requested.
+func (w *writerImpl) triggerFlush() {
+	select {
+	case w.flushChan <- struct{}{}:
+	default:
+	}
+}
+
 // Close closes the writer. Any subsequent calls to Write will return
-// io.ErrClosedPipe.
+// io.ErrClosedPipe, and calling Close again returns the same result.
 func (w *writerImpl) Close() error {
+	w.closeOnce.Do(func() {
+		w.closeErr = w.close()
+	})
+	return w.closeErr
+}
+
+func (w *writerImpl) close() (err error) {
 	defer w.throttle.Stop()
+	defer w.recoverPanic(&err)
 
+	w.Lock()
 	w.closed = true
+	w.Unlock()
 	close(w.closeChan)
 
-	for w.events.hasMore() {
-		if w.flushTrottled() != nil {
+	if w.onClose != nil {
+		w.onClose()
+	}
+
+	w.flushPending()
+
+	ctx, cancel := w.closeContext()
+	defer cancel()
+
+	for w.buffered().hasMore() {
+		if err := w.flushTrottled(ctx); err == ErrCircuitOpen {
+			return err
+		} else if w.closeTimeout > 0 && ctx.Err() == context.DeadlineExceeded {
+			return &DrainTimeoutError{Remaining: w.buffered().len()}
+		} else if err != nil && w.getErr() != nil {
 			break
+		} else if err != nil && w.closeTimeout <= 0 {
+			// Without a close timeout, a flush which keeps failing, for
+			// example timing out, would be retried forever.
+			return err
 		}
 	}
 
-	return w.err
+	return w.getErr()
 }
 
-func (w *writerImpl) flushTrottled() error {
-	<-w.throttle.C
+// Flush sends all buffered events to AWS CloudWatch Logs. If a previous flush
+// failed, its error is returned instead.
+func (w *writerImpl) Flush() (err error) {
+	defer w.recoverPanic(&err)
+
+	if w.closed {
+		return io.ErrClosedPipe
+	}
+
+	if err := w.getErr(); err != nil {
+		return err
+	}
+
 	return w.flushBatch()
 }
 
+func (w *writerImpl) flushTrottled(ctx context.Context) error {
+	select {
+	case <-w.throttle.C:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return w.flushBatch()
+}
+
+// closeContext returns the context bounding Close, which also applies to
+// requests made while closing.
+func (w *writerImpl) closeContext() (context.Context, context.CancelFunc) {
+	if w.closeTimeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.closeTimeout)
+
+	w.Lock()
+	w.drainCtx = ctx
+	w.Unlock()
+
+	return ctx, cancel
+}
+
 func (w *writerImpl) flushBatch() error {
 	w.Lock()
 	defer w.Unlock()
 
-	events := w.events.drain()
+	w.flushPending()
+	w.addHeartbeat()
+	defer w.reportBufferSize()
 
-	// No events to flush.
-	if len(events) == 0 {
-		return nil
+	if w.buffered().hasMore() && !w.allowFlush() {
+		return ErrCircuitOpen
+	}
+
+	// Batches are sent sequentially so that the sequence token returned for
+	// one batch is used for the next.
+	batches := w.buffered().drain()
+	for i, events := range batches {
+		// Keep the remaining events, if any, in case the batch is requeued.
+		if events = w.dropOutOfRange(events); len(events) == 0 {
+			continue
+		}
+		batches[i] = events
+
+		if w.bucket != nil {
+			if err := w.bucket.wait(w.requestContext(), len(events)); err != nil {
+				w.buffered().requeue(batches[i:])
+				return err
+			}
+		}
+
+		id := w.recordPending(events)
+		start := time.Now()
+		err := w.flush(events)
+		elapsed := time.Since(start)
+		w.stats.recordFlush(elapsed, err)
+		if w.metrics != nil {
+			w.metrics.ObserveFlush(elapsed, len(events), batchBytes(events), err)
+		}
+		if err != nil {
+			w.log().Error("could not flush batch", "stream", aws.StringValue(w.streamName), "events", len(events), "error", err)
+			w.reportError(err)
+		} else {
+			w.log().Info("flushed batch", "stream", aws.StringValue(w.streamName), "events", len(events), "bytes", batchBytes(events), "latency", elapsed)
+		}
+
+		// Rejected events mean CloudWatch Logs is reachable.
+		_, rejected := err.(*RejectedLogEventsInfoError)
+		if rejected {
+			w.recordFlush(nil)
+		} else {
+			w.recordFlush(err)
+		}
+
+		// Timeouts are transient, so rather than failing the writer the
+		// unsent batches are kept for the next flush. So are all other
+		// failures when using a circuit breaker, and once the time for a
+		// last flush is up, so that unsent events can be counted.
+		// Requeued batches are recorded again when they're retried, while
+		// batches failing the writer are left for the next writer to send.
+		requeue := err == ErrWriteTimeout || (err != nil && !rejected && (w.breaker != nil || w.drainExpired()))
+		if err == nil || rejected || requeue {
+			w.ack(id)
+		}
+
+		if requeue {
+			w.buffered().requeue(batches[i:])
+			return err
+		}
+
+		if w.setErr(err); err != nil {
+			// The writer can't send the batches which weren't tried yet
+			// anymore.
+			for _, events := range batches[i+1:] {
+				w.dropEvents(events, err)
+			}
+			return err
+		}
+		w.lastSent = w.now()
+	}
+
+	if a, ok := w.buffered().(*eventBufferAdapter); ok {
+		if err := a.commit(); err != nil {
+			w.log().Error("could not commit sent events", "stream", aws.StringValue(w.streamName), "error", err)
+		}
+	}
+
+	return nil
+}
+
+// addHeartbeat buffers a heartbeat event if it's due. The caller must hold the
+// lock.
+func (w *writerImpl) addHeartbeat() {
+	if w.heartbeatInterval <= 0 {
+		return
+	}
+
+	now := w.now()
+	if w.lastSent.IsZero() {
+		w.lastSent = now
+		return
+	}
+
+	if w.buffered().hasMore() || now.Sub(w.lastSent) < w.heartbeatInterval {
+		return
+	}
+
+	w.buffered().addUnbounded(&cloudwatchlogs.InputLogEvent{
+		Message:   aws.String(w.heartbeatMessage),
+		Timestamp: aws.Int64(toMillis(now)),
+	})
+}
+
+// dropOutOfRange returns the events with a timestamp CloudWatch Logs accepts,
+// passing the others to the dead-letter handler.
+func (w *writerImpl) dropOutOfRange(events []*cloudwatchlogs.InputLogEvent) []*cloudwatchlogs.InputLogEvent {
+	now := w.now()
+	oldest, newest := toMillis(now.Add(-maxEventAge)), toMillis(now.Add(maxEventFuture))
+
+	var ret, dropped []*cloudwatchlogs.InputLogEvent
+	for i, event := range events {
+		ts := aws.Int64Value(event.Timestamp)
+		if ts >= oldest && ts <= newest {
+			if dropped != nil {
+				ret = append(ret, event)
+			}
+			continue
+		}
+
+		// Only copy the events once one needs dropping.
+		if dropped == nil {
+			ret = append([]*cloudwatchlogs.InputLogEvent(nil), events[:i]...)
+		}
+		dropped = append(dropped, event)
 	}
 
-	w.err = w.flush(events)
+	if dropped == nil {
+		return events
+	}
+
+	w.dropEvents(dropped, ErrEventOutOfRange)
+	return ret
+}
+
+// buffered returns where events are added to and flushed from.
+func (w *writerImpl) buffered() eventStore {
+	if w.store != nil {
+		return w.store
+	}
+	return w.events
+}
+
+// dropEvents passes events which won't be sent to the dead-letter handler, or
+// logs them to the group's logger if there isn't one.
+func (w *writerImpl) dropEvents(events []*cloudwatchlogs.InputLogEvent, err error) {
+	if w.deadLetter != nil {
+		w.deadLetter(events, err)
+		return
+	}
+	w.log().Warn("dropping log events", "stream", aws.StringValue(w.streamName), "events", len(events), "error", err)
+}
+
+// recoverPanic turns a panic, for example in event middleware or the AWS SDK,
+// into an error failing the writer. The error is stored in err and sent to the
+// error channel. It must be deferred.
+func (w *writerImpl) recoverPanic(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	*err = errors.Errorf("recovered from panic while writing log events: %v", r)
+	w.setErr(*err)
+	w.reportError(*err)
+}
+
+// runCallback calls fn, a user callback named name. Unlike recoverPanic, a panic
+// in fn doesn't fail the writer, and is only sent to the error channel.
+func (w *writerImpl) runCallback(name string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := errors.Errorf("recovered from panic in %s callback: %v", name, r)
+			w.log().Error("callback panicked", "stream", aws.StringValue(w.streamName), "error", err)
+			w.reportError(err)
+		}
+	}()
+
+	fn()
+}
+
+func (w *writerImpl) getErr() error {
+	w.errMu.RLock()
+	defer w.errMu.RUnlock()
 	return w.err
 }
 
+func (w *writerImpl) setErr(err error) {
+	w.errMu.Lock()
+	defer w.errMu.Unlock()
+	w.err = err
+}
+
+// reportError sends err to the error channel, if there is one, without
+// blocking.
+func (w *writerImpl) reportError(err error) {
+	if w.errChan == nil {
+		return
+	}
+
+	select {
+	case w.errChan <- err:
+	default:
+	}
+}
+
 // flush flushes a slice of log events. This method should be called
 // sequentially to ensure that the sequence token is updated properly.
+// Throttled requests are retried with exponential backoff.
 func (w *writerImpl) flush(events []*cloudwatchlogs.InputLogEvent) (err error) {
 	var resp *cloudwatchlogs.PutLogEventsOutput
+	var recreated, throttled bool
 
-	for {
-		resp, err = w.client.PutLogEventsWithContext(w.ctx, &cloudwatchlogs.PutLogEventsInput{
-			LogEvents:     events,
-			LogGroupName:  w.groupName,
-			LogStreamName: w.streamName,
-			SequenceToken: w.sequenceToken,
-		})
+	if w.onBatch != nil {
+		w.runCallback("batch", func() { w.onBatch(events) })
+	}
+	if w.onBatchSize != nil {
+		w.runCallback("batch size", func() { w.onBatchSize(len(events), batchBytes(events)) })
+	}
+	if w.onFlushed != nil {
+		defer func() {
+			w.runCallback("post-flush", func() { w.onFlushed(resp, err) })
+		}()
+	}
+
+	for attempt := 0; ; {
+		resp, err = w.putLogEvents(events)
+
+		// An earlier attempt whose response was lost already sent the batch,
+		// so it mustn't be sent again.
+		if accepted, ok := err.(*cloudwatchlogs.DataAlreadyAcceptedException); ok {
+			w.log().Warn("batch already accepted", "stream", aws.StringValue(w.streamName), "error", err)
+			resp, err = &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: accepted.ExpectedSequenceToken}, nil
+		}
 
 		if err == nil {
 			break
 		}
 
-		sequenceError, ok := err.(*cloudwatchlogs.InvalidSequenceTokenException)
-		if !ok {
+		// The throttle is only slowed down once per flush, however many of
+		// its attempts were throttled.
+		if request.IsErrorThrottle(err) && !throttled {
+			throttled = true
+			defer w.adaptThrottle(true)
+		}
+
+		if request.IsErrorThrottle(err) && attempt < w.retry.maxRetries {
+			delay := w.retry.delay(attempt)
+			w.log().Warn("retrying throttled request", "stream", aws.StringValue(w.streamName), "attempt", attempt+1, "delay", delay)
+			if err = aws.SleepWithContext(w.requestContext(), delay); err != nil {
+				return err
+			}
+			attempt++
+			continue
+		}
+
+		// The exception carries the sequence token CloudWatch expects next.
+		switch sequenceError := err.(type) {
+		case *cloudwatchlogs.InvalidSequenceTokenException:
+			w.sequenceToken = sequenceError.ExpectedSequenceToken
+			w.log().Warn("recovered sequence token", "stream", aws.StringValue(w.streamName), "error", err)
+		case *cloudwatchlogs.ResourceNotFoundException:
+			// Only try recreating the stream once, in case it's the group
+			// that's missing.
+			if !w.autoRecreateStream || recreated {
+				return err
+			}
+			if err = w.recreateStream(); err != nil {
+				return err
+			}
+			w.log().Warn("recreated log stream", "stream", aws.StringValue(w.streamName))
+			recreated = true
+		default:
 			return err
 		}
+	}
 
-		w.sequenceToken = sequenceError.ExpectedSequenceToken
+	if !throttled {
+		w.adaptThrottle(false)
 	}
 
 	if resp.RejectedLogEventsInfo != nil {
-		w.err = &RejectedLogEventsInfoError{Info: resp.RejectedLogEventsInfo}
-		return w.err
+		rejected := &RejectedLogEventsInfoError{Info: resp.RejectedLogEventsInfo, Events: events}
+		accepted := rejected.acceptedEvents()
+		w.stats.recordSent(accepted, len(events)-len(accepted))
+		return rejected
 	}
 
 	w.sequenceToken = resp.NextSequenceToken
+	w.stats.recordSent(events, 0)
+	w.log().Info("updated sequence token", "stream", aws.StringValue(w.streamName), "token", aws.StringValue(w.sequenceToken))
+
+	if w.tokenStore != nil && w.sequenceToken != nil {
+		w.tokenStore.Save(aws.StringValue(w.streamName), *w.sequenceToken)
+	}
+
+	return nil
+}
+
+// putLogEvents sends a single PutLogEvents request, applying the write timeout
+// if there is one.
+func (w *writerImpl) putLogEvents(events []*cloudwatchlogs.InputLogEvent) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	parent := w.requestContext()
+	ctx := parent
+	if w.writeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, w.writeTimeout)
+		defer cancel()
+	}
+
+	resp, err := w.client.PutLogEventsWithContext(ctx, &cloudwatchlogs.PutLogEventsInput{
+		LogEvents:     events,
+		LogGroupName:  w.groupName,
+		LogStreamName: w.streamName,
+		SequenceToken: w.sequenceToken,
+	})
+
+	// Only our own deadline counts as a timeout, not the writer's context
+	// being done.
+	if err != nil && ctx.Err() == context.DeadlineExceeded && parent.Err() == nil {
+		return nil, ErrWriteTimeout
+	}
+
+	return resp, err
+}
+
+// recreateStream creates the log stream again after it had been deleted. A new
+// stream does not have a sequence token.
+func (w *writerImpl) recreateStream() error {
+	_, err := w.client.CreateLogStreamWithContext(w.requestContext(), &cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  w.groupName,
+		LogStreamName: w.streamName,
+	})
+
+	// Someone else may have beaten us to it, in which case the sequence token
+	// will be recovered on the next attempt.
+	if _, ok := err.(*cloudwatchlogs.ResourceAlreadyExistsException); err != nil && !ok {
+		return errors.Wrap(err, "could not recreate the log stream")
+	}
 
+	w.sequenceToken = nil
 	return nil
 }
 
-// buffer splits up b into individual log events and inserts them into the
-// buffer.
-func (w *writerImpl) buffer(b []byte) (int, error) {
-	r := bufio.NewReader(bytes.NewReader(b))
+// buffer splits up what's read from r into individual log events and inserts
+// them into the buffer. Events exceeding the per-event size limit are handled
+// according to the writer's OversizePolicy.
+func (w *writerImpl) buffer(src io.Reader) (n int, err error) {
+	defer w.recoverPanic(&err)
 
-	var (
-		n   int
-		eof bool
-	)
+	r := bufio.NewReader(src)
+
+	var eof bool
 
 	for !eof {
 		b, err := r.ReadBytes('\n')
@@ -181,26 +1325,199 @@ func (w *writerImpl) buffer(b []byte) (int, error) {
 			continue
 		}
 
-		event := &cloudwatchlogs.InputLogEvent{
-			Message:   aws.String(string(b)),
-			Timestamp: aws.Int64(w.now().UnixNano() / 1000000),
+		if w.multiLineStart != nil {
+			err = w.aggregate(b)
+		} else {
+			err = w.enqueue(b, w.now())
 		}
-
-		if w.onEvent != nil {
-			w.onEvent(event)
+		if err != nil {
+			return n, err
 		}
 
-		w.events.add(event)
-
 		n += len(b)
 	}
 
 	return n, nil
 }
 
+// enqueue turns message into a log event and adds it to the buffer, unless it's
+// dropped by the writer's policies.
+func (w *writerImpl) enqueue(message []byte, t time.Time) error {
+	event, err := w.newEvent(message, t)
+	if event == nil || err != nil {
+		return err
+	}
+	if err = w.buffered().add(w.ctx, event); err != nil {
+		return err
+	}
+	w.observeSize(event)
+	return nil
+}
+
+// observeSize passes the size of event to the size observer, if any.
+func (w *writerImpl) observeSize(event *cloudwatchlogs.InputLogEvent) {
+	if w.onEventSize != nil {
+		w.runCallback("size", func() { w.onEventSize(len(aws.StringValue(event.Message))) })
+	}
+}
+
+func (w *writerImpl) newEvent(message []byte, t time.Time) (*cloudwatchlogs.InputLogEvent, error) {
+	if w.validateJSON && !json.Valid(message) {
+		w.log().Warn("dropping log event which isn't valid JSON", "stream", aws.StringValue(w.streamName), "message", fmt.Sprintf("%q", message))
+		return nil, nil
+	}
+
+	if w.dedup != nil {
+		if message = w.dedup.filter(message, t); message == nil {
+			return nil, nil
+		}
+	}
+
+	if w.stripNewlines || w.newlineReplace != nil {
+		if message = w.normalizeNewlines(message); len(message) == 0 {
+			return nil, nil
+		}
+	}
+
+	if len(w.messagePrefix) > 0 {
+		message = append(append([]byte(nil), w.messagePrefix...), message...)
+	}
+
+	event := &cloudwatchlogs.InputLogEvent{
+		Message:   aws.String(string(message)),
+		Timestamp: aws.Int64(toMillis(t)),
+	}
+
+	for _, fn := range w.middleware {
+		if event = fn(event); event == nil {
+			return nil, nil
+		}
+		message = []byte(aws.StringValue(event.Message))
+	}
+
+	if len(w.enrichers) > 0 {
+		for _, fn := range w.enrichers {
+			fn(w.ctx, event)
+		}
+		message = []byte(aws.StringValue(event.Message))
+	}
+
+	if w.hostname != "" {
+		message = append([]byte("host="+w.hostname+" "), message...)
+	}
+
+	if w.tooOld(event) {
+		event.Message = aws.String(string(message))
+		w.dropEvents([]*cloudwatchlogs.InputLogEvent{event}, ErrEventTooOld)
+		return nil, nil
+	}
+
+	limit := maxEventMessageBytes
+	if w.hmacKey != nil {
+		limit -= signatureFieldBytes
+	}
+	message, err := w.oversizePolicy.applyLimit(message, limit)
+	if message == nil || err != nil {
+		return nil, err
+	}
+	event.Message = aws.String(string(message))
+
+	if w.hmacKey != nil {
+		w.sign(event)
+	}
+
+	if w.onEvent != nil {
+		w.onEvent(event)
+	}
+
+	return event, nil
+}
+
+// normalizeNewlines strips the trailing line break of message and replaces the
+// embedded ones, according to the writer's options.
+func (w *writerImpl) normalizeNewlines(message []byte) []byte {
+	body := bytes.TrimRight(message, "\r\n")
+	trailer := message[len(body):]
+
+	if w.newlineReplace != nil {
+		r := []byte(*w.newlineReplace)
+		body = bytes.ReplaceAll(body, []byte("\r\n"), r)
+		body = bytes.ReplaceAll(body, []byte("\n"), r)
+	}
+
+	if w.stripNewlines {
+		return body
+	}
+	return append(body[:len(body):len(body)], trailer...)
+}
+
+// tooOld reports whether the event is older than the maximum event age.
+func (w *writerImpl) tooOld(event *cloudwatchlogs.InputLogEvent) bool {
+	maxAge := w.maxEventAge
+	if maxAge <= 0 {
+		maxAge = MaxEventAge
+	}
+	return aws.Int64Value(event.Timestamp) < toMillis(w.now().Add(-maxAge))
+}
+
+// aggregate appends line to the pending multi-line event, or buffers the
+// pending event and starts a new one with line.
+func (w *writerImpl) aggregate(line []byte) error {
+	maxBytes := w.multiLineMaxBytes
+	if maxBytes == 0 {
+		maxBytes = maxEventMessageBytes
+	}
+
+	w.pendingMu.Lock()
+	if len(w.pending) > 0 && !w.multiLineStart.Match(line) && len(w.pending)+len(line) <= maxBytes {
+		w.pending = append(w.pending, line...)
+		w.pendingMu.Unlock()
+		return nil
+	}
+
+	message, t := w.pending, w.pendingTime
+	w.pending, w.pendingTime = append([]byte(nil), line...), w.now()
+	w.pendingMu.Unlock()
+
+	if len(message) == 0 {
+		return nil
+	}
+	return w.enqueue(message, t)
+}
+
+// flushPending buffers the pending multi-line event, if any. Capacity isn't
+// enforced, since this is called before draining the buffer.
+func (w *writerImpl) flushPending() {
+	w.pendingMu.Lock()
+	message, t := w.pending, w.pendingTime
+	w.pending = nil
+	w.pendingMu.Unlock()
+
+	if len(message) == 0 {
+		return
+	}
+
+	event, err := w.newEvent(message, t)
+	if err != nil {
+		w.reportError(err)
+		w.setErr(err)
+		return
+	}
+	if event != nil {
+		w.buffered().addUnbounded(event)
+		w.observeSize(event)
+	}
+}
+
 func (w *writerImpl) now() time.Time {
 	if w.nowFunc == nil {
 		return time.Now()
 	}
 	return w.nowFunc()
 }
+
+// toMillis converts t to the number of milliseconds since the epoch, which is
+// how CloudWatch Logs represents timestamps.
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / 1000000
+}
@@ -1,14 +1,24 @@
 package cloudwatch
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -18,7 +28,7 @@ type writerTestSuite struct {
 	api                   *mockAPI
 	ctx                   context.Context
 	groupName, streamName string
-	sut                   io.WriteCloser
+	sut                   Writer
 }
 
 func (w *writerTestSuite) SetupTest() {
@@ -103,6 +113,372 @@ func (w *writerTestSuite) TestWriteRejected() {
 	w.EqualError(err, expectedError)
 }
 
+func (w *writerTestSuite) TestRejectedEvents() {
+	events := []*cloudwatchlogs.InputLogEvent{
+		{Message: aws.String("expired")},
+		{Message: aws.String("too old")},
+		{Message: aws.String("ok")},
+		{Message: aws.String("too new")},
+	}
+
+	err := &RejectedLogEventsInfoError{
+		Info: &cloudwatchlogs.RejectedLogEventsInfo{
+			ExpiredLogEventEndIndex:  aws.Int64(1),
+			TooOldLogEventEndIndex:   aws.Int64(2),
+			TooNewLogEventStartIndex: aws.Int64(3),
+		},
+		Events: events,
+	}
+
+	w.Equal(events[:1], err.ExpiredEvents())
+	w.Equal(events[:2], err.TooOldEvents())
+	w.Equal(events[3:], err.TooNewEvents())
+
+	// Missing or out of range indexes do not select any events.
+	err.Info = &cloudwatchlogs.RejectedLogEventsInfo{TooNewLogEventStartIndex: aws.Int64(4)}
+	w.Nil(err.ExpiredEvents())
+	w.Nil(err.TooOldEvents())
+	w.Nil(err.TooNewEvents())
+}
+
+func (w *writerTestSuite) TestErrorChannel() {
+	errChan := make(chan error, 1)
+	writer := w.newUnstartedWriter(w.ctx, WithErrorChannel(errChan))
+
+	info := &cloudwatchlogs.RejectedLogEventsInfo{TooOldLogEventEndIndex: aws.Int64(1)}
+	w.putLogEventsReturns(&cloudwatchlogs.PutLogEventsOutput{RejectedLogEventsInfo: info}, nil)
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+	w.Error(writer.flushBatch())
+
+	select {
+	case err := <-errChan:
+		w.Require().IsType(new(RejectedLogEventsInfoError), err)
+		w.Equal(info, err.(*RejectedLogEventsInfoError).Info)
+		w.Len(err.(*RejectedLogEventsInfoError).Events, 1)
+	default:
+		w.Fail("expected an error on the channel")
+	}
+
+	// Sending to a full channel does not block.
+	errChan <- nil
+	writer.reportError(io.ErrUnexpectedEOF)
+	w.Nil(<-errChan)
+}
+
+func (w *writerTestSuite) TestBatchCallbacks() {
+	var batches [][]*cloudwatchlogs.InputLogEvent
+	var outputs []*cloudwatchlogs.PutLogEventsOutput
+	setupCalls := len(w.api.Calls)
+	writer := w.newUnstartedWriter(
+		w.ctx,
+		WithBatchCallback(func(batch []*cloudwatchlogs.InputLogEvent) {
+			w.Len(w.api.Calls, setupCalls+len(batches), "the callback must be called before sending")
+			batches = append(batches, batch)
+		}),
+		WithPostFlushCallback(func(output *cloudwatchlogs.PutLogEventsOutput, err error) {
+			w.NoError(err)
+			outputs = append(outputs, output)
+		}),
+	)
+
+	output := &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("bacon")}
+	w.putLogEventsReturns(output, nil)
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+	w.Require().NoError(writer.flushBatch())
+
+	w.Require().Len(batches, 1)
+	w.Equal("Hello", aws.StringValue(batches[0][0].Message))
+	w.Equal([]*cloudwatchlogs.PutLogEventsOutput{output}, outputs)
+}
+
+func (w *writerTestSuite) TestBatchCallbackPanic() {
+	errChan := make(chan error, 1)
+	writer := w.newUnstartedWriter(
+		w.ctx,
+		WithErrorChannel(errChan),
+		WithBatchCallback(func([]*cloudwatchlogs.InputLogEvent) { panic("boom") }),
+	)
+	w.putLogEventsReturns(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+
+	// The batch is still sent, and the writer keeps working.
+	w.NoError(writer.flushBatch())
+	w.NoError(writer.getErr())
+	w.EqualError(<-errChan, "recovered from panic in batch callback: boom")
+	w.api.AssertCalled(w.T(), "PutLogEventsWithContext", w.ctx, mock.Anything, []request.Option(nil))
+}
+
+func (w *writerTestSuite) TestSizeObservers() {
+	var eventSizes []int
+	var batchSizes [][2]int
+	setupCalls := len(w.api.Calls)
+	writer := w.newUnstartedWriter(
+		w.ctx,
+		WithSizeObserver(func(eventBytes int) {
+			eventSizes = append(eventSizes, eventBytes)
+		}),
+		WithBatchSizeObserver(func(batchEvents, batchBytes int) {
+			w.Len(w.api.Calls, setupCalls, "the observer must be called before sending")
+			batchSizes = append(batchSizes, [2]int{batchEvents, batchBytes})
+		}),
+	)
+	w.api.On("PutLogEventsWithContext", w.ctx, mock.Anything, []request.Option(nil)).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	_, err := io.WriteString(writer, "Hello\nWorld!\n")
+	w.Require().NoError(err)
+	w.Equal([]int{6, 7}, eventSizes)
+
+	w.Require().NoError(writer.flushBatch())
+	w.Equal([][2]int{{2, 6 + 7 + 2*paddingSize}}, batchSizes)
+}
+
+func (w *writerTestSuite) TestSizeObserverPanic() {
+	errChan := make(chan error, 1)
+	writer := w.newUnstartedWriter(
+		w.ctx,
+		WithErrorChannel(errChan),
+		WithSizeObserver(func(int) { panic("boom") }),
+	)
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+	w.NoError(writer.getErr())
+	w.EqualError(<-errChan, "recovered from panic in size callback: boom")
+}
+
+func (w *writerTestSuite) TestCircuitBreaker() {
+	errChan := make(chan error, 10)
+	writer := w.newUnstartedWriter(w.ctx, WithCircuitBreaker(2, time.Minute), WithErrorChannel(errChan))
+	now := time.Unix(1, 0)
+	writer.nowFunc = func() time.Time { return now }
+
+	unavailable := awserr.New("ServiceUnavailableException", "Unavailable", nil)
+	w.putLogEventsReturns(nil, unavailable).Twice()
+	w.putLogEventsReturns(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("bacon")}, nil).Once()
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+
+	// Failures below the threshold keep the events and the circuit closed.
+	w.Equal(unavailable, writer.flushBatch())
+	w.Equal(CircuitClosed, writer.State())
+	w.Require().NoError(writer.getErr())
+
+	w.Equal(unavailable, writer.flushBatch())
+	w.Equal(CircuitOpen, writer.State())
+
+	var changes []*CircuitStateChange
+	for len(errChan) > 0 {
+		if change, ok := (<-errChan).(*CircuitStateChange); ok {
+			changes = append(changes, change)
+		}
+	}
+	w.Equal([]*CircuitStateChange{{From: CircuitClosed, To: CircuitOpen, Err: unavailable}}, changes)
+
+	// While open, new events are dropped and nothing is sent.
+	n, err := io.WriteString(writer, "x")
+	w.NoError(err)
+	w.Equal(1, n)
+	w.Equal(ErrCircuitOpen, writer.flushBatch())
+
+	// Once a probe is due, a successful flush closes the circuit.
+	now = now.Add(time.Minute)
+	w.NoError(writer.flushBatch())
+	w.Equal(CircuitClosed, writer.State())
+	w.Equal("bacon", *writer.sequenceToken)
+	w.False(writer.events.hasMore())
+}
+
+func (w *writerTestSuite) TestCircuitBreaker_EventBuffer() {
+	buf := new(sliceEventBuffer)
+	writer := w.newUnstartedWriter(w.ctx, WithCircuitBreaker(1, time.Minute), WithEventBuffer(buf))
+
+	unavailable := awserr.New("ServiceUnavailableException", "Unavailable", nil)
+	w.putLogEventsReturns(nil, unavailable).Once()
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+	w.Equal(unavailable, writer.flushBatch())
+	w.Require().Equal(CircuitOpen, writer.State())
+
+	// While open, new events are kept by the custom buffer.
+	_, err = io.WriteString(writer, "World")
+	w.Require().NoError(err)
+	w.True(buf.HasMore())
+}
+
+func (w *writerTestSuite) TestWriteAfterCancel() {
+	ctx, cancel := context.WithCancel(w.ctx)
+	writer := w.newUnstartedWriter(ctx)
+
+	cancel()
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Equal(context.Canceled, err)
+	w.False(writer.events.hasMore())
+}
+
+func (w *writerTestSuite) TestCancelFlushesAndStops() {
+	ctx, cancel := context.WithCancel(w.ctx)
+	writer := w.newUnstartedWriter(ctx)
+	writer.throttle = time.NewTicker(time.Hour)
+	defer writer.throttle.Stop()
+
+	// The final flush uses its own context, as the writer's is done.
+	w.api.On(
+		"PutLogEventsWithContext",
+		mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil }),
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil).Once()
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+
+	done := make(chan error)
+	go func() { done <- writer.start() }()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		w.Equal(context.Canceled, err)
+	case <-time.After(time.Second):
+		w.Fail("expected the background flush to stop")
+	}
+
+	w.False(writer.events.hasMore())
+	w.Equal(context.Canceled, writer.getErr())
+	w.Equal(context.Canceled, writer.Flush())
+}
+
+func (w *writerTestSuite) TestFlushPanic() {
+	errChan := make(chan error, 1)
+	writer := w.newUnstartedWriter(w.ctx, WithErrorChannel(errChan))
+	writer.throttle = time.NewTicker(time.Hour)
+	defer writer.throttle.Stop()
+	writer.flushChan = make(chan struct{}, 1)
+
+	w.putLogEventsReturns(nil, nil).Run(func(mock.Arguments) { panic("boom") })
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+
+	done := make(chan error)
+	go func() { done <- writer.start() }()
+	writer.triggerFlush()
+
+	select {
+	case err := <-done:
+		w.EqualError(err, "recovered from panic while writing log events: boom")
+	case <-time.After(time.Second):
+		w.Fail("expected the background flush to stop")
+	}
+
+	w.EqualError(<-errChan, "recovered from panic while writing log events: boom")
+
+	_, err = io.WriteString(writer, "World")
+	w.EqualError(err, "recovered from panic while writing log events: boom")
+}
+
+func (w *writerTestSuite) TestBufferPanic() {
+	writer := w.newUnstartedWriter(w.ctx, WithEventMiddleware(func(*cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+		panic("boom")
+	}))
+
+	_, err := io.WriteString(writer, "Hello")
+	w.EqualError(err, "recovered from panic while writing log events: boom")
+
+	_, err = io.WriteString(writer, "World")
+	w.Error(err)
+}
+
+func (w *writerTestSuite) TestCloseTimeout() {
+	writer := w.newUnstartedWriter(w.ctx, WithCloseTimeout(50*time.Millisecond))
+	writer.closeChan = make(chan struct{})
+	writer.throttle = time.NewTicker(time.Millisecond)
+
+	// A slow endpoint only gives up once the request's context is done.
+	w.api.On(
+		"PutLogEventsWithContext",
+		mock.Anything,
+		mock.Anything,
+		[]request.Option(nil),
+	).Run(func(args mock.Arguments) {
+		select {
+		case <-args.Get(0).(context.Context).Done():
+		case <-time.After(time.Second):
+		}
+	}).Return((*cloudwatchlogs.PutLogEventsOutput)(nil), awserr.New(request.CanceledErrorCode, "request context canceled", context.DeadlineExceeded))
+
+	_, err := io.WriteString(writer, "Hello\nWorld")
+	w.Require().NoError(err)
+
+	start := time.Now()
+	err = writer.Close()
+
+	w.Less(int64(time.Since(start)), int64(time.Second))
+	w.Equal(&DrainTimeoutError{Remaining: 2}, err)
+	w.EqualError(err, "timed out draining log events, 2 not sent")
+}
+
+func (w *writerTestSuite) TestStats() {
+	writer := w.newUnstartedWriter(w.ctx)
+	var _ Writer = writer
+
+	w.putLogEventsReturns(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("bacon")}, nil).Once()
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("too old\n"), Timestamp: aws.Int64(1000)},
+				{Message: aws.String("ok\n"), Timestamp: aws.Int64(1000)},
+				{Message: aws.String("too new"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+			SequenceToken: aws.String("bacon"),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.PutLogEventsOutput{
+		RejectedLogEventsInfo: &cloudwatchlogs.RejectedLogEventsInfo{
+			TooOldLogEventEndIndex:   aws.Int64(1),
+			TooNewLogEventStartIndex: aws.Int64(2),
+		},
+	}, nil).Once()
+
+	w.Zero(writer.Stats())
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+	w.Require().NoError(writer.flushBatch())
+
+	_, err = io.WriteString(writer, "too old\nok\ntoo new")
+	w.Require().NoError(err)
+	w.Error(writer.flushBatch())
+
+	stats := writer.Stats()
+	w.Equal(int64(2), stats.FlushCount)
+	w.Equal(int64(2), stats.EventsSent)
+	w.Equal(int64(len("Hello")+len("ok\n")+2*paddingSize), stats.BytesSent)
+	w.Equal(int64(1), stats.ErrorCount)
+	w.Equal(int64(2), stats.RejectedCount)
+	w.True(stats.LastFlushDuration > 0)
+}
+
 func (w *writerTestSuite) TestWriteInvalidSequenceToken() {
 	const expectedSequenceToken = "bacon"
 
@@ -144,6 +520,324 @@ func (w *writerTestSuite) TestWriteInvalidSequenceToken() {
 	w.Equal("cabbage", *w.sut.(*writerImpl).sequenceToken)
 }
 
+func (w *writerTestSuite) TestWriteDataAlreadyAccepted() {
+	w.putLogEventsReturns(nil, &cloudwatchlogs.DataAlreadyAcceptedException{
+		ExpectedSequenceToken: aws.String("bacon"),
+	}).Once()
+
+	_, err := io.WriteString(w.sut, "Hello")
+	w.Require().NoError(err)
+	w.Require().NoError(w.sut.Flush())
+
+	// The batch isn't sent again, and counts as sent.
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 1)
+	w.Equal("bacon", *w.sut.(*writerImpl).sequenceToken)
+	w.Equal(int64(1), w.sut.Stats().EventsSent)
+}
+
+func (w *writerTestSuite) TestWriteError_DropsRemainingBatches() {
+	var dropped []string
+	var droppedErr error
+	writer := w.newUnstartedWriter(w.ctx,
+		WithMaxBatchEvents(1),
+		WithDeadLetterHandler(func(events []*cloudwatchlogs.InputLogEvent, err error) {
+			droppedErr = err
+			for _, event := range events {
+				dropped = append(dropped, *event.Message)
+			}
+		}),
+	)
+	w.api.On("PutLogEventsWithContext", w.ctx, mock.Anything, []request.Option(nil)).
+		Once().Return((*cloudwatchlogs.PutLogEventsOutput)(nil), awserr.New("AccessDeniedException", "not authorized", nil))
+
+	_, err := io.WriteString(writer, "Hello\nWorld\nAgain")
+	w.Require().NoError(err)
+
+	// The batches after the failing one can't be sent anymore.
+	err = writer.flushBatch()
+	w.Error(err)
+	w.Equal(err, droppedErr)
+	w.Equal([]string{"World\n", "Again"}, dropped)
+	w.False(writer.events.hasMore())
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 1)
+}
+
+func (w *writerTestSuite) TestWriteStreamDeleted() {
+	w.sut.(*writerImpl).sequenceToken = aws.String("bacon")
+
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+			SequenceToken: aws.String("bacon"),
+		},
+		[]request.Option(nil),
+	).Once().Return((*cloudwatchlogs.PutLogEventsOutput)(nil), new(cloudwatchlogs.ResourceNotFoundException))
+
+	w.putLogEventsReturns(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("cabbage")}, nil).Once()
+
+	_, err := io.WriteString(w.sut, "Hello")
+	w.Require().NoError(err)
+	w.Require().NoError(w.sut.Flush())
+
+	w.api.AssertNumberOfCalls(w.T(), "CreateLogStreamWithContext", 2)
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 2)
+	w.Equal("cabbage", *w.sut.(*writerImpl).sequenceToken)
+}
+
+func (w *writerTestSuite) TestWriteStreamDeletedNoRecreate() {
+	w.putLogEventsReturns(nil, new(cloudwatchlogs.ResourceNotFoundException)).Once()
+	WithAutoRecreateStream(false)(w.sut.(*writerImpl))
+
+	_, err := io.WriteString(w.sut, "Hello")
+	w.Require().NoError(err)
+	w.IsType(new(cloudwatchlogs.ResourceNotFoundException), w.sut.Flush())
+
+	w.api.AssertNumberOfCalls(w.T(), "CreateLogStreamWithContext", 1)
+}
+
+func (w *writerTestSuite) TestSequenceTokenStore() {
+	w.putLogEventsReturns(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("bacon")}, nil).Once()
+
+	store := MemoryTokenStore()
+	WithSequenceTokenStore(store)(w.sut.(*writerImpl))
+
+	_, err := io.WriteString(w.sut, "Hello")
+	w.Require().NoError(err)
+	w.Require().NoError(w.sut.Flush())
+
+	token, ok := store.Load(w.streamName)
+	w.True(ok)
+	w.Equal("bacon", token)
+}
+
+func (w *writerTestSuite) TestWriteThrottled() {
+	w.putLogEventsReturns(nil, awserr.New("ThrottlingException", "Rate exceeded", nil)).Once()
+	w.putLogEventsReturns(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("bacon")}, nil).Once()
+
+	writer := w.sut.(*writerImpl)
+	WithBaseDelay(time.Millisecond)(writer)
+
+	_, err := io.WriteString(w.sut, "Hello")
+	w.Require().NoError(err)
+	w.Require().NoError(w.sut.Flush())
+
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 2)
+	w.Equal("bacon", *writer.sequenceToken)
+}
+
+func (w *writerTestSuite) TestWriteThrottledRetriesExhausted() {
+	w.putLogEventsReturns(nil, awserr.New("RequestLimitExceeded", "Rate exceeded", nil))
+
+	writer := w.sut.(*writerImpl)
+	WithBaseDelay(time.Millisecond)(writer)
+	WithMaxRetries(2)(writer)
+
+	_, err := io.WriteString(w.sut, "Hello")
+	w.Require().NoError(err)
+	w.EqualError(w.sut.Flush(), "RequestLimitExceeded: Rate exceeded")
+
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 3)
+}
+
+func (w *writerTestSuite) TestAdaptiveThrottle() {
+	w.putLogEventsReturns(nil, awserr.New("ThrottlingException", "Rate exceeded", nil)).Times(6)
+	w.putLogEventsReturns(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("bacon")}, nil).Once()
+
+	writer := w.sut.(*writerImpl)
+	WithMaxRetries(0)(writer)
+	w.InDelta(5, writer.CurrentFlushRate(), 0.001)
+
+	events := []*cloudwatchlogs.InputLogEvent{{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)}}
+	flush := func() error {
+		writer.Lock()
+		defer writer.Unlock()
+		return writer.flush(events)
+	}
+
+	// Each throttled request halves the rate, down to a tenth of the
+	// configured rate.
+	var rates []float64
+	for i := 0; i < 6; i++ {
+		w.Require().Error(flush())
+		rates = append(rates, writer.CurrentFlushRate())
+	}
+	w.InDeltaSlice([]float64{2.5, 1.25, 0.625, 0.5, 0.5, 0.5}, rates, 0.001)
+
+	// Successful flushes then raise it gradually.
+	w.Require().NoError(flush())
+	w.InDelta(0.55, writer.CurrentFlushRate(), 0.001)
+}
+
+func (w *writerTestSuite) TestAdaptiveThrottle_Retries() {
+	w.putLogEventsReturns(nil, awserr.New("ThrottlingException", "Rate exceeded", nil)).Twice()
+	w.putLogEventsReturns(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("bacon")}, nil).Once()
+
+	writer := w.sut.(*writerImpl)
+	WithBaseDelay(time.Millisecond)(writer)
+
+	writer.Lock()
+	err := writer.flush([]*cloudwatchlogs.InputLogEvent{{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)}})
+	writer.Unlock()
+
+	// A flush whose attempts were throttled twice halves the rate only once.
+	w.Require().NoError(err)
+	w.InDelta(2.5, writer.CurrentFlushRate(), 0.001)
+}
+
+func (w *writerTestSuite) TestTokenBucket() {
+	writer := w.newUnstartedWriter(w.ctx, WithTokenBucket(1000, 2))
+	w.Equal(2, writer.events.maxEvents)
+
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.PutLogEventsInput"),
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("token")}, nil)
+
+	_, err := io.WriteString(writer, "a\nb\nc\nd\ne\nf")
+	w.Require().NoError(err)
+
+	// The first batch uses up the burst, and the other two wait about 2ms
+	// each for the bucket to refill.
+	start := time.Now()
+	w.Require().NoError(writer.flushBatch())
+	w.True(time.Since(start) >= 3*time.Millisecond)
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 3)
+}
+
+func (w *writerTestSuite) TestTokenBucketContextCancelled() {
+	ctx, cancel := context.WithCancel(w.ctx)
+	cancel()
+
+	writer := w.newUnstartedWriter(ctx, WithTokenBucket(0.001, 1))
+	writer.bucket.tokens = 0
+	writer.events.addUnbounded(&cloudwatchlogs.InputLogEvent{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)})
+
+	w.Equal(context.Canceled, writer.flushBatch())
+	w.Equal([]string{"Hello"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestHeartbeat() {
+	start := time.Unix(1700000000, 0)
+	now := start
+	writer := w.newUnstartedWriter(w.ctx, WithHeartbeat(time.Minute, "[heartbeat]"))
+	writer.nowFunc = func() time.Time { return now }
+
+	var sent []string
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		mock.AnythingOfType("*cloudwatchlogs.PutLogEventsInput"),
+		[]request.Option(nil),
+	).Run(func(args mock.Arguments) {
+		for _, event := range args.Get(1).(*cloudwatchlogs.PutLogEventsInput).LogEvents {
+			sent = append(sent, fmt.Sprintf("%s@%d", *event.Message, (*event.Timestamp-toMillis(start))/1000))
+		}
+	}).Return(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("token")}, nil)
+
+	flushAt := func(seconds int) {
+		now = start.Add(time.Duration(seconds) * time.Second)
+		w.Require().NoError(writer.flushBatch())
+	}
+
+	flushAt(0)
+	flushAt(59)
+	flushAt(60)
+	flushAt(119)
+
+	// Real events reset the heartbeat timer.
+	now = start.Add(150 * time.Second)
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+	flushAt(150)
+	flushAt(209)
+	flushAt(210)
+
+	w.Equal([]string{"[heartbeat]@60", "Hello@150", "[heartbeat]@210"}, sent)
+}
+
+func (w *writerTestSuite) TestWriteThrottledContextCancelled() {
+	ctx, cancel := context.WithCancel(w.ctx)
+
+	w.api.On(
+		"PutLogEventsWithContext",
+		ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Return((*cloudwatchlogs.PutLogEventsOutput)(nil), awserr.New("ThrottlingException", "Rate exceeded", nil))
+
+	writer := w.newUnstartedWriter(ctx, WithBaseDelay(time.Hour))
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+
+	cancel()
+	w.Equal(context.Canceled, writer.Flush())
+}
+
+func (w *writerTestSuite) TestWriteTimeout() {
+	writer := w.newUnstartedWriter(w.ctx, WithWriteTimeout(time.Millisecond))
+
+	input := &cloudwatchlogs.PutLogEventsInput{
+		LogEvents: []*cloudwatchlogs.InputLogEvent{
+			{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+		},
+		LogGroupName:  aws.String(w.groupName),
+		LogStreamName: aws.String(w.streamName),
+	}
+
+	w.api.On("PutLogEventsWithContext", mock.Anything, input, []request.Option(nil)).
+		Once().
+		After(20*time.Millisecond).
+		Return((*cloudwatchlogs.PutLogEventsOutput)(nil), awserr.New(request.CanceledErrorCode, "request context canceled", context.DeadlineExceeded))
+
+	w.api.On("PutLogEventsWithContext", mock.Anything, input, []request.Option(nil)).
+		Once().
+		Return(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("bacon")}, nil)
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+
+	w.Equal(ErrWriteTimeout, writer.Flush())
+	w.True(writer.events.hasMore())
+	w.Nil(writer.sequenceToken)
+
+	w.NoError(writer.Flush())
+	w.False(writer.events.hasMore())
+	w.Equal("bacon", *writer.sequenceToken)
+}
+
+func (w *writerTestSuite) TestCloseWriteTimeout() {
+	writer := w.newUnstartedWriter(w.ctx, WithWriteTimeout(time.Millisecond))
+	writer.closeChan = make(chan struct{})
+	writer.throttle = time.NewTicker(time.Millisecond)
+
+	w.api.On("PutLogEventsWithContext", mock.Anything, mock.Anything, []request.Option(nil)).
+		After(20*time.Millisecond).
+		Return((*cloudwatchlogs.PutLogEventsOutput)(nil), awserr.New(request.CanceledErrorCode, "request context canceled", context.DeadlineExceeded))
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+
+	// Without a close timeout, the timed out batch isn't retried.
+	w.Equal(ErrWriteTimeout, writer.Close())
+	w.True(writer.events.hasMore())
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 1)
+}
+
 func (w *writerTestSuite) TestNewline() {
 	w.api.On(
 		"PutLogEventsWithContext",
@@ -165,6 +859,1578 @@ func (w *writerTestSuite) TestNewline() {
 	w.NoError(w.sut.Close())
 }
 
-func TestWriter(t *testing.T) {
-	suite.Run(t, new(writerTestSuite))
+func (w *writerTestSuite) TestFlush() {
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello\n"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	_, err := io.WriteString(w.sut, "Hello\n")
+	w.Require().NoError(err)
+	w.Require().NoError(w.sut.Flush())
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 1)
+
+	// The writer remains open after flushing.
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("World"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	_, err = io.WriteString(w.sut, "World")
+	w.NoError(err)
+	w.NoError(w.sut.Close())
+}
+
+func (w *writerTestSuite) TestFlushAfterClose() {
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	_, err := io.WriteString(w.sut, "Hello")
+	w.Require().NoError(err)
+	w.Require().NoError(w.sut.Close())
+
+	w.Equal(io.ErrClosedPipe, w.sut.Flush())
+}
+
+func (w *writerTestSuite) TestBatchLimits() {
+	writer, err := NewGroup(w.api, w.groupName).Create(
+		w.ctx,
+		w.streamName,
+		freezeTime(time.Unix(1, 0)),
+		WithMaxBatchEvents(1),
+	)
+	w.Require().NoError(err)
+
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello\n"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("bacon")}, nil)
+
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("World"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+			SequenceToken: aws.String("bacon"),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("cabbage")}, nil)
+
+	_, err = io.WriteString(writer, "Hello\nWorld")
+	w.Require().NoError(err)
+	w.Require().NoError(writer.Close())
+
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 2)
+	w.Equal("cabbage", *writer.(*writerImpl).sequenceToken)
+}
+
+func (w *writerTestSuite) TestFlushThreshold() {
+	// The interval is long enough for the first tick to never come.
+	writer, err := NewGroup(w.api, w.groupName).Create(
+		w.ctx,
+		w.streamName,
+		freezeTime(time.Unix(1, 0)),
+		WithFlushInterval(time.Hour),
+		WithFlushThresholdEvents(2),
+	)
+	w.Require().NoError(err)
+
+	sent := make(chan struct{})
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello\n"), Timestamp: aws.Int64(1000)},
+				{Message: aws.String("World"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Once().Run(func(mock.Arguments) { close(sent) }).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	_, err = io.WriteString(writer, "Hello\nWorld")
+	w.Require().NoError(err)
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		w.Fail("crossing the threshold did not trigger a flush")
+	}
+
+	w.NoError(writer.Close())
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 1)
+}
+
+func (w *writerTestSuite) TestFlushThreshold_Flush() {
+	writer, err := NewGroup(w.api, w.groupName).Create(
+		w.ctx,
+		w.streamName,
+		freezeTime(time.Unix(1, 0)),
+		WithFlushInterval(time.Hour),
+		WithFlushThresholdEvents(2),
+	)
+	w.Require().NoError(err)
+
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello\n"), Timestamp: aws.Int64(1000)},
+				{Message: aws.String("World"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	// Flushing explicitly while a threshold-triggered flush is in progress
+	// must not send the events twice.
+	_, err = io.WriteString(writer, "Hello\nWorld")
+	w.Require().NoError(err)
+	w.NoError(writer.Flush())
+
+	w.NoError(writer.Close())
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 1)
+}
+
+func (w *writerTestSuite) TestFlushThreshold_ConcurrentWithTicker() {
+	writer, err := NewGroup(w.api, w.groupName).Create(
+		w.ctx,
+		w.streamName,
+		WithUnsafeFlushInterval(time.Millisecond),
+		WithFlushThresholdEvents(3),
+	)
+	w.Require().NoError(err)
+
+	var mu sync.Mutex
+	var sent []string
+	w.api.On("PutLogEventsWithContext", w.ctx, mock.Anything, []request.Option(nil)).Run(func(args mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, event := range args.Get(1).(*cloudwatchlogs.PutLogEventsInput).LogEvents {
+			sent = append(sent, *event.Message)
+		}
+	}).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	// Both the ticker and the threshold keep triggering flushes while events
+	// are written. Every event must be sent exactly once, in order.
+	var want []string
+	for i := 0; i < 500; i++ {
+		msg := fmt.Sprintf("event %d\n", i)
+		want = append(want, msg)
+		_, err := io.WriteString(writer, msg)
+		w.Require().NoError(err)
+		if i%25 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	w.NoError(writer.Close())
+	w.Equal(want, sent)
+}
+
+func (w *writerTestSuite) TestBufferFullPolicyBlock() {
+	writer := w.newUnstartedWriter(w.ctx, WithBufferCapacity(2))
+
+	_, err := io.WriteString(writer, "a\nb\n")
+	w.Require().NoError(err)
+
+	var written int32
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := io.WriteString(writer, "c\n")
+			w.NoError(err)
+			atomic.AddInt32(&written, 1)
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	w.EqualValues(0, atomic.LoadInt32(&written))
+	w.Equal([]string{"a\n", "b\n"}, drainMessages(writer))
+
+	wg.Wait()
+	w.Equal([]string{"c\n", "c\n"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestBufferFullPolicyBlockCancelled() {
+	ctx, cancel := context.WithCancel(w.ctx)
+	writer := w.newUnstartedWriter(ctx, WithBufferCapacity(1))
+
+	_, err := io.WriteString(writer, "a\n")
+	w.Require().NoError(err)
+
+	errChan := make(chan error)
+	go func() {
+		_, err := io.WriteString(writer, "b\n")
+		errChan <- err
+	}()
+
+	cancel()
+	w.Equal(context.Canceled, <-errChan)
+	w.Equal([]string{"a\n"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestBufferFullPolicyDropOldest() {
+	writer := w.newUnstartedWriter(w.ctx, WithBufferCapacity(2), WithBufferFullPolicy(BufferFullPolicyDropOldest))
+
+	_, err := io.WriteString(writer, "a\nb\nc\nd\n")
+	w.Require().NoError(err)
+	w.Equal([]string{"c\n", "d\n"}, drainMessages(writer))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := io.WriteString(writer, "e\n")
+			w.NoError(err)
+		}()
+	}
+
+	wg.Wait()
+	w.Len(drainMessages(writer), 2)
+}
+
+func (w *writerTestSuite) TestFlushInterval() {
+	writer := w.newUnstartedWriter(w.ctx, WithFlushInterval(time.Minute))
+	w.Equal(time.Minute, writer.flushInterval)
+
+	writer = w.newUnstartedWriter(w.ctx, WithFlushInterval(time.Millisecond))
+	w.Equal(writeThrottle, writer.flushInterval)
+
+	writer = w.newUnstartedWriter(w.ctx, WithUnsafeFlushInterval(time.Millisecond))
+	w.Equal(time.Millisecond, writer.flushInterval)
+
+	// Intervals which aren't positive would make the ticker panic.
+	writer = w.newUnstartedWriter(w.ctx, WithFlushInterval(time.Minute), WithUnsafeFlushInterval(0))
+	w.Equal(time.Minute, writer.flushInterval)
+
+	writer = w.newUnstartedWriter(w.ctx, WithFlushInterval(time.Minute), WithUnsafeFlushInterval(-time.Second))
+	w.Equal(time.Minute, writer.flushInterval)
+}
+
+func (w *writerTestSuite) TestUnsafeFlushInterval() {
+	writer, err := NewGroup(w.api, w.groupName).Create(
+		w.ctx,
+		w.streamName,
+		freezeTime(time.Unix(1, 0)),
+		WithUnsafeFlushInterval(time.Millisecond),
+	)
+	w.Require().NoError(err)
+
+	sent := make(chan struct{})
+	w.putLogEventsReturns(&cloudwatchlogs.PutLogEventsOutput{}, nil).Once().Run(func(mock.Arguments) { close(sent) })
+
+	_, err = io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+
+	select {
+	case <-sent:
+	case <-time.After(writeThrottle / 2):
+		w.Fail("events were not flushed at the configured interval")
+	}
+
+	w.NoError(writer.Close())
+}
+
+func (w *writerTestSuite) TestBatchSpan() {
+	for _, sorted := range []bool{false, true} {
+		writer := w.newUnstartedWriter(w.ctx)
+		writer.events.sorted = sorted
+
+		start := time.Now().Add(-48 * time.Hour)
+		for _, offset := range []time.Duration{0, 23 * time.Hour, 25 * time.Hour, 0} {
+			w.Require().NoError(writer.enqueue([]byte("Hello"), start.Add(offset)))
+		}
+
+		// An event more than 24 hours after the first one starts a new batch,
+		// as does one more than 24 hours before the last one.
+		batches := writer.events.drain()
+		w.Require().Len(batches, 3, "sorted: %v", sorted)
+		w.Len(batches[0], 2)
+		w.Len(batches[1], 1)
+		w.Equal(toMillis(start.Add(25*time.Hour)), *batches[1][0].Timestamp)
+		w.Len(batches[2], 1)
+	}
+}
+
+func (w *writerTestSuite) TestBatchLimitsError() {
+	writer, err := NewGroup(w.api, w.groupName).Create(
+		w.ctx,
+		w.streamName,
+		freezeTime(time.Unix(1, 0)),
+		WithMaxBatchEvents(1),
+	)
+	w.Require().NoError(err)
+
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello\n"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Once().Return((*cloudwatchlogs.PutLogEventsOutput)(nil), errors.New("bacon"))
+
+	_, err = io.WriteString(writer, "Hello\nWorld\nAgain")
+	w.Require().NoError(err)
+
+	// The batches after the failing one can't be sent anymore.
+	w.EqualError(writer.Close(), "bacon")
+	w.False(writer.(*writerImpl).events.hasMore())
+	w.api.AssertNumberOfCalls(w.T(), "PutLogEventsWithContext", 1)
+}
+
+func (w *writerTestSuite) TestOversizePolicyTruncate() {
+	for _, size := range []int{maxEventMessageBytes - 1, maxEventMessageBytes} {
+		events, err := w.writeMessageOfSize(OversizePolicyTruncate, size)
+		w.NoError(err)
+		w.Require().Len(events, 1)
+		w.Len(*events[0].Message, size)
+	}
+
+	events, err := w.writeMessageOfSize(OversizePolicyTruncate, maxEventMessageBytes+1)
+	w.NoError(err)
+	w.Require().Len(events, 1)
+	w.Len(*events[0].Message, maxEventMessageBytes)
+	w.True(strings.HasSuffix(*events[0].Message, truncatedMarker))
+}
+
+func (w *writerTestSuite) TestOversizePolicyDrop() {
+	for _, size := range []int{maxEventMessageBytes - 1, maxEventMessageBytes} {
+		events, err := w.writeMessageOfSize(OversizePolicyDrop, size)
+		w.NoError(err)
+		w.Len(events, 1)
+	}
+
+	events, err := w.writeMessageOfSize(OversizePolicyDrop, maxEventMessageBytes+1)
+	w.NoError(err)
+	w.Empty(events)
+}
+
+func (w *writerTestSuite) TestOversizePolicyError() {
+	for _, size := range []int{maxEventMessageBytes - 1, maxEventMessageBytes} {
+		events, err := w.writeMessageOfSize(OversizePolicyError, size)
+		w.NoError(err)
+		w.Len(events, 1)
+	}
+
+	events, err := w.writeMessageOfSize(OversizePolicyError, maxEventMessageBytes+1)
+	w.Equal(ErrEventTooLarge, err)
+	w.Empty(events)
+}
+
+func (w *writerTestSuite) putLogEventsReturns(output *cloudwatchlogs.PutLogEventsOutput, err error) *mock.Call {
+	return w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Return(output, err)
+}
+
+// writeMessageOfSize writes a single message of the given size to a writer
+// with the given oversize policy, and returns the buffered events.
+func (w *writerTestSuite) writeMessageOfSize(policy OversizePolicy, size int) ([]*cloudwatchlogs.InputLogEvent, error) {
+	writer := w.newUnstartedWriter(w.ctx, WithOversizePolicy(policy))
+
+	_, err := writer.Write(bytes.Repeat([]byte("a"), size))
+
+	var events []*cloudwatchlogs.InputLogEvent
+	for _, batch := range writer.events.drain() {
+		events = append(events, batch...)
+	}
+	return events, err
+}
+
+// newUnstartedWriter returns a writer which does not flush in the background,
+// allowing tests to inspect its buffer.
+func (w *writerTestSuite) newUnstartedWriter(ctx context.Context, opts ...CreateOption) *writerImpl {
+	writer := &writerImpl{
+		client:     w.api,
+		ctx:        ctx,
+		events:     newEventsBuffer(),
+		groupName:  aws.String(w.groupName),
+		nowFunc:    func() time.Time { return time.Unix(1, 0) },
+		retry:      newBackoff(),
+		streamName: aws.String(w.streamName),
+	}
+
+	for _, opt := range opts {
+		opt(writer)
+	}
+
+	return writer
+}
+
+// drainMessages drains the writer's buffer and returns the event messages.
+func drainMessages(writer *writerImpl) []string {
+	var ret []string
+	for _, batch := range writer.events.drain() {
+		for _, event := range batch {
+			ret = append(ret, *event.Message)
+		}
+	}
+	return ret
+}
+
+func (w *writerTestSuite) TestJSONValidation() {
+	writer := w.newUnstartedWriter(w.ctx, WithJSONValidation())
+
+	input := "{\"level\":\"info\"}\nnot JSON\n{\"level\":\"error\"}\n"
+	n, err := io.WriteString(writer, input)
+
+	w.NoError(err)
+	w.Equal(len(input), n)
+	w.Equal([]string{"{\"level\":\"info\"}\n", "{\"level\":\"error\"}\n"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestMultiLine() {
+	writer := w.newUnstartedWriter(w.ctx, WithMultiLineStart(regexp.MustCompile(`^\d{4}-`)))
+
+	trace := "2020-01-01 panic: bacon\n\ngoroutine 1 [running]:\nmain.main()\n\t/app/main.go:5 +0x25\n"
+	_, err := io.WriteString(writer, "2020-01-01 Hello\n"+trace)
+	w.Require().NoError(err)
+
+	// The stack trace is only buffered once the next event starts.
+	w.Equal([]string{"2020-01-01 Hello\n"}, drainMessages(writer))
+
+	_, err = io.WriteString(writer, "2020-01-01 World\n")
+	w.Require().NoError(err)
+	w.Equal([]string{trace}, drainMessages(writer))
+
+	// Flushing buffers the pending event.
+	writer.flushPending()
+	w.Equal([]string{"2020-01-01 World\n"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestMultiLineMaxBytes() {
+	writer := w.newUnstartedWriter(w.ctx,
+		WithMultiLineStart(regexp.MustCompile(`^\S`)),
+		WithMultiLineMaxBytes(11),
+	)
+
+	// "Hello\n" and "\tabc\n" add up to exactly 11 bytes, so the next line
+	// starts a new event.
+	_, err := io.WriteString(writer, "Hello\n\tabc\n\tdef\nWorld\n")
+	w.Require().NoError(err)
+	writer.flushPending()
+
+	w.Equal([]string{"Hello\n\tabc\n", "\tdef\n", "World\n"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestEventMiddleware() {
+	var calls []string
+	writer := w.newUnstartedWriter(w.ctx,
+		WithEventMiddleware(func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+			calls = append(calls, "first")
+			if strings.HasPrefix(*event.Message, "Drop") {
+				return nil
+			}
+			return event
+		}),
+		WithEventMiddleware(func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+			calls = append(calls, "second")
+			event.Message = aws.String(strings.ToUpper(*event.Message))
+			return event
+		}),
+	)
+
+	_, err := io.WriteString(writer, "Hello\nDrop me\n")
+	w.Require().NoError(err)
+
+	w.Equal([]string{"first", "second", "first"}, calls)
+	w.Equal([]string{"HELLO\n"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestBuiltinMiddleware() {
+	writer := w.newUnstartedWriter(w.ctx,
+		WithEventMiddleware(RedactRegexp(regexp.MustCompile(`[\w.]+@[\w.]+`), "[EMAIL]")),
+		WithEventMiddleware(AddField("service", "orders")),
+	)
+
+	_, err := io.WriteString(writer, "{\"msg\":\"bacon@example.com\",\"id\":12345678901234567890}\nbacon@example.com\n")
+	w.Require().NoError(err)
+
+	w.Equal([]string{
+		`{"id":12345678901234567890,"msg":"[EMAIL]","service":"orders"}`,
+		"[EMAIL]\n",
+	}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestJSONFieldInjector() {
+	writer := w.newUnstartedWriter(w.ctx, WithJSONFieldInjector(map[string]string{"service": "orders", "env": "prod"}))
+
+	_, err := io.WriteString(writer, strings.Join([]string{
+		`{"msg":"Hello"}`,
+		` { } `,
+		`{"a":{"b":{"c":[{"d":"}"}]}}}`,
+		`{"msg":"truncated"`,
+		`{"msg":"Hello"} trailing`,
+		`Hello`,
+	}, "\n")+"\n")
+	w.Require().NoError(err)
+
+	w.Equal([]string{
+		`{"msg":"Hello","env":"prod","service":"orders"}` + "\n",
+		` { "env":"prod","service":"orders"} ` + "\n",
+		`{"a":{"b":{"c":[{"d":"}"}]}},"env":"prod","service":"orders"}` + "\n",
+		`{"msg":"truncated"` + "\n",
+		`{"msg":"Hello"} trailing` + "\n",
+		"Hello\n",
+	}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestRedactionMiddleware() {
+	writer := w.newUnstartedWriter(w.ctx, WithEventMiddleware(NewRedactionMiddleware([]RedactionRule{
+		RedactEmail(),
+		RedactCreditCard(),
+		RedactIPAddress(),
+		{Pattern: regexp.MustCompile(`token=(\w+)`), Replacement: "token=$1"},
+	})))
+
+	_, err := io.WriteString(writer, strings.Join([]string{
+		"user bacon.eggs+test@example.co.uk logged in",
+		"paid with 4111 1111 1111 1111 and 5500-0000-0000-0004, order 12345",
+		"request from 192.168.0.1 and 2001:0db8:85a3:0000:0000:8a2e:0370:7334, version 1.2.3",
+		"token=abc",
+	}, "\n")+"\n")
+	w.Require().NoError(err)
+
+	w.Equal([]string{
+		"user [EMAIL] logged in\n",
+		"paid with [CREDIT_CARD] and [CREDIT_CARD], order 12345\n",
+		"request from [IP] and [IP], version 1.2.3\n",
+		"token=$1\n",
+	}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestIntegrityHMAC() {
+	key := []byte("secret")
+	writer := w.newUnstartedWriter(w.ctx, WithIntegrityHMAC(key))
+
+	_, err := io.WriteString(writer, "Hello\n")
+	w.Require().NoError(err)
+	_, err = writer.Write(bytes.Repeat([]byte("a"), maxEventMessageBytes))
+	w.Require().NoError(err)
+
+	var events []*cloudwatchlogs.OutputLogEvent
+	for _, batch := range writer.events.drain() {
+		for _, event := range batch {
+			events = append(events, &cloudwatchlogs.OutputLogEvent{Message: event.Message, Timestamp: event.Timestamp})
+		}
+	}
+	w.Require().Len(events, 2)
+	w.Regexp(`^Hello sig=[0-9a-f]{64}\n$`, *events[0].Message)
+
+	// The signature fits in the size limit.
+	w.Len(*events[1].Message, maxEventMessageBytes)
+	w.Contains(*events[1].Message, truncatedMarker+" sig=")
+
+	for _, event := range events {
+		ok, err := VerifyEvent(key, event)
+		w.NoError(err)
+		w.True(ok)
+
+		ok, err = VerifyEvent([]byte("other"), event)
+		w.NoError(err)
+		w.False(ok)
+	}
+
+	tampered := *events[0]
+	tampered.Message = aws.String(strings.Replace(*tampered.Message, "Hello", "Hallo", 1))
+	ok, err := VerifyEvent(key, &tampered)
+	w.NoError(err)
+	w.False(ok)
+
+	tampered = *events[0]
+	tampered.Timestamp = aws.Int64(2000)
+	ok, err = VerifyEvent(key, &tampered)
+	w.NoError(err)
+	w.False(ok)
+
+	// Digits can't be moved between the message and the timestamp.
+	w.NotEqual(signature(key, "Hello1", 23), signature(key, "Hello", 123))
+
+	_, err = VerifyEvent(key, &cloudwatchlogs.OutputLogEvent{Message: aws.String("Hello\n"), Timestamp: aws.Int64(1000)})
+	w.EqualError(err, "log event isn't signed")
+	_, err = VerifyEvent(key, &cloudwatchlogs.OutputLogEvent{Message: aws.String("Hello sig=bacon"), Timestamp: aws.Int64(1000)})
+	w.EqualError(err, "log event has an invalid signature")
+}
+
+type requestIDKey struct{}
+
+func (w *writerTestSuite) TestEventEnricher() {
+	ctx := context.WithValue(w.ctx, requestIDKey{}, "abc123")
+	writer := w.newUnstartedWriter(ctx,
+		WithEventMiddleware(func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+			event.Message = aws.String(strings.ToUpper(*event.Message))
+			return event
+		}),
+		WithEventEnricher(RequestIDEnricher(requestIDKey{}, "request_id")),
+	)
+
+	_, err := io.WriteString(writer, "Hello\nWorld")
+	w.Require().NoError(err)
+
+	w.Equal([]string{"HELLO request_id=abc123\n", "WORLD request_id=abc123"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestEventEnricherMissingValue() {
+	writer := w.newUnstartedWriter(w.ctx,
+		WithEventEnricher(RequestIDEnricher(requestIDKey{}, "request_id")),
+	)
+
+	_, err := io.WriteString(writer, "Hello\n")
+	w.Require().NoError(err)
+
+	w.Equal([]string{"Hello\n"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestHostname() {
+	defer func(fn func() (string, error)) { osHostname = fn }(osHostname)
+	osHostname = func() (string, error) { return "web-1", nil }
+
+	ctx := context.WithValue(w.ctx, requestIDKey{}, "abc123")
+	writer := w.newUnstartedWriter(ctx,
+		WithHostname(),
+		WithEventEnricher(RequestIDEnricher(requestIDKey{}, "request_id")),
+	)
+
+	_, err := io.WriteString(writer, "Hello\n")
+	w.Require().NoError(err)
+
+	w.Equal([]string{"host=web-1 Hello request_id=abc123\n"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestCustomHostname() {
+	defer func(fn func() (string, error)) { osHostname = fn }(osHostname)
+	osHostname = func() (string, error) { return "", errors.New("no hostname") }
+
+	writer := w.newUnstartedWriter(w.ctx, WithHostname())
+	_, err := io.WriteString(writer, "Hello\n")
+	w.Require().NoError(err)
+	w.Equal([]string{"Hello\n"}, drainMessages(writer))
+
+	writer = w.newUnstartedWriter(w.ctx, WithCustomHostname("web-2"))
+	_, err = io.WriteString(writer, "Hello\n")
+	w.Require().NoError(err)
+	w.Equal([]string{"host=web-2 Hello\n"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestMessagePrefix() {
+	for _, test := range []struct {
+		prefix, separator string
+		expected          string
+	}{
+		{"", "|", "Hello\n"},
+		{"checkout", "", "checkout Hello\n"},
+		{"checkout", "|", "checkout|Hello\n"},
+		{"café☕", " » ", "café☕ » Hello\n"},
+	} {
+		writer := w.newUnstartedWriter(w.ctx, WithMessagePrefix(test.prefix, test.separator))
+		_, err := io.WriteString(writer, "Hello\n")
+		w.Require().NoError(err)
+		w.Equal([]string{test.expected}, drainMessages(writer))
+	}
+}
+
+func (w *writerTestSuite) TestMessagePrefixOrder() {
+	var seen []string
+	writer := w.newUnstartedWriter(w.ctx,
+		WithMessagePrefix("checkout", ""),
+		WithMultiLineStart(regexp.MustCompile(`^\S`)),
+		WithEventMiddleware(func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+			seen = append(seen, aws.StringValue(event.Message))
+			return event
+		}),
+	)
+
+	_, err := io.WriteString(writer, "panic: boom\n\tmain.go:12\n")
+	w.Require().NoError(err)
+	writer.flushPending()
+
+	// The prefix is added once per multi-line event, and seen by middleware.
+	w.Equal([]string{"checkout panic: boom\n\tmain.go:12\n"}, seen)
+	w.Equal(seen, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestMessagePrefixSizeLimit() {
+	prefix := "checkout"
+	writer := w.newUnstartedWriter(w.ctx, WithMessagePrefix(prefix, ""), WithOversizePolicy(OversizePolicyError))
+
+	// The prefix counts towards the limit.
+	_, err := writer.Write(bytes.Repeat([]byte("a"), maxEventMessageBytes-len(prefix)-1))
+	w.NoError(err)
+	_, err = writer.Write(bytes.Repeat([]byte("a"), maxEventMessageBytes-len(prefix)))
+	w.Equal(ErrEventTooLarge, err)
+
+	writer = w.newUnstartedWriter(w.ctx, WithMessagePrefix(prefix, ""))
+	_, err = writer.Write(bytes.Repeat([]byte("a"), maxEventMessageBytes))
+	w.NoError(err)
+	messages := drainMessages(writer)
+	w.Require().Len(messages, 1)
+	w.Len(messages[0], maxEventMessageBytes)
+	w.True(strings.HasPrefix(messages[0], prefix+" "))
+	w.True(strings.HasSuffix(messages[0], truncatedMarker))
+}
+
+func (w *writerTestSuite) TestStripNewlines() {
+	writer := w.newUnstartedWriter(w.ctx, WithStripNewlines())
+
+	_, err := io.WriteString(writer, "Hello\r\n\nWorld\n")
+	w.Require().NoError(err)
+
+	// The blank line is dropped.
+	w.Equal([]string{"Hello", "World"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestNewlineReplacement() {
+	for _, test := range []struct {
+		opts     []CreateOption
+		expected string
+	}{
+		{nil, "panic: boom\n\tmain.go:12\r\n"},
+		{[]CreateOption{WithNewlineReplacement(`\n`)}, "panic: boom\\n\tmain.go:12\r\n"},
+		{[]CreateOption{WithNewlineReplacement(" | "), WithStripNewlines()}, "panic: boom | \tmain.go:12"},
+	} {
+		opts := append(test.opts, WithMultiLineStart(regexp.MustCompile(`^\S`)))
+		writer := w.newUnstartedWriter(w.ctx, opts...)
+
+		_, err := io.WriteString(writer, "panic: boom\n\tmain.go:12\r\n")
+		w.Require().NoError(err)
+		writer.flushPending()
+
+		w.Equal([]string{test.expected}, drainMessages(writer))
+	}
+}
+
+func (w *writerTestSuite) TestDeduplication() {
+	now := time.Unix(1, 0)
+	writer := w.newUnstartedWriter(w.ctx, WithDeduplication(time.Second))
+	writer.nowFunc = func() time.Time { return now }
+
+	// Messages sharing a prefix are not duplicates.
+	_, err := io.WriteString(writer, "ok\nok\nok\nok 200\n")
+	w.Require().NoError(err)
+	w.Equal([]string{"ok\n", "ok 200 [suppressed 2 times]\n"}, drainMessages(writer))
+
+	now = now.Add(999 * time.Millisecond)
+	_, err = io.WriteString(writer, "ok\n")
+	w.Require().NoError(err)
+	w.Empty(drainMessages(writer))
+
+	// The window has expired since "ok" was first seen.
+	now = now.Add(time.Millisecond)
+	_, err = io.WriteString(writer, "ok\n")
+	w.Require().NoError(err)
+	w.Equal([]string{"ok [suppressed 1 times]\n"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestSortEvents() {
+	now := time.Unix(2, 0)
+	writer := w.newUnstartedWriter(w.ctx, WithSortEvents())
+	writer.nowFunc = func() time.Time { return now }
+
+	for _, message := range []string{"b", "c", "a"} {
+		if message == "a" {
+			now = time.Unix(1, 0)
+		}
+		_, err := io.WriteString(writer, message)
+		w.Require().NoError(err)
+	}
+
+	w.Equal([]string{"a", "b", "c"}, drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestDropOutOfRange() {
+	now := time.Unix(1700000000, 0)
+	timestamps := map[string]time.Time{
+		"too old": now.Add(-15 * 24 * time.Hour),
+		"too new": now.Add(3 * time.Hour),
+	}
+
+	// Stale events would otherwise be dropped before being buffered.
+	var dropped []string
+	writer := w.newUnstartedWriter(w.ctx,
+		WithMaxEventAge(30*24*time.Hour),
+		WithEventMiddleware(func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+			if t, ok := timestamps[*event.Message]; ok {
+				event.Timestamp = aws.Int64(toMillis(t))
+			}
+			return event
+		}),
+		WithDeadLetterHandler(func(events []*cloudwatchlogs.InputLogEvent, err error) {
+			w.Equal(ErrEventOutOfRange, err)
+			for _, event := range events {
+				dropped = append(dropped, *event.Message)
+			}
+		}),
+	)
+	writer.nowFunc = func() time.Time { return now }
+
+	for _, message := range []string{"too old", "ok", "too new"} {
+		_, err := io.WriteString(writer, message)
+		w.Require().NoError(err)
+	}
+
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("ok"), Timestamp: aws.Int64(toMillis(now))},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("token")}, nil)
+
+	w.NoError(writer.flushBatch())
+	w.Equal([]string{"too old", "too new"}, dropped)
+}
+
+func (w *writerTestSuite) TestMaxEventAge() {
+	now := time.Unix(1700000000, 0)
+	ages := map[string]time.Duration{
+		"stale": MaxEventAge + time.Millisecond,
+		"old":   MaxEventAge,
+	}
+
+	var dropped []string
+	writer := w.newUnstartedWriter(w.ctx,
+		WithEventMiddleware(func(event *cloudwatchlogs.InputLogEvent) *cloudwatchlogs.InputLogEvent {
+			event.Timestamp = aws.Int64(toMillis(now.Add(-ages[*event.Message])))
+			return event
+		}),
+		WithDeadLetterHandler(func(events []*cloudwatchlogs.InputLogEvent, err error) {
+			w.Equal(ErrEventTooOld, err)
+			for _, event := range events {
+				dropped = append(dropped, *event.Message)
+			}
+		}),
+	)
+	writer.nowFunc = func() time.Time { return now }
+
+	_, err := io.WriteString(writer, "stale")
+	w.Require().NoError(err)
+	_, err = io.WriteString(writer, "old")
+	w.Require().NoError(err)
+
+	w.Equal([]string{"stale"}, dropped)
+	w.Equal([]string{"old"}, drainMessages(writer))
+
+	// A lower maximum age applies instead.
+	WithMaxEventAge(time.Hour)(writer)
+	_, err = io.WriteString(writer, "old")
+	w.Require().NoError(err)
+
+	w.Equal([]string{"stale", "old"}, dropped)
+	w.Empty(drainMessages(writer))
+}
+
+func (w *writerTestSuite) TestRotateAfterBytes() {
+	var rotations [][2]string
+	writer := w.newUnstartedWriter(w.ctx,
+		WithRotateAfterBytes(10),
+		WithOnRotate(func(old, new string) {
+			rotations = append(rotations, [2]string{old, new})
+		}),
+	)
+
+	const rotated = "streamName-1970-01-01T00-00-01"
+
+	w.api.On(
+		"CreateLogStreamWithContext",
+		w.ctx,
+		&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(rotated),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil)
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Require().NoError(err)
+	w.Empty(rotations)
+
+	// This write crosses the threshold, so "Hello" and "World" are sent to the
+	// original stream before rotating.
+	w.api.On(
+		"PutLogEventsWithContext",
+		w.ctx,
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)},
+				{Message: aws.String("World"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(w.streamName),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("token")}, nil)
+
+	_, err = io.WriteString(writer, "World")
+	w.Require().NoError(err)
+
+	w.Equal([][2]string{{w.streamName, rotated}}, rotations)
+	w.Equal(rotated, *writer.streamName)
+	w.Nil(writer.sequenceToken)
+	w.Zero(writer.writtenBytes)
+}
+
+func (w *writerTestSuite) TestReset() {
+	const newStream = "newStreamName"
+
+	w.api.On(
+		"CreateLogStreamWithContext",
+		w.ctx,
+		&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(w.groupName),
+			LogStreamName: aws.String(newStream),
+		},
+		[]request.Option(nil),
+	).Once().Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil)
+
+	var (
+		mu       sync.Mutex
+		received = make(map[string]int)
+	)
+	w.api.On(
+		"PutLogEventsWithContext",
+		mock.Anything,
+		mock.Anything,
+		[]request.Option(nil),
+	).Run(func(args mock.Arguments) {
+		input := args.Get(1).(*cloudwatchlogs.PutLogEventsInput)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, event := range input.LogEvents {
+			received[*event.Message]++
+		}
+	}).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	const writers, lines = 4, 100
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < lines; j++ {
+				_, err := fmt.Fprintf(w.sut, "%d-%d\n", i, j)
+				w.NoError(err)
+			}
+		}(i)
+	}
+
+	w.NoError(w.sut.Reset(w.ctx, newStream))
+	wg.Wait()
+	w.NoError(w.sut.Close())
+
+	w.Equal(newStream, *w.sut.(*writerImpl).streamName)
+	w.Len(received, writers*lines)
+	for message, count := range received {
+		w.Equal(1, count, message)
+	}
+}
+
+func (w *writerTestSuite) TestRotateAfterDuration() {
+	now := time.Unix(1, 0)
+	writer := w.newUnstartedWriter(w.ctx, WithRotateAfterDuration(time.Hour))
+	writer.nowFunc = func() time.Time { return now }
+
+	w.False(writer.shouldRotate(5))
+
+	now = now.Add(time.Hour)
+	w.True(writer.shouldRotate(5))
+}
+
+// fakeWriter is an io.WriteCloser recording what's written to it.
+type fakeWriter struct {
+	bytes.Buffer
+	writeErr, closeErr error
+	closed             bool
+}
+
+func (f *fakeWriter) Write(b []byte) (int, error) {
+	f.Buffer.Write(b)
+	return len(b), f.writeErr
+}
+
+func (f *fakeWriter) Close() error {
+	f.closed = true
+	return f.closeErr
+}
+
+func (w *writerTestSuite) TestTee() {
+	cw, local := new(fakeWriter), new(fakeWriter)
+	writer := Tee(cw, local)
+
+	n, err := io.WriteString(writer, "Hello")
+
+	w.NoError(err)
+	w.Equal(5, n)
+	w.Equal("Hello", cw.String())
+	w.Equal("Hello", local.String())
+
+	w.NoError(writer.Close())
+	w.True(cw.closed)
+	w.True(local.closed)
+}
+
+func (w *writerTestSuite) TestTeeErrors() {
+	cwErr, localErr := errors.New("cw"), errors.New("local")
+
+	cw, local := &fakeWriter{writeErr: cwErr}, &fakeWriter{writeErr: localErr}
+	_, err := io.WriteString(Tee(cw, local), "Hello")
+	w.Equal(cwErr, err)
+	w.Equal("Hello", local.String(), "the local writer is written to regardless")
+
+	cw.writeErr = nil
+	_, err = io.WriteString(Tee(cw, local), "Hello")
+	w.Equal(localErr, err)
+
+	local.closeErr = localErr
+	w.Equal(localErr, Tee(cw, local).Close())
+
+	cw.closeErr = cwErr
+	w.Equal(cwErr, Tee(cw, local).Close())
+	w.True(local.closed)
+}
+
+func (w *writerTestSuite) TestTeeLocalNotCloser() {
+	var local bytes.Buffer
+	writer := Tee(new(fakeWriter), &local)
+
+	w.NoError(writer.Close())
+}
+
+func (w *writerTestSuite) TestFork() {
+	first, second := new(fakeWriter), new(fakeWriter)
+	writer := Fork(first, second)
+
+	n, err := io.WriteString(writer, "Hello")
+
+	w.NoError(err)
+	w.Equal(5, n)
+	w.Equal("Hello", first.String())
+	w.Equal("Hello", second.String())
+
+	w.NoError(writer.Close())
+	w.True(first.closed)
+	w.True(second.closed)
+}
+
+func (w *writerTestSuite) TestForkErrors() {
+	writeErr, closeErr := errors.New("write"), errors.New("close")
+
+	first, second := &fakeWriter{writeErr: writeErr, closeErr: closeErr}, new(fakeWriter)
+	writer := Fork(first, second)
+
+	_, err := io.WriteString(writer, "Hello")
+	w.Equal(MultiError{writeErr}, err)
+	w.Equal("Hello", second.String(), "other writers are written to regardless")
+
+	w.Equal(MultiError{closeErr}, writer.Close())
+	w.True(second.closed)
+}
+
+// fakeEventsBuffer replaces a writer's buffer, each call to add or drain
+// consuming the next configured behavior. Requeued batches are drained again
+// before the next behavior.
+type fakeEventsBuffer struct {
+	mu        sync.Mutex
+	behaviors []fakeBehavior
+	added     []*cloudwatchlogs.InputLogEvent
+	requeued  [][]*cloudwatchlogs.InputLogEvent
+}
+
+type fakeBehavior struct {
+	events []*cloudwatchlogs.InputLogEvent
+	err    error
+}
+
+// ReturnEvents makes the next call return events.
+func (b *fakeEventsBuffer) ReturnEvents(events ...*cloudwatchlogs.InputLogEvent) *fakeEventsBuffer {
+	b.behaviors = append(b.behaviors, fakeBehavior{events: events})
+	return b
+}
+
+// ReturnError makes the next call to add fail with err.
+func (b *fakeEventsBuffer) ReturnError(err error) *fakeEventsBuffer {
+	b.behaviors = append(b.behaviors, fakeBehavior{err: err})
+	return b
+}
+
+func (b *fakeEventsBuffer) next() fakeBehavior {
+	if len(b.behaviors) == 0 {
+		return fakeBehavior{}
+	}
+	ret := b.behaviors[0]
+	b.behaviors = b.behaviors[1:]
+	return ret
+}
+
+func (b *fakeEventsBuffer) add(_ context.Context, event *cloudwatchlogs.InputLogEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.next().err; err != nil {
+		return err
+	}
+	b.added = append(b.added, event)
+	return nil
+}
+
+func (b *fakeEventsBuffer) addUnbounded(event *cloudwatchlogs.InputLogEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.added = append(b.added, event)
+}
+
+func (b *fakeEventsBuffer) drain() [][]*cloudwatchlogs.InputLogEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ret := b.requeued; ret != nil {
+		b.requeued = nil
+		return ret
+	}
+	if events := b.next().events; len(events) > 0 {
+		return [][]*cloudwatchlogs.InputLogEvent{events}
+	}
+	return nil
+}
+
+func (b *fakeEventsBuffer) requeue(batches [][]*cloudwatchlogs.InputLogEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.requeued = append(batches, b.requeued...)
+}
+
+func (b *fakeEventsBuffer) len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var ret int
+	for _, batch := range b.requeued {
+		ret += len(batch)
+	}
+	for _, behavior := range b.behaviors {
+		ret += len(behavior.events)
+	}
+	return ret
+}
+
+func (b *fakeEventsBuffer) hasMore() bool {
+	return b.len() > 0
+}
+
+func TestFlushBatchErrors(t *testing.T) {
+	event := &cloudwatchlogs.InputLogEvent{Message: aws.String("Hello"), Timestamp: aws.Int64(1000)}
+	future := &cloudwatchlogs.InputLogEvent{Message: aws.String("Later"), Timestamp: aws.Int64(toMillis(time.Unix(1, 0).Add(3 * time.Hour)))}
+	boom := errors.New("boom")
+
+	testCases := []struct {
+		name         string
+		buffer       *fakeEventsBuffer
+		opts         []CreateOption
+		putErr       error
+		putOutput    *cloudwatchlogs.PutLogEventsOutput
+		expectPut    bool
+		expectErr    error
+		expectRemain int
+	}{
+		{
+			name:      "request fails",
+			buffer:    new(fakeEventsBuffer).ReturnEvents(event),
+			putErr:    boom,
+			expectPut: true,
+			expectErr: boom,
+		},
+		{
+			name:         "request fails with a circuit breaker",
+			buffer:       new(fakeEventsBuffer).ReturnEvents(event),
+			opts:         []CreateOption{WithCircuitBreaker(3, time.Minute)},
+			putErr:       boom,
+			expectPut:    true,
+			expectErr:    boom,
+			expectRemain: 1,
+		},
+		{
+			name:   "events rejected",
+			buffer: new(fakeEventsBuffer).ReturnEvents(event),
+			putOutput: &cloudwatchlogs.PutLogEventsOutput{
+				RejectedLogEventsInfo: &cloudwatchlogs.RejectedLogEventsInfo{TooOldLogEventEndIndex: aws.Int64(1)},
+			},
+			expectPut: true,
+			expectErr: &RejectedLogEventsInfoError{},
+		},
+		{
+			name:   "events out of range",
+			buffer: new(fakeEventsBuffer).ReturnEvents(future),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := new(mockAPI)
+			if tc.expectPut {
+				output := tc.putOutput
+				if output == nil && tc.putErr == nil {
+					output = &cloudwatchlogs.PutLogEventsOutput{}
+				}
+				api.On(
+					"PutLogEventsWithContext",
+					context.Background(),
+					mock.Anything,
+					[]request.Option(nil),
+				).Return(output, tc.putErr)
+			}
+
+			writer := &writerImpl{
+				client:     api,
+				ctx:        context.Background(),
+				events:     newEventsBuffer(),
+				groupName:  aws.String("groupName"),
+				nowFunc:    func() time.Time { return time.Unix(1, 0) },
+				retry:      newBackoff(),
+				store:      tc.buffer,
+				streamName: aws.String("streamName"),
+			}
+			for _, opt := range append(tc.opts, WithDeadLetterHandler(func([]*cloudwatchlogs.InputLogEvent, error) {})) {
+				opt(writer)
+			}
+
+			err := writer.flushBatch()
+			if tc.expectErr == nil {
+				require.NoError(t, err)
+			} else if _, ok := tc.expectErr.(*RejectedLogEventsInfoError); ok {
+				require.IsType(t, tc.expectErr, err)
+			} else {
+				require.Equal(t, tc.expectErr, err)
+			}
+			require.Equal(t, tc.expectRemain, tc.buffer.len())
+			api.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWriteBufferError(t *testing.T) {
+	full := errors.New("buffer full")
+	writer := &writerImpl{
+		ctx:     context.Background(),
+		events:  newEventsBuffer(),
+		nowFunc: func() time.Time { return time.Unix(1, 0) },
+		store:   new(fakeEventsBuffer).ReturnEvents().ReturnError(full),
+	}
+
+	_, err := writer.WriteString("Hello\nWorld\n")
+	require.Equal(t, full, err)
+}
+
+// sliceEventBuffer is a minimal custom EventBuffer.
+type sliceEventBuffer struct {
+	mu     sync.Mutex
+	events []*cloudwatchlogs.InputLogEvent
+}
+
+func (b *sliceEventBuffer) Add(_ context.Context, event *cloudwatchlogs.InputLogEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *sliceEventBuffer) Drain() []*cloudwatchlogs.InputLogEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ret := b.events
+	b.events = nil
+	return ret
+}
+
+func (b *sliceEventBuffer) HasMore() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.events) > 0
+}
+
+func TestEventBuffers(t *testing.T) {
+	testCases := []struct {
+		name string
+		buf  EventBuffer
+	}{
+		{"memory", NewMemoryEventBuffer()},
+		{"bounded", NewBoundedEventBuffer(3)},
+		{"custom", new(sliceEventBuffer)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.False(t, tc.buf.HasMore())
+
+			var events []*cloudwatchlogs.InputLogEvent
+			for i := 0; i < 3; i++ {
+				event := &cloudwatchlogs.InputLogEvent{Message: aws.String(fmt.Sprint(i)), Timestamp: aws.Int64(1000)}
+				require.NoError(t, tc.buf.Add(context.Background(), event))
+				events = append(events, event)
+			}
+
+			require.True(t, tc.buf.HasMore())
+			require.Equal(t, events, tc.buf.Drain())
+			require.False(t, tc.buf.HasMore())
+			require.Empty(t, tc.buf.Drain())
+		})
+	}
+}
+
+func TestBoundedEventBuffer(t *testing.T) {
+	testCases := []struct {
+		name      string
+		maxEvents int
+		expectErr error
+	}{
+		{"has space", 2, nil},
+		{"full", 1, context.DeadlineExceeded},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := NewBoundedEventBuffer(tc.maxEvents)
+			require.NoError(t, buf.Add(context.Background(), &cloudwatchlogs.InputLogEvent{Message: aws.String("Hello")}))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			require.Equal(t, tc.expectErr, buf.Add(ctx, &cloudwatchlogs.InputLogEvent{Message: aws.String("World")}))
+		})
+	}
+}
+
+func TestWithEventBuffer(t *testing.T) {
+	api := new(mockAPI)
+	api.On(
+		"PutLogEventsWithContext",
+		context.Background(),
+		&cloudwatchlogs.PutLogEventsInput{
+			LogEvents: []*cloudwatchlogs.InputLogEvent{
+				{Message: aws.String("Hello\n"), Timestamp: aws.Int64(1000)},
+				{Message: aws.String("World"), Timestamp: aws.Int64(1000)},
+			},
+			LogGroupName:  aws.String("groupName"),
+			LogStreamName: aws.String("streamName"),
+		},
+		[]request.Option(nil),
+	).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
+
+	buf := new(sliceEventBuffer)
+	writer := &writerImpl{
+		client:     api,
+		ctx:        context.Background(),
+		events:     newEventsBuffer(),
+		groupName:  aws.String("groupName"),
+		nowFunc:    func() time.Time { return time.Unix(1, 0) },
+		retry:      newBackoff(),
+		streamName: aws.String("streamName"),
+	}
+	WithEventBuffer(buf)(writer)
+
+	_, err := writer.WriteString("Hello\nWorld")
+	require.NoError(t, err)
+	require.True(t, buf.HasMore())
+
+	require.NoError(t, writer.flushBatch())
+	require.False(t, buf.HasMore())
+	api.AssertExpectations(t)
+}
+
+func TestWithEventBuffer_BatchLimits(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts func(EventBuffer) []CreateOption
+	}{
+		{"limits first", func(buf EventBuffer) []CreateOption {
+			return []CreateOption{WithMaxBatchEvents(2), WithEventBuffer(buf)}
+		}},
+		{"limits last", func(buf EventBuffer) []CreateOption {
+			return []CreateOption{WithEventBuffer(buf), WithMaxBatchEvents(2)}
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			writer := &writerImpl{ctx: context.Background(), events: newEventsBuffer()}
+			buf := new(sliceEventBuffer)
+			for _, opt := range tc.opts(buf) {
+				opt(writer)
+			}
+
+			for i := 0; i < 5; i++ {
+				require.NoError(t, buf.Add(context.Background(), &cloudwatchlogs.InputLogEvent{Message: aws.String(fmt.Sprint(i)), Timestamp: aws.Int64(1000)}))
+			}
+
+			var sizes []int
+			for _, batch := range writer.buffered().drain() {
+				sizes = append(sizes, len(batch))
+			}
+			require.Equal(t, []int{2, 2, 1}, sizes)
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	suite.Run(t, new(writerTestSuite))
+}
+
+func benchmarkWrite(b *testing.B, write func(*writerImpl, string) (int, error)) {
+	writer := &writerImpl{ctx: context.Background(), events: newEventsBuffer()}
+	input := strings.Repeat("level=info msg=\"handled request\" status=200\n", 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := write(writer, input); err != nil {
+			b.Fatal(err)
+		}
+		writer.events.drain()
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	benchmarkWrite(b, func(w *writerImpl, s string) (int, error) {
+		return w.Write([]byte(s))
+	})
+}
+
+func BenchmarkWriteString(b *testing.B) {
+	benchmarkWrite(b, (*writerImpl).WriteString)
+}
+
+func BenchmarkRedactionMiddleware(b *testing.B) {
+	redact := NewRedactionMiddleware([]RedactionRule{RedactEmail(), RedactCreditCard(), RedactIPAddress()})
+	message := `{"level":"info","msg":"payment accepted","email":"bacon@example.com","card":"4111 1111 1111 1111","ip":"10.0.0.1","order_id":12345}`
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		redact(&cloudwatchlogs.InputLogEvent{Message: aws.String(message)})
+	}
+}
+
+// countingStore counts the PutLogEvents requests made to a MemoryStore.
+type countingStore struct {
+	*MemoryStore
+	requests int64
+}
+
+func (s *countingStore) PutLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.PutLogEventsInput, opts ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	atomic.AddInt64(&s.requests, 1)
+	return s.MemoryStore.PutLogEventsWithContext(ctx, input, opts...)
+}
+
+// benchmarkWriter writes b.N events at 10,000 events per second to a writer
+// backed by a MemoryStore, and reports the PutLogEvents requests it made per
+// second and how long Close took to send the remaining events.
+func benchmarkWriter(b *testing.B, opts ...CreateOption) {
+	const (
+		eventsPerSecond = 10000
+		tick            = 10 * time.Millisecond
+		eventsPerTick   = eventsPerSecond * int(tick) / int(time.Second)
+	)
+
+	_, memory := NewMemoryGroup("groupName")
+	store := &countingStore{MemoryStore: memory}
+	writer, err := NewGroup(store, "groupName").Create(context.Background(), "streamName", opts...)
+	require.NoError(b, err)
+
+	line := "level=info msg=\"handled request\" status=200\n"
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	b.ResetTimer()
+	start := time.Now()
+	for written := 0; written < b.N; written += eventsPerTick {
+		n := eventsPerTick
+		if b.N-written < n {
+			n = b.N - written
+		}
+		if _, err := io.WriteString(writer, strings.Repeat(line, n)); err != nil {
+			b.Fatal(err)
+		}
+		<-ticker.C
+	}
+	closing := time.Now()
+	if err := writer.Close(); err != nil {
+		b.Fatal(err)
+	}
+	b.StopTimer()
+	end := time.Now()
+
+	require.Len(b, store.Events("streamName"), b.N)
+	b.ReportMetric(float64(atomic.LoadInt64(&store.requests))/end.Sub(start).Seconds(), "requests/s")
+	b.ReportMetric(float64(end.Sub(closing).Milliseconds()), "close-ms")
+}
+
+func BenchmarkWriterTicker(b *testing.B) {
+	benchmarkWriter(b)
+}
+
+func BenchmarkWriterTokenBucket(b *testing.B) {
+	benchmarkWriter(b, WithTokenBucket(10000, 2000))
 }
@@ -0,0 +1,17 @@
+package cloudwatch
+
+import (
+	"context"
+	"io"
+)
+
+// NewZerologWriter returns an io.WriteCloser to use as the output of a
+// zerolog.Logger, writing to the streamName log stream of g.
+//
+// zerolog writes each entry as a line of JSON, which the writer sends as a
+// single log event, so the fields remain queryable with CloudWatch Logs
+// Insights. Entries are validated as per WithJSONValidation, which doesn't
+// need to be passed in opts.
+func NewZerologWriter(g Group, ctx context.Context, streamName string, opts ...CreateOption) (io.WriteCloser, error) {
+	return g.Create(ctx, streamName, append([]CreateOption{WithJSONValidation()}, opts...)...)
+}